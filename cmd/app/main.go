@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/romeros69/basket/config"
 	"github.com/romeros69/basket/internal/app"
@@ -13,5 +14,19 @@ func main() {
 		log.Fatalf("Config error: %s", err)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err = app.Migrate(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("Migrate error: %s", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		if err = app.Backfill(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("Backfill error: %s", err)
+		}
+		return
+	}
+
 	app.Run(cfg)
 }
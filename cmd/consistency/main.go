@@ -0,0 +1,94 @@
+// Сверка id игроков и матчей между каталогом (Mongo), статистикой (ClickHouse) и графом наград (Neo4j).
+//
+//	go run ./cmd/consistency                 # только отчёт
+//	go run ./cmd/consistency --fix=cascade   # удалить осиротевшие строки статистики и узлы графа
+//	go run ./cmd/consistency --fix=tombstone # пометить осиротевшие узлы графа удалёнными
+//
+// Код выхода 1, если остались неисправленные расхождения
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/romeros69/basket/config"
+	"github.com/romeros69/basket/internal/app"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+func main() {
+	fix := flag.String("fix", "", "repair policy: cascade or tombstone; empty - report only")
+	limit := flag.Int("limit", 20, "ids to print per finding, 0 - all")
+	asJSON := flag.Bool("json", false, "print the report as json")
+	flag.Parse()
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("Config error: %s", err)
+	}
+
+	for _, storage := range []string{cfg.Storage.Catalog, cfg.Storage.AwardsGraph, cfg.Storage.PlayerStats} {
+		if storage == config.StorageMemory {
+			log.Printf("storage %q lives only inside the app process, the tool sees it empty", config.StorageMemory)
+			break
+		}
+	}
+
+	report, err := app.CheckConsistency(context.Background(), cfg, entity.ConsistencyRequest{
+		Fix:   entity.DeletionPolicy(*fix),
+		Limit: *limit,
+	})
+	if err != nil {
+		log.Fatalf("Consistency error: %s", err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(report); err != nil {
+			log.Fatalf("Encode error: %s", err)
+		}
+	} else {
+		printReport(report)
+	}
+
+	if !report.Consistent() {
+		os.Exit(1)
+	}
+}
+
+func printReport(report *entity.ConsistencyReport) {
+	for _, entityKind := range []string{entity.DeletionEntityPlayer, entity.DeletionEntityGame} {
+		counts := report.Counts[entityKind]
+		fmt.Printf("%s: catalog=%d tombstones=%d player_stats=%d awards_graph=%d\n",
+			entityKind, counts.Catalog, counts.Tombstones, counts.Stats, counts.Graph)
+	}
+
+	if len(report.Findings) == 0 {
+		fmt.Println("stores are consistent")
+		return
+	}
+
+	for _, finding := range report.Findings {
+		severity := "ERROR"
+		if finding.Info {
+			severity = "info"
+		}
+		fmt.Printf("\n%s %s %s in %s: %d", severity, finding.Entity, finding.Kind, finding.Store, finding.Count)
+		if finding.Fixed != 0 {
+			fmt.Printf(", fixed %d", finding.Fixed)
+		}
+		fmt.Println()
+
+		ids := strings.Join(finding.IDs, " ")
+		if len(finding.IDs) < finding.Count {
+			ids += " ..."
+		}
+		fmt.Println("  " + ids)
+	}
+}
@@ -23,6 +23,13 @@ type Game struct {
 	Type       string `json:"type"`
 }
 
+type Team struct {
+	Name         string `json:"name"`
+	City         string `json:"city"`
+	Abbreviation string `json:"abbreviation"`
+	Conference   string `json:"conference"`
+}
+
 type League struct {
 	Name   string `json:"name"`
 	Season string `json:"season"`
@@ -40,14 +47,23 @@ type Player struct {
 }
 
 type PlayerStat struct {
-	Assists       int     `json:"assists"`
-	AvgGoals      float64 `json:"avgGoals"`
-	Goals         int     `json:"goals"`
-	Interceptions int     `json:"interceptions"`
-	MatchID       string  `json:"matchId"`
-	PlayerID      string  `json:"playerId"`
-	Rebounds      int     `json:"rebounds"`
-	TotalAvgStats float64 `json:"totalAvgStats"`
+	Assists           int     `json:"assists"`
+	Blocks            int     `json:"blocks"`
+	DefensiveRebounds int     `json:"defensiveRebounds"`
+	FGA               int     `json:"fga"`
+	FGM               int     `json:"fgm"`
+	FTA               int     `json:"fta"`
+	FTM               int     `json:"ftm"`
+	MatchID           string  `json:"matchId"`
+	Minutes           float64 `json:"minutes"`
+	OffensiveRebounds int     `json:"offensiveRebounds"`
+	PersonalFouls     int     `json:"personalFouls"`
+	PlayerID          string  `json:"playerId"`
+	PlusMinus         int     `json:"plusMinus"`
+	Steals            int     `json:"steals"`
+	ThreePA           int     `json:"threePa"`
+	ThreePM           int     `json:"threePm"`
+	Turnovers         int     `json:"turnovers"`
 }
 
 type RewardStat struct {
@@ -61,6 +77,10 @@ type CreatePlayerResp struct {
 	PlayerID string `json:"playerID"`
 }
 
+type CreateTeamResp struct {
+	TeamID string `json:"team_id"`
+}
+
 type CreateGameResp struct {
 	GameID string `json:"game_id"`
 }
@@ -75,7 +95,9 @@ type CreateLeagueResp struct {
 
 var (
 	playerIDs   []string
+	teamIDs     []string
 	gameIDs     []string
+	gameLeagues []string // лига каждого матча из gameIDs
 	awardIDs    []string
 	leagueIDs   []string
 	playerStats []PlayerStat
@@ -95,6 +117,20 @@ func randomString(length int) string {
 }
 
 // Генерация данных для сущностей
+func generateTeam() Team {
+	return Team{
+		Name:         "LA Lakers " + randomString(4),
+		City:         "Los Angeles",
+		Abbreviation: "LAL",
+		Conference:   "West",
+	}
+}
+
+// randomTeam - случайная команда из созданных
+func randomTeam() string {
+	return teamIDs[rand.Intn(len(teamIDs))]
+}
+
 func generatePlayer() Player {
 	return Player{
 		Age:         30,
@@ -103,17 +139,25 @@ func generatePlayer() Player {
 		Name:        "LeBron",
 		Role:        "Forward",
 		Surname:     "James",
-		Team:        "LA Lakers",
+		Team:        randomTeam(),
 		Weight:      113,
 	}
 }
 
 func generateGame() Game {
+	firstTeam, secondTeam := randomTeam(), randomTeam()
+	for secondTeam == firstTeam {
+		secondTeam = randomTeam()
+	}
+	var league string
+	if len(leagueIDs) != 0 {
+		league = leagueIDs[rand.Intn(len(leagueIDs))]
+	}
 	return Game{
 		Date:       "12.03.24",
-		FirstTeam:  "LA Lakers",
-		SecondTeam: "Chicago Bulls",
-		League:     "NBA",
+		FirstTeam:  firstTeam,
+		SecondTeam: secondTeam,
+		League:     league,
 		Type:       "Final",
 	}
 }
@@ -161,8 +205,60 @@ func sendPostRequest(url string, data interface{}, responseStruct interface{}) e
 	return nil
 }
 
+// Сколько строк статистики игроков отправляется одним запросом
+const statBatchSize = 1000
+
+// StatBatchResult - итог пакетной вставки статистики
+type StatBatchResult struct {
+	Inserted int `json:"inserted"`
+	Failed   int `json:"failed"`
+}
+
+// Отправка пачки статистики игроков
+func sendStatBatch(stats []PlayerStat) {
+	jsonData, err := json.Marshal(stats)
+	if err != nil {
+		log.Printf("Ошибка маршалинга данных: %v", err)
+		return
+	}
+
+	resp, err := http.Post(apiBase+"/stat_player/batch", "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		log.Printf("Ошибка отправки пачки статистики: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("Ошибка: неверный статус код %d при отправке пачки статистики", resp.StatusCode)
+		return
+	}
+
+	var result StatBatchResult
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		log.Printf("Ошибка декодирования ответа: %v", err)
+		return
+	}
+	if result.Failed != 0 {
+		log.Printf("Пачка статистики: вставлено %d, с ошибками %d", result.Inserted, result.Failed)
+	}
+}
+
 // Отправка запросов на создание сущностей и сбор ID
 func createEntities() {
+	// Создание команд
+	for i := 0; i < 30; i++ {
+		var resp CreateTeamResp
+		err := sendPostRequest(apiBase+"/team", generateTeam(), &resp)
+		if err == nil {
+			teamIDs = append(teamIDs, resp.TeamID)
+		}
+	}
+	if len(teamIDs) < 2 {
+		log.Println("Ошибка: для генерации игроков и игр нужно хотя бы две команды")
+		return
+	}
+
 	// Создание игроков
 	for i := 0; i < 100000; i++ {
 		var resp CreatePlayerResp
@@ -172,12 +268,23 @@ func createEntities() {
 		}
 	}
 
+	// Создание лиг
+	for i := 0; i < 100000; i++ {
+		var resp CreateLeagueResp
+		err := sendPostRequest(apiBase+"/league", generateLeague(), &resp)
+		if err == nil {
+			leagueIDs = append(leagueIDs, resp.LeagueID)
+		}
+	}
+
 	// Создание игр
 	for i := 0; i < 100000; i++ {
 		var resp CreateGameResp
-		err := sendPostRequest(apiBase+"/game", generateGame(), &resp)
+		game := generateGame()
+		err := sendPostRequest(apiBase+"/game", game, &resp)
 		if err == nil {
 			gameIDs = append(gameIDs, resp.GameID)
+			gameLeagues = append(gameLeagues, game.League)
 		}
 	}
 
@@ -190,15 +297,6 @@ func createEntities() {
 		}
 	}
 
-	// Создание лиг
-	for i := 0; i < 100000; i++ {
-		var resp CreateLeagueResp
-		err := sendPostRequest(apiBase+"/league", generateLeague(), &resp)
-		if err == nil {
-			leagueIDs = append(leagueIDs, resp.LeagueID)
-		}
-	}
-
 	fmt.Println("Создание всех сущностей завершено")
 }
 
@@ -209,30 +307,46 @@ func generatePlayerStat() PlayerStat {
 		log.Println("Ошибка: playerIDs или gameIDs пусты!")
 		return PlayerStat{}
 	}
+	// Попадания не больше попыток, трёхочковые входят в броски с игры
+	threePA := rand.Intn(12)
+	threePM := rand.Intn(threePA + 1)
+	fga := threePA + rand.Intn(20)
+	fgm := threePM + rand.Intn(fga-threePA+1)
+	fta := rand.Intn(12)
 	return PlayerStat{
-		Assists:       rand.Intn(10),
-		AvgGoals:      rand.Float64() * 30,
-		Goals:         rand.Intn(50),
-		Interceptions: rand.Intn(10),
-		MatchID:       gameIDs[rand.Intn(len(gameIDs))],   // Переиспользование случайного gameID
-		PlayerID:      playerIDs[rand.Intn(len(playerIDs))], // Переиспользование случайного playerID
-		Rebounds:      rand.Intn(20),
-		TotalAvgStats: rand.Float64() * 100,
+		Assists:           rand.Intn(10),
+		Blocks:            rand.Intn(5),
+		DefensiveRebounds: rand.Intn(12),
+		FGA:               fga,
+		FGM:               fgm,
+		FTA:               fta,
+		FTM:               rand.Intn(fta + 1),
+		MatchID:           gameIDs[rand.Intn(len(gameIDs))], // Переиспользование случайного gameID
+		Minutes:           float64(rand.Intn(480)) / 10,
+		OffensiveRebounds: rand.Intn(6),
+		PersonalFouls:     rand.Intn(7),
+		PlayerID:          playerIDs[rand.Intn(len(playerIDs))], // Переиспользование случайного playerID
+		PlusMinus:         rand.Intn(41) - 20,
+		Steals:            rand.Intn(5),
+		ThreePA:           threePA,
+		ThreePM:           threePM,
+		Turnovers:         rand.Intn(6),
 	}
 }
 
-// Генерация RewardStat с использованием созданных playerID и gameID
+// Генерация RewardStat с использованием созданных playerID, gameID, awardID и лиги матча
 func generateRewardStat() RewardStat {
-	// Проверяем, что playerIDs и gameIDs содержат хотя бы одну запись
-	if len(playerIDs) == 0 || len(gameIDs) == 0 {
-		log.Println("Ошибка: playerIDs или gameIDs пусты!")
+	// Проверяем, что playerIDs, gameIDs и awardIDs содержат хотя бы одну запись
+	if len(playerIDs) == 0 || len(gameIDs) == 0 || len(awardIDs) == 0 {
+		log.Println("Ошибка: playerIDs, gameIDs или awardIDs пусты!")
 		return RewardStat{}
 	}
+	game := rand.Intn(len(gameIDs))
 	return RewardStat{
-		Match:      gameIDs[rand.Intn(len(gameIDs))],   // Переиспользование случайного gameID
+		Match:      gameIDs[game],                        // Переиспользование случайного gameID
 		Player:     playerIDs[rand.Intn(len(playerIDs))], // Переиспользование случайного playerID
-		Reward:     "Reward " + randomString(8),         // Случайная строка для награды
-		Tournament: "Tournament " + randomString(8),     // Случайная строка для турнира
+		Reward:     awardIDs[rand.Intn(len(awardIDs))],   // Награда из каталога
+		Tournament: gameLeagues[game],                    // Турнир - лига, в которой сыгран матч
 	}
 }
 
@@ -244,18 +358,26 @@ func createStats() {
 		return
 	}
 
-	// Создание статистики игроков
+	// Создание статистики игроков пачками
+	batch := make([]PlayerStat, 0, statBatchSize)
 	for i := 0; i < 100000; i++ {
 		playerStat := generatePlayerStat()
 		if playerStat.PlayerID != "" && playerStat.MatchID != "" {
-			sendPostRequest(apiBase+"/stat_player", playerStat, nil)
+			batch = append(batch, playerStat)
+		}
+		if len(batch) == statBatchSize {
+			sendStatBatch(batch)
+			batch = batch[:0]
 		}
 	}
+	if len(batch) != 0 {
+		sendStatBatch(batch)
+	}
 
 	// Создание статистики наград
 	for i := 0; i < 100000; i++ {
 		rewardStat := generateRewardStat()
-		if rewardStat.Player != "" && rewardStat.Match != "" {
+		if rewardStat.Player != "" && rewardStat.Match != "" && rewardStat.Tournament != "" {
 			sendPostRequest(apiBase+"/stat_awards", rewardStat, nil)
 		}
 	}
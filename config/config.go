@@ -2,17 +2,39 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 const pathToConfig = "./config/config.yml"
 
+const (
+	StorageMemory     = "memory"
+	StorageMongo      = "mongo"
+	StorageNeo4j      = "neo4j"
+	StorageClickHouse = "clickhouse"
+)
+
+// Neo4jLogOff - отключает логирование драйвера neo4j
+const Neo4jLogOff = "off"
+
+// Политики удаления сущностей каталога
+const (
+	DeletionReject    = "reject"
+	DeletionCascade   = "cascade"
+	DeletionTombstone = "tombstone"
+)
+
 type (
 	Config struct {
 		App   `yaml:"app"`
 		HTTP  `yaml:"http"`
 		Log   `yaml:"logger"`
+		Storage `yaml:"storage"`
+		Profile `yaml:"profile"`
+		Deletion `yaml:"deletion"`
+		Outbox `yaml:"outbox"`
 		Mongo `yaml:"mongo"`
 		Neo4j `yaml:"neo4j"`
 		ClickHouse `yaml:"clickhouse"`
@@ -27,19 +49,70 @@ type (
 		Port string `env-required:"true" yaml:"port" env:"HTTP_PORT"`
 	}
 
+	// Storage - выбор хранилища для каждого домена: catalog (players/teams/awards/games/leagues),
+	// awards_graph (граф наград) и player_stats (статистика игроков). Сущности каталога ссылаются друг на друга
+	// и всегда живут в одном хранилище, по отдельности их не выбрать
+	Storage struct {
+		Catalog     string `yaml:"catalog"      env:"STORAGE_CATALOG"      env-default:"mongo"`
+		AwardsGraph string `yaml:"awards_graph" env:"STORAGE_AWARDS_GRAPH" env-default:"neo4j"`
+		PlayerStats string `yaml:"player_stats" env:"STORAGE_PLAYER_STATS" env-default:"clickhouse"`
+	}
+
+	// Profile - сколько ждать каждое хранилище при сборке профиля игрока
+	Profile struct {
+		CatalogTimeout time.Duration `yaml:"catalog_timeout" env:"PROFILE_CATALOG_TIMEOUT" env-default:"2s"`
+		StatsTimeout   time.Duration `yaml:"stats_timeout"   env:"PROFILE_STATS_TIMEOUT"   env-default:"3s"`
+		AwardsTimeout  time.Duration `yaml:"awards_timeout"  env:"PROFILE_AWARDS_TIMEOUT"  env-default:"3s"`
+	}
+
+	// Deletion - политика удаления игроков и матчей: reject - отказ, если есть статистика, награды или события,
+	// cascade - удаление из всех хранилищ, tombstone - удаление из каталога с сохранением истории.
+	// Упавшие задачи удаления повторяются раз в retry_interval
+	Deletion struct {
+		PlayerPolicy  string        `yaml:"player_policy"  env:"DELETION_PLAYER_POLICY"  env-default:"reject"`
+		GamePolicy    string        `yaml:"game_policy"    env:"DELETION_GAME_POLICY"    env-default:"reject"`
+		RetryInterval time.Duration `yaml:"retry_interval" env:"DELETION_RETRY_INTERVAL" env-default:"1m"`
+	}
+
+	// Outbox - relay событий каталога из mongo в граф наград и измерения ClickHouse:
+	// раз в relay_interval берётся до batch_size событий, событие паркуется после max_attempts неудачных попыток.
+	// Обработанные события mongo удаляет по TTL-индексу через retention, необработанные и запаркованные хранятся
+	Outbox struct {
+		RelayInterval time.Duration `yaml:"relay_interval" env:"OUTBOX_RELAY_INTERVAL" env-default:"1s"`
+		BatchSize     int64         `yaml:"batch_size"     env:"OUTBOX_BATCH_SIZE"     env-default:"100"`
+		MaxAttempts   int           `yaml:"max_attempts"   env:"OUTBOX_MAX_ATTEMPTS"   env-default:"10"`
+		Retention     time.Duration `yaml:"retention"      env:"OUTBOX_RETENTION"      env-default:"168h"`
+	}
+
 	Mongo struct {
-		MongoURL string `env-required:"true" yaml:"mongo_url" env:"MONGO_URL"`
-		MongoDB  string `env-required:"true" yaml:"mongo_db" env:"MONGO_DB"`
+		MongoURL string `yaml:"mongo_url" env:"MONGO_URL"`
+		MongoDB  string `yaml:"mongo_db" env:"MONGO_DB"`
 	}
 	
-    ClickHouse struct {
-		ClickHouseURL string `env-required:"true" yaml:"clickhouse_url" env:"CLICKHOUSE_URL"`
+	// ClickHouse - статистика игроков пишется пачками: по batch_size строк или раз в flush_interval,
+	// в очереди ждут не больше queue_size строк, дальше запись блокируется.
+	// auto_migrate - применять миграции схемы при старте; по умолчанию выключен: несколько экземпляров
+	// не должны мигрировать одновременно, а миграции с пересозданием таблиц (0005) требуют остановить запись,
+	// поэтому схему обновляет команда migrate, запущенная один раз при остановленных экземплярах.
+	// cluster_mode - схема из реплицируемых таблиц ON CLUSTER '{cluster}' за Distributed-таблицами;
+	// на узлах нужны макросы cluster, shard и replica. Выключен по умолчанию; у кластерной схемы свой журнал
+	// миграций, и включить режим на базе со схемой одного узла можно только после переноса данных, см. chouse.Migrator
+	ClickHouse struct {
+		ClickHouseURL string        `yaml:"clickhouse_url" env:"CLICKHOUSE_URL"`
+		ClusterMode   bool          `yaml:"cluster_mode"   env:"CLICKHOUSE_CLUSTER_MODE"   env-default:"false"`
+		AutoMigrate   bool          `yaml:"auto_migrate"   env:"CLICKHOUSE_AUTO_MIGRATE"   env-default:"false"`
+		BatchSize     int           `yaml:"batch_size"     env:"CLICKHOUSE_BATCH_SIZE"     env-default:"1000"`
+		FlushInterval time.Duration `yaml:"flush_interval" env:"CLICKHOUSE_FLUSH_INTERVAL" env-default:"200ms"`
+		QueueSize     int           `yaml:"queue_size"     env:"CLICKHOUSE_QUEUE_SIZE"     env-default:"10000"`
 	}
 
+	// Neo4j - пустой neo4j_login - подключение без аутентификации;
+	// neo4j_log_level - уровень логов драйвера: error, warn, info, debug или off
 	Neo4j struct {
-		Neo4jURL string `env-required:"true" yaml:"neo4j_url" env:"NEO4J_URL"`
-		Neo4jLogin string `env-required:"true" yaml:"neo4j_login" env:"NEO4J_LOGIN"`
-		Neo4jPassword string `env-required:"true" yaml:"neo4j_password" env:"NEO4J_PASSWORD"`
+		Neo4jURL      string `yaml:"neo4j_url"       env:"NEO4J_URL"`
+		Neo4jLogin    string `yaml:"neo4j_login"     env:"NEO4J_LOGIN"`
+		Neo4jPassword string `yaml:"neo4j_password"  env:"NEO4J_PASSWORD"`
+		Neo4jLogLevel string `yaml:"neo4j_log_level" env:"NEO4J_LOG_LEVEL" env-default:"warn"`
 	}
 
 	Log struct {
@@ -60,5 +133,100 @@ func NewConfig() (*Config, error) {
 		return nil, err
 	}
 
+	err = cfg.validateStorage()
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
+	err = cfg.validateDeletion()
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
+	err = cfg.validateOutbox()
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
 	return cfg, nil
 }
+
+// validateStorage - проверяет, что для каждого выбранного хранилища заданы параметры подключения
+func (c *Config) validateStorage() error {
+	switch c.Storage.Catalog {
+	case StorageMemory:
+	case StorageMongo:
+		if c.Mongo.MongoURL == "" || c.Mongo.MongoDB == "" {
+			return fmt.Errorf("storage.catalog is %q, but mongo_url or mongo_db is empty", StorageMongo)
+		}
+	default:
+		return fmt.Errorf("unknown storage.catalog %q", c.Storage.Catalog)
+	}
+
+	switch c.Storage.AwardsGraph {
+	case StorageMemory:
+	case StorageNeo4j:
+		if c.Neo4j.Neo4jURL == "" {
+			return fmt.Errorf("storage.awards_graph is %q, but neo4j_url is empty", StorageNeo4j)
+		}
+		switch c.Neo4j.Neo4jLogLevel {
+		case "error", "warn", "info", "debug", Neo4jLogOff:
+		default:
+			return fmt.Errorf("unknown neo4j_log_level %q", c.Neo4j.Neo4jLogLevel)
+		}
+	default:
+		return fmt.Errorf("unknown storage.awards_graph %q", c.Storage.AwardsGraph)
+	}
+
+	switch c.Storage.PlayerStats {
+	case StorageMemory:
+	case StorageClickHouse:
+		if c.ClickHouse.ClickHouseURL == "" {
+			return fmt.Errorf("storage.player_stats is %q, but clickhouse_url is empty", StorageClickHouse)
+		}
+		if c.ClickHouse.BatchSize <= 0 || c.ClickHouse.QueueSize <= 0 || c.ClickHouse.FlushInterval <= 0 {
+			return fmt.Errorf("clickhouse batch_size, queue_size and flush_interval must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown storage.player_stats %q", c.Storage.PlayerStats)
+	}
+
+	return nil
+}
+
+// validateDeletion - проверяет политики удаления и интервал повтора задач
+func (c *Config) validateDeletion() error {
+	for option, policy := range map[string]string{
+		"deletion.player_policy": c.Deletion.PlayerPolicy,
+		"deletion.game_policy":   c.Deletion.GamePolicy,
+	} {
+		switch policy {
+		case DeletionReject, DeletionCascade, DeletionTombstone:
+		default:
+			return fmt.Errorf("unknown %s %q", option, policy)
+		}
+	}
+
+	if c.Deletion.RetryInterval <= 0 {
+		return fmt.Errorf("deletion.retry_interval must be positive, got %s", c.Deletion.RetryInterval)
+	}
+
+	return nil
+}
+
+// validateOutbox - проверяет параметры relay outbox
+func (c *Config) validateOutbox() error {
+	if c.Outbox.RelayInterval <= 0 {
+		return fmt.Errorf("outbox.relay_interval must be positive, got %s", c.Outbox.RelayInterval)
+	}
+	if c.Outbox.BatchSize <= 0 {
+		return fmt.Errorf("outbox.batch_size must be positive, got %d", c.Outbox.BatchSize)
+	}
+	if c.Outbox.MaxAttempts <= 0 {
+		return fmt.Errorf("outbox.max_attempts must be positive, got %d", c.Outbox.MaxAttempts)
+	}
+	if c.Outbox.Retention < time.Second {
+		return fmt.Errorf("outbox.retention must be at least 1s, got %s", c.Outbox.Retention)
+	}
+	return nil
+}
@@ -1,5 +1,4 @@
-// Package docs GENERATED BY SWAG; DO NOT EDIT
-// This file was generated by swaggo/swag
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
 package docs
 
 import "github.com/swaggo/swag"
@@ -57,6 +56,50 @@ const docTemplate = `{
                 }
             }
         },
+        "/award/grants": {
+            "get": {
+                "description": "Get award list from the catalog with how many times each award was granted and to whom",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "award"
+                ],
+                "summary": "Get award grants",
+                "operationId": "get-award-grants",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter page number",
+                        "name": "page_number",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.AwardGrants"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/award/list": {
             "get": {
                 "description": "Get award list",
@@ -241,6 +284,114 @@ const docTemplate = `{
                             "$ref": "#/definitions/v1.errResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deletion_job/{id}": {
+            "get": {
+                "description": "Get deletion job with the state of each store step",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deletion-job"
+                ],
+                "summary": "Get deletion job",
+                "operationId": "get-deletion-job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter deletion job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.DeletionJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deletion_job/{id}/retry": {
+            "post": {
+                "description": "Continue a failed deletion job from its first unfinished step. A finished job is returned as is\nand a job that is being run right now is rejected",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deletion-job"
+                ],
+                "summary": "Retry deletion job",
+                "operationId": "retry-deletion-job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter deletion job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.DeletionJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -252,7 +403,7 @@ const docTemplate = `{
         },
         "/game": {
             "post": {
-                "description": "Create new game",
+                "description": "Create new scheduled or postponed game. Results are recorded through /game/{id}/result",
                 "consumes": [
                     "application/json"
                 ],
@@ -386,7 +537,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update game by id",
+                "description": "Update game schedule, teams and league by id. Status, score and periods are kept, they change only through /game/{id}/result",
                 "consumes": [
                     "application/json"
                 ],
@@ -435,6 +586,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/v1.errResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -444,7 +601,10 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete game by id",
+                "description": "Delete game by id according to its deletion policy. Stats and award graph records are removed or kept by the policy.\nIf a store fails, the deletion job is returned with 202 and retried in the background",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "game"
                 ],
@@ -460,6 +620,12 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.DeletionJob"
+                        }
+                    },
                     "204": {
                         "description": "No Content"
                     },
@@ -475,46 +641,11 @@ const docTemplate = `{
                             "$ref": "#/definitions/v1.errResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/v1.errResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/league": {
-            "post": {
-                "description": "Create new league",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "league"
-                ],
-                "summary": "Create league",
-                "operationId": "create-league",
-                "parameters": [
-                    {
-                        "description": "Enter new league info",
-                        "name": "league",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.League"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/v1.createLeagueResp"
-                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -525,36 +656,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/league/list": {
+        "/game/{id}/box_score": {
             "get": {
-                "description": "Get league list",
+                "description": "Get player stats derived from the game events so far",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "league"
+                    "game"
                 ],
-                "summary": "Get league list",
-                "operationId": "get-league-list",
+                "summary": "Get game box score",
+                "operationId": "get-game-box-score",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Enter page number",
-                        "name": "page_number",
-                        "in": "query"
+                        "description": "Enter game id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.League"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.PlayerStat"
+                            }
                         }
                     },
                     "400": {
@@ -563,6 +692,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/v1.errResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -572,21 +707,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/league/{id}": {
+        "/game/{id}/events": {
             "get": {
-                "description": "Get league by id",
+                "description": "Get play-by-play events of the game",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "league"
+                    "game"
                 ],
-                "summary": "Get league",
-                "operationId": "get-league",
+                "summary": "Get game events",
+                "operationId": "get-game-events",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter league id",
+                        "description": "Enter game id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -596,7 +731,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.League"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.GameEvent"
+                            }
                         }
                     },
                     "400": {
@@ -619,8 +757,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update league by id",
+            "post": {
+                "description": "Append play-by-play events to the game. When the game goes final, player stats are derived from its events",
                 "consumes": [
                     "application/json"
                 ],
@@ -628,33 +766,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "league"
+                    "game"
                 ],
-                "summary": "Update league",
-                "operationId": "update-league",
+                "summary": "Add game events",
+                "operationId": "add-game-events",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter id league",
+                        "description": "Enter game id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Enter new league info for update",
-                        "name": "league",
+                        "description": "Enter events in order they happened",
+                        "name": "events",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.League"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.GameEvent"
+                            }
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entity.League"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.GameEvent"
+                            }
                         }
                     },
                     "400": {
@@ -669,6 +813,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/v1.errResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -676,26 +832,110 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete league by id",
-                "tags": [
-                    "league"
-                ],
-                "summary": "Delete league",
-                "operationId": "delete-league",
+            }
+        },
+        "/game/{id}/result": {
+            "put": {
+                "description": "Correct score and periods of the final game",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "game"
+                ],
+                "summary": "Correct game result",
+                "operationId": "correct-game-result",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter id league",
+                        "description": "Enter id game",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Enter corrected game result",
+                        "name": "result",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.GameResult"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Game"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Record score, periods and status of the game that is not final yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "game"
+                ],
+                "summary": "Record game result",
+                "operationId": "record-game-result",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter id game",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Enter game result",
+                        "name": "result",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.GameResult"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Game"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -709,6 +949,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/v1.errResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -718,9 +964,90 @@ const docTemplate = `{
                 }
             }
         },
-        "/player": {
+        "/leaders": {
+            "get": {
+                "description": "Get players ranked by a stat; players with equal values share the rank",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "player-stats"
+                ],
+                "summary": "Get stat leaders",
+                "operationId": "get-leaders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter stat",
+                        "name": "stat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter game, total or 36, game by default",
+                        "name": "per",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter season",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter league id",
+                        "name": "league",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter team id",
+                        "name": "team",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter minimum games played",
+                        "name": "min_games",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter number of leaders, 10 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Leader"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/league": {
             "post": {
-                "description": "Create new player",
+                "description": "Create new league",
                 "consumes": [
                     "application/json"
                 ],
@@ -728,18 +1055,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "player"
+                    "league"
                 ],
-                "summary": "Create player",
-                "operationId": "create-player",
+                "summary": "Create league",
+                "operationId": "create-league",
                 "parameters": [
                     {
-                        "description": "Enter new player info",
-                        "name": "player",
+                        "description": "Enter new league info",
+                        "name": "league",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Player"
+                            "$ref": "#/definitions/entity.League"
                         }
                     }
                 ],
@@ -747,7 +1074,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/v1.createPlayerResp"
+                            "$ref": "#/definitions/v1.createLeagueResp"
                         }
                     },
                     "500": {
@@ -759,17 +1086,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/player/list": {
+        "/league/list": {
             "get": {
-                "description": "Get player list",
+                "description": "Get league list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "player"
+                    "league"
                 ],
-                "summary": "Get player list",
-                "operationId": "get-player-list",
+                "summary": "Get league list",
+                "operationId": "get-league-list",
                 "parameters": [
                     {
                         "type": "string",
@@ -788,7 +1115,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Player"
+                            "$ref": "#/definitions/entity.League"
                         }
                     },
                     "400": {
@@ -806,21 +1133,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/player/{id}": {
+        "/league/{id}": {
             "get": {
-                "description": "Get player by id",
+                "description": "Get league by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "player"
+                    "league"
                 ],
-                "summary": "Get player",
-                "operationId": "get-player",
+                "summary": "Get league",
+                "operationId": "get-league",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter player id",
+                        "description": "Enter league id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -830,7 +1157,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Player"
+                            "$ref": "#/definitions/entity.League"
                         }
                     },
                     "400": {
@@ -854,7 +1181,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update player by id",
+                "description": "Update league by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -862,25 +1189,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "player"
+                    "league"
                 ],
-                "summary": "Update player",
-                "operationId": "update-player",
+                "summary": "Update league",
+                "operationId": "update-league",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter id player",
+                        "description": "Enter id league",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Enter new player info for update",
-                        "name": "player",
+                        "description": "Enter new league info for update",
+                        "name": "league",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Player"
+                            "$ref": "#/definitions/entity.League"
                         }
                     }
                 ],
@@ -888,7 +1215,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Player"
+                            "$ref": "#/definitions/entity.League"
                         }
                     },
                     "400": {
@@ -912,16 +1239,16 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete player by id",
+                "description": "Delete league by id",
                 "tags": [
-                    "player"
+                    "league"
                 ],
-                "summary": "Delete player",
-                "operationId": "delete-player",
+                "summary": "Delete league",
+                "operationId": "delete-league",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter id player",
+                        "description": "Enter id league",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -952,72 +1279,101 @@ const docTemplate = `{
                 }
             }
         },
-        "/stat_awards": {
-            "post": {
-                "description": "Create new record",
-                "consumes": [
-                    "application/json"
-                ],
+        "/league/{id}/playoffs": {
+            "get": {
+                "description": "Get playoff bracket of the league",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "record"
+                    "league"
                 ],
-                "summary": "Create record",
-                "operationId": "create-record",
+                "summary": "Get playoffs",
+                "operationId": "get-league-playoffs",
                 "parameters": [
                     {
-                        "description": "Enter new record info",
-                        "name": "player",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.RewardStat"
-                        }
+                        "type": "string",
+                        "description": "Enter league id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PlayoffBracket"
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/v1.errResponse"
                         }
                     }
                 }
-            }
-        },
-        "/stat_awards/match/{id}": {
-            "get": {
-                "description": "Get stat by match id",
+            },
+            "post": {
+                "description": "Seed top teams of the regular season standings into a bracket and create the first games of every series",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "league"
                 ],
-                "summary": "Get stat by match",
-                "operationId": "get-stat-match",
+                "summary": "Create playoffs",
+                "operationId": "create-league-playoffs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter match id",
+                        "description": "Enter league id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Enter playoff format",
+                        "name": "playoffs",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PlayoffRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.RewardStat"
-                            }
+                            "$ref": "#/definitions/entity.PlayoffBracket"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
                         }
                     },
                     "500": {
@@ -1029,21 +1385,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/stat_awards/player/{id}": {
-            "get": {
-                "description": "Get stat by player id",
+        "/league/{id}/playoffs/sync": {
+            "post": {
+                "description": "Recount series wins from games, advance winners and create missing series games",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "league"
                 ],
-                "summary": "Get stat by player",
-                "operationId": "get-stat-player",
+                "summary": "Sync playoffs",
+                "operationId": "sync-league-playoffs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter player id",
+                        "description": "Enter league id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1053,10 +1409,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.RewardStat"
-                            }
+                            "$ref": "#/definitions/entity.PlayoffBracket"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
                         }
                     },
                     "500": {
@@ -1068,34 +1427,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/stat_awards/reward/{id}": {
-            "get": {
-                "description": "Get stat by reward id",
+        "/league/{id}/schedule": {
+            "post": {
+                "description": "Generate balanced round-robin regular season for the league and create its games",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "league"
                 ],
-                "summary": "Get stat by reward",
-                "operationId": "get-stat-reward",
+                "summary": "Generate season schedule",
+                "operationId": "generate-league-schedule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter reward id",
+                        "description": "Enter league id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Enter schedule constraints",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.ScheduleRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.RewardStat"
-                            }
+                            "$ref": "#/definitions/entity.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
                         }
                     },
                     "500": {
@@ -1107,24 +1499,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/stat_awards/tournament/{id}": {
+        "/league/{id}/standings": {
             "get": {
-                "description": "Get stat by tournament id",
+                "description": "Get standings computed from final games of the league",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "league"
                 ],
-                "summary": "Get stat by tournament",
-                "operationId": "get-stat-tournament",
+                "summary": "Get league standings",
+                "operationId": "get-league-standings",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter tournament id",
+                        "description": "Enter league id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter conference to get standings of",
+                        "name": "conference",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter comma separated tie-breaker rules: head_to_head, point_differential, conference_record",
+                        "name": "tie_breakers",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1133,10 +1537,22 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/entity.RewardStat"
+                                "$ref": "#/definitions/entity.Standing"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1146,9 +1562,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/stat_player": {
+        "/player": {
             "post": {
-                "description": "Create new stat player",
+                "description": "Create new player",
                 "consumes": [
                     "application/json"
                 ],
@@ -1156,24 +1572,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat-player"
+                    "player"
                 ],
-                "summary": "Create stat player",
-                "operationId": "create-stat-player",
+                "summary": "Create player",
+                "operationId": "create-player",
                 "parameters": [
                     {
-                        "description": "Enter new player stat",
+                        "description": "Enter new player info",
                         "name": "player",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.PlayerStat"
+                            "$ref": "#/definitions/entity.Player"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created"
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createPlayerResp"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -1184,41 +1603,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/stat_player/all_points/{mid}": {
+        "/player/list": {
             "get": {
-                "description": "Get players stat avg points by match id",
+                "description": "Get player list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "player-stats"
+                    "player"
                 ],
-                "summary": "Get players stat avg points by match id",
-                "operationId": "get-player-points",
+                "summary": "Get player list",
+                "operationId": "get-player-list",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter avg points",
-                        "name": "points",
-                        "in": "query",
-                        "required": true
+                        "description": "Enter page size",
+                        "name": "page_size",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Enter match id",
-                        "name": "mid",
-                        "in": "path",
-                        "required": true
+                        "description": "Enter page number",
+                        "name": "page_number",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.PlayerStat"
-                            }
+                            "$ref": "#/definitions/entity.Player"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
                         }
                     },
                     "500": {
@@ -1230,29 +1650,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/stat_player/goals/{mid}": {
+        "/player/{id}": {
             "get": {
-                "description": "Get players stat avg goals by match id",
+                "description": "Get player by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "player-stats"
+                    "player"
                 ],
-                "summary": "Get players stat avg goals by match id",
-                "operationId": "get-player-goals",
+                "summary": "Get player",
+                "operationId": "get-player",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Enter avg goals",
-                        "name": "goals",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Enter match id",
-                        "name": "mid",
+                        "description": "Enter player id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1261,10 +1674,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.PlayerStat"
-                            }
+                            "$ref": "#/definitions/entity.Player"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
                         }
                     },
                     "500": {
@@ -1274,31 +1696,144 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/stat_player/{pid}/{mid}": {
-            "get": {
-                "description": "Get player stats by player id and match id",
+            },
+            "put": {
+                "description": "Update player by id",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "player-stats"
+                    "player"
                 ],
-                "summary": "Get player stats by player id and match id",
-                "operationId": "get-player-stats",
+                "summary": "Update player",
+                "operationId": "update-player",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter id player",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Enter new player info for update",
+                        "name": "player",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Player"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Player"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete player by id according to its deletion policy. Stats and award graph records are removed or kept by the policy.\nIf a store fails, the deletion job is returned with 202 and retried in the background",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "player"
+                ],
+                "summary": "Delete player",
+                "operationId": "delete-player",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter id player",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.DeletionJob"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/player/{id}/common_opponents/{other}": {
+            "get": {
+                "description": "Get teams both players played against and how many games each played against them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "player"
+                ],
+                "summary": "Get common opponents",
+                "operationId": "get-player-common-opponents",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Enter player id",
-                        "name": "pid",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Enter match id",
-                        "name": "mid",
+                        "description": "Enter other player id",
+                        "name": "other",
                         "in": "path",
                         "required": true
                     }
@@ -1309,10 +1844,142 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/entity.PlayerStat"
+                                "$ref": "#/definitions/entity.CommonOpponent"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/player/{id}/metrics": {
+            "get": {
+                "description": "Get TS%, eFG%, usage, PER, assist/turnover ratio and per-36/per-100-possession numbers over a match, a date range or a season",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "player-stats"
+                ],
+                "summary": "Get player advanced metrics",
+                "operationId": "get-player-metrics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter player id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter match id",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter first game date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter last game date",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter season",
+                        "name": "season",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PlayerMetrics"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/player/{id}/profile": {
+            "get": {
+                "description": "Get player, career and season stat lines and award history in one response. If a store fails, its part is omitted and the reason is listed in warnings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "player"
+                ],
+                "summary": "Get player profile",
+                "operationId": "get-player-profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter player id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PlayerProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1321,143 +1988,2485 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.Award": {
+        },
+        "/player/{id}/seasons": {
+            "get": {
+                "description": "Get player season totals and per-game averages, kept up to date on every stat insert",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "player-stats"
+                ],
+                "summary": "Get player season stats",
+                "operationId": "get-player-season-stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter player id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter season, all seasons by default",
+                        "name": "season",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.PlayerSeasonStat"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/player/{id}/separation/{other}": {
+            "get": {
+                "description": "Get the shortest chain of players between two players, where neighbours played in the same match",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "player"
+                ],
+                "summary": "Get degrees of separation",
+                "operationId": "get-player-separation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter player id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter other player id",
+                        "name": "other",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Separation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/player/{id}/teammates": {
+            "get": {
+                "description": "Get current and former teammates: players on the same team roster at the same time or on the same team in a match",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "player"
+                ],
+                "summary": "Get teammates",
+                "operationId": "get-player-teammates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter player id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Teammate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_awards": {
+            "post": {
+                "description": "Create new record",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Create record",
+                "operationId": "create-record",
+                "parameters": [
+                    {
+                        "description": "Enter new record info",
+                        "name": "player",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.RewardStat"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Don't check that player, match, reward and tournament exist (bulk backfills)",
+                        "name": "skip_ref_check",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_awards/centrality": {
+            "get": {
+                "description": "Get players with the most distinct co-awarded players in the same matches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "Get degree centrality",
+                "operationId": "get-stat-centrality",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter number of players, 10 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.PlayerCentrality"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_awards/co_occurrence": {
+            "get": {
+                "description": "Get pairs of awards that go to the same players",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "Get award co-occurrence",
+                "operationId": "get-stat-co-occurrence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter award id to get only pairs with this award",
+                        "name": "award",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter number of pairs, 10 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.AwardCoOccurrence"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_awards/match/{id}": {
+            "get": {
+                "description": "Get stat by match id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "Get stat by match",
+                "operationId": "get-stat-match",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter match id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.RewardStat"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_awards/player/{id}": {
+            "get": {
+                "description": "Get stat by player id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "Get stat by player",
+                "operationId": "get-stat-player",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter player id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.RewardStat"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_awards/player/{id}/co_awarded": {
+            "get": {
+                "description": "Get players awarded in the same matches or tournaments as the player",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "Get co-awarded players",
+                "operationId": "get-stat-co-awarded",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter player id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter number of players, 10 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.CoAwardedPlayer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_awards/reward/{id}": {
+            "get": {
+                "description": "Get stat by reward id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "Get stat by reward",
+                "operationId": "get-stat-reward",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter reward id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.RewardStat"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_awards/tournament/{id}": {
+            "get": {
+                "description": "Get stat by tournament id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "Get stat by tournament",
+                "operationId": "get-stat-tournament",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter tournament id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.RewardStat"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_awards/tournament/{id}/most_decorated": {
+            "get": {
+                "description": "Get players of the tournament with the most awards",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "Get most decorated players",
+                "operationId": "get-stat-most-decorated",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter tournament id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter number of players, 10 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.DecoratedPlayer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_player": {
+            "post": {
+                "description": "Create new stat player. Points and total rebounds are calculated when omitted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat-player"
+                ],
+                "summary": "Create stat player",
+                "operationId": "create-stat-player",
+                "parameters": [
+                    {
+                        "description": "Enter new player stat",
+                        "name": "player",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PlayerStat"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Don't check that player, match, team and league exist (bulk backfills)",
+                        "name": "skip_ref_check",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_player/all_points/{mid}": {
+            "get": {
+                "description": "Get players stat avg points by match id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "player-stats"
+                ],
+                "summary": "Get players stat avg points by match id",
+                "operationId": "get-player-points",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter avg points",
+                        "name": "points",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter match id",
+                        "name": "mid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.PlayerStat"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_player/batch": {
+            "post": {
+                "description": "Create many stat players at once. The body is a JSON array of stats or NDJSON, one stat per line.\nRows are validated one by one and written to storage in batches; rows are numbered from zero",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat-player"
+                ],
+                "summary": "Create stat players in batch",
+                "operationId": "create-stat-player-batch",
+                "parameters": [
+                    {
+                        "description": "Enter player stats",
+                        "name": "stats",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.PlayerStat"
+                            }
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Don't check that players, matches, teams and leagues exist (bulk backfills)",
+                        "name": "skip_ref_check",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.StatBatchResult"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_player/goals/{mid}": {
+            "get": {
+                "description": "Get players stat avg goals by match id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "player-stats"
+                ],
+                "summary": "Get players stat avg goals by match id",
+                "operationId": "get-player-goals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter avg goals",
+                        "name": "goals",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter match id",
+                        "name": "mid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.PlayerStat"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat_player/{pid}/{mid}": {
+            "get": {
+                "description": "Get player stats by player id and match id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "player-stats"
+                ],
+                "summary": "Get player stats by player id and match id",
+                "operationId": "get-player-stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter player id",
+                        "name": "pid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter match id",
+                        "name": "mid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.PlayerStat"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team": {
+            "post": {
+                "description": "Create new team",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Create team",
+                "operationId": "create-team",
+                "parameters": [
+                    {
+                        "description": "Enter new team info",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Team"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/v1.createTeamResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/list": {
+            "get": {
+                "description": "Get team list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Get team list",
+                "operationId": "get-team-list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter page number",
+                        "name": "page_number",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/{id}": {
+            "get": {
+                "description": "Get team by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Get team",
+                "operationId": "get-team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter team id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update team by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Update team",
+                "operationId": "update-team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter id team",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Enter new team info for update",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Team"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete team by id. A team with players or games can't be deleted",
+                "tags": [
+                    "team"
+                ],
+                "summary": "Delete team",
+                "operationId": "delete-team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter id team",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/{id}/games": {
+            "get": {
+                "description": "Get games of the team",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Get team schedule",
+                "operationId": "get-team-schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter team id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Game"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/{id}/players": {
+            "get": {
+                "description": "Get players of the team",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Get team roster",
+                "operationId": "get-team-roster",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter team id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Player"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/{id}/seasons": {
+            "get": {
+                "description": "Get team season totals and per-game averages, kept up to date on every stat insert",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "player-stats"
+                ],
+                "summary": "Get team season stats",
+                "operationId": "get-team-season-stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enter team id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Enter season, all seasons by default",
+                        "name": "season",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.TeamSeasonStat"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.errResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.Award": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "заполняется при чтении из хранилища",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "default": "MVP of season 2024"
+                },
+                "surname": {
+                    "type": "string",
+                    "default": "Best player of season 2024"
+                }
+            }
+        },
+        "entity.AwardCoOccurrence": {
+            "type": "object",
+            "properties": {
+                "award": {
+                    "type": "string"
+                },
+                "award_title": {
+                    "type": "string"
+                },
+                "other_award": {
+                    "type": "string"
+                },
+                "other_award_title": {
+                    "type": "string"
+                },
+                "players": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.AwardGrants": {
+            "type": "object",
+            "properties": {
+                "award": {
+                    "$ref": "#/definitions/entity.Award"
+                },
+                "grants": {
+                    "description": "сколько раз награда вручалась",
+                    "type": "integer"
+                },
+                "recipients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AwardRecipient"
+                    }
+                }
+            }
+        },
+        "entity.AwardRecipient": {
+            "type": "object",
+            "properties": {
+                "grants": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "player": {
+                    "type": "string"
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.CareerStat": {
+            "type": "object",
+            "properties": {
+                "games": {
+                    "type": "integer"
+                },
+                "perGame": {
+                    "$ref": "#/definitions/entity.SeasonAverages"
+                },
+                "totals": {
+                    "$ref": "#/definitions/entity.SeasonTotals"
+                }
+            }
+        },
+        "entity.CoAwardedPlayer": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "player": {
+                    "type": "string"
+                },
+                "shared_matches": {
+                    "type": "integer"
+                },
+                "shared_tournaments": {
+                    "type": "integer"
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.CommonOpponent": {
+            "type": "object",
+            "properties": {
+                "games": {
+                    "type": "integer"
+                },
+                "other_games": {
+                    "type": "integer"
+                },
+                "team": {
+                    "type": "string"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.DecoratedPlayer": {
+            "type": "object",
+            "properties": {
+                "awards": {
+                    "description": "сколько раз награждался",
+                    "type": "integer"
+                },
+                "distinct_awards": {
+                    "description": "сколько разных наград получил",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "player": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.DeletionJob": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "player"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "policy": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.DeletionPolicy"
+                        }
+                    ],
+                    "example": "cascade"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "done"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.DeletionStep"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.DeletionPolicy": {
+            "type": "string",
+            "enum": [
+                "reject",
+                "cascade",
+                "tombstone"
+            ],
+            "x-enum-comments": {
+                "DeletionCascade": "удалить вместе со статистикой, узлом графа наград и событиями",
+                "DeletionReject": "отказать, если на сущность ссылаются статистика, награды или события",
+                "DeletionTombstone": "убрать из каталога, сохранив историю; узел графа помечается удалённым"
+            },
+            "x-enum-varnames": [
+                "DeletionReject",
+                "DeletionCascade",
+                "DeletionTombstone"
+            ]
+        },
+        "entity.DeletionStep": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "ошибка последней попытки",
+                    "type": "string"
+                },
+                "store": {
+                    "type": "string",
+                    "example": "player_stats"
+                }
+            }
+        },
+        "entity.Game": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "default": "12.03.24"
+                },
+                "first_team": {
+                    "description": "id команды хозяев",
+                    "type": "string"
+                },
+                "first_team_score": {
+                    "type": "integer"
+                },
+                "league": {
+                    "description": "id лиги",
+                    "type": "string"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PeriodScore"
+                    }
+                },
+                "round": {
+                    "description": "раунд плей-офф",
+                    "type": "integer"
+                },
+                "second_team": {
+                    "description": "id команды гостей",
+                    "type": "string"
+                },
+                "second_team_score": {
+                    "type": "integer"
+                },
+                "series": {
+                    "description": "id серии плей-офф",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "default": "scheduled"
+                },
+                "type": {
+                    "type": "string",
+                    "default": "final"
+                },
+                "winner": {
+                    "description": "id команды-победителя, только для final",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.GameEvent": {
+            "type": "object",
+            "properties": {
+                "clock": {
+                    "description": "оставшееся время периода, mm:ss",
+                    "type": "string",
+                    "example": "11:42"
+                },
+                "game": {
+                    "description": "id матча",
+                    "type": "string"
+                },
+                "period": {
+                    "description": "5 и дальше - овертаймы",
+                    "type": "integer",
+                    "example": 1
+                },
+                "player": {
+                    "description": "id игрока; для замены - выходящий на площадку",
+                    "type": "string"
+                },
+                "rebound_type": {
+                    "type": "string",
+                    "example": "defensive"
+                },
+                "replaced": {
+                    "description": "id игрока, уходящего с площадки при замене",
+                    "type": "string"
+                },
+                "sequence": {
+                    "description": "порядковый номер в матче, проставляется при записи",
+                    "type": "integer"
+                },
+                "shot_type": {
+                    "description": "two_point, three_point, free_throw",
+                    "type": "string",
+                    "example": "two_point"
+                },
+                "team": {
+                    "description": "id команды игрока",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "shot_made, shot_missed, rebound, assist, steal, block, turnover, foul, substitution",
+                    "type": "string",
+                    "example": "shot_made"
+                }
+            }
+        },
+        "entity.GameResult": {
+            "type": "object",
+            "properties": {
+                "first_team_score": {
+                    "type": "integer",
+                    "example": 102
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PeriodScore"
+                    }
+                },
+                "second_team_score": {
+                    "type": "integer",
+                    "example": 98
+                },
+                "status": {
+                    "type": "string",
+                    "example": "final"
+                }
+            }
+        },
+        "entity.Leader": {
+            "type": "object",
+            "properties": {
+                "games": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "playerId": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.League": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "default": "NBA"
+                },
+                "season": {
+                    "type": "string",
+                    "default": "2023/2024"
+                },
+                "tie_breakers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "head_to_head",
+                        "point_differential"
+                    ]
+                }
+            }
+        },
+        "entity.PeriodScore": {
+            "type": "object",
+            "properties": {
+                "first_team": {
+                    "type": "integer",
+                    "example": 28
+                },
+                "period": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "second_team": {
+                    "type": "integer",
+                    "example": 24
+                }
+            }
+        },
+        "entity.Player": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "default": 34
+                },
+                "citizenship": {
+                    "type": "string",
+                    "default": "USA"
+                },
+                "height": {
+                    "type": "integer",
+                    "default": 201
+                },
+                "middle_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "default": "Jimmi"
+                },
+                "role": {
+                    "type": "string",
+                    "default": "heavy forward"
+                },
+                "surname": {
+                    "type": "string",
+                    "default": "Butler"
+                },
+                "team": {
+                    "description": "id команды",
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer",
+                    "default": 104
+                }
+            }
+        },
+        "entity.PlayerCentrality": {
+            "type": "object",
+            "properties": {
+                "centrality": {
+                    "type": "number"
+                },
+                "degree": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "player": {
+                    "type": "string"
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PlayerMetrics": {
+            "type": "object",
+            "properties": {
+                "assistTurnover": {
+                    "description": "AST/TO",
+                    "type": "number"
+                },
+                "effectiveFg": {
+                    "description": "eFG%, доля",
+                    "type": "number"
+                },
+                "games": {
+                    "type": "integer"
+                },
+                "minutes": {
+                    "type": "number"
+                },
+                "per": {
+                    "description": "player efficiency rating",
+                    "type": "number"
+                },
+                "per100": {
+                    "$ref": "#/definitions/entity.StatLine"
+                },
+                "per36": {
+                    "$ref": "#/definitions/entity.StatLine"
+                },
+                "playerId": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "scope": {
+                    "$ref": "#/definitions/entity.StatScope"
+                },
+                "trueShooting": {
+                    "description": "TS%, доля",
+                    "type": "number"
+                },
+                "usage": {
+                    "description": "USG%, доля владений команды на площадке",
+                    "type": "number"
+                }
+            }
+        },
+        "entity.PlayerProfile": {
+            "type": "object",
+            "properties": {
+                "awards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.RewardStat"
+                    }
+                },
+                "career": {
+                    "$ref": "#/definitions/entity.CareerStat"
+                },
+                "player": {
+                    "$ref": "#/definitions/entity.Player"
+                },
+                "playerId": {
+                    "type": "string"
+                },
+                "seasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PlayerSeasonStat"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entity.PlayerSeasonStat": {
+            "type": "object",
+            "properties": {
+                "games": {
+                    "type": "integer"
+                },
+                "league": {
+                    "type": "string"
+                },
+                "perGame": {
+                    "$ref": "#/definitions/entity.SeasonAverages"
+                },
+                "playerId": {
+                    "type": "string"
+                },
+                "season": {
+                    "type": "string"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "totals": {
+                    "$ref": "#/definitions/entity.SeasonTotals"
+                }
+            }
+        },
+        "entity.PlayerStat": {
+            "type": "object",
+            "properties": {
+                "assists": {
+                    "type": "integer"
+                },
+                "avgGoals": {
+                    "type": "number"
+                },
+                "blocks": {
+                    "type": "integer"
+                },
+                "defensiveRebounds": {
+                    "type": "integer"
+                },
+                "fga": {
+                    "description": "0 при FGM \u003e 0 - попытки неизвестны",
+                    "type": "integer"
+                },
+                "fgm": {
+                    "description": "забитые броски с игры, включая трёхочковые",
+                    "type": "integer"
+                },
+                "fta": {
+                    "type": "integer"
+                },
+                "ftm": {
+                    "type": "integer"
+                },
+                "gameDate": {
+                    "description": "по умолчанию дата матча, в формате GameDateLayout",
+                    "type": "string"
+                },
+                "goals": {
+                    "description": "устарело, то же что FGM",
+                    "type": "integer"
+                },
+                "interceptions": {
+                    "description": "устарело, то же что Steals",
+                    "type": "integer"
+                },
+                "leagueId": {
+                    "description": "по умолчанию лига матча",
+                    "type": "string"
+                },
+                "matchId": {
+                    "type": "string"
+                },
+                "minutes": {
+                    "type": "number"
+                },
+                "offensiveRebounds": {
+                    "type": "integer"
+                },
+                "personalFouls": {
+                    "type": "integer"
+                },
+                "playerId": {
+                    "type": "string"
+                },
+                "plusMinus": {
+                    "type": "integer"
+                },
+                "points": {
+                    "description": "по умолчанию считается по броскам",
+                    "type": "integer"
+                },
+                "rebounds": {
+                    "description": "всего подборов",
+                    "type": "integer"
+                },
+                "season": {
+                    "description": "по умолчанию сезон лиги матча",
+                    "type": "string"
+                },
+                "steals": {
+                    "type": "integer"
+                },
+                "teamId": {
+                    "description": "по умолчанию текущая команда игрока",
+                    "type": "string"
+                },
+                "threePa": {
+                    "type": "integer"
+                },
+                "threePm": {
+                    "type": "integer"
+                },
+                "totalAvgStats": {
+                    "type": "number"
+                },
+                "turnovers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.PlayoffBracket": {
+            "type": "object",
+            "properties": {
+                "best_of": {
+                    "type": "integer"
+                },
+                "champion": {
+                    "type": "string"
+                },
+                "days_between_games": {
+                    "type": "integer"
+                },
+                "league": {
+                    "type": "string"
+                },
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PlayoffRound"
+                    }
+                },
+                "seeds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PlayoffSeed"
+                    }
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PlayoffRequest": {
+            "type": "object",
+            "properties": {
+                "best_of": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "days_between_games": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "20.04.24"
+                },
+                "teams": {
+                    "description": "степень двойки: 2, 4, 8, 16",
+                    "type": "integer",
+                    "example": 8
+                }
+            }
+        },
+        "entity.PlayoffRound": {
+            "type": "object",
+            "properties": {
+                "round": {
+                    "type": "integer"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PlayoffSeries"
+                    }
+                }
+            }
+        },
+        "entity.PlayoffSeed": {
+            "type": "object",
+            "properties": {
+                "seed": {
+                    "type": "integer"
+                },
+                "team": {
+                    "description": "id команды",
+                    "type": "string"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PlayoffSeries": {
+            "type": "object",
+            "properties": {
+                "bottom": {
+                    "$ref": "#/definitions/entity.PlayoffSeed"
+                },
+                "bottom_wins": {
+                    "type": "integer"
+                },
+                "games": {
+                    "description": "id матчей серии по порядку",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "R1S1"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "top": {
+                    "$ref": "#/definitions/entity.PlayoffSeed"
+                },
+                "top_wins": {
+                    "type": "integer"
+                },
+                "winner": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Record": {
+            "type": "object",
+            "properties": {
+                "losses": {
+                    "type": "integer"
+                },
+                "wins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.RewardStat": {
+            "type": "object",
+            "properties": {
+                "match": {
+                    "type": "string"
+                },
+                "matchDate": {
+                    "type": "string"
+                },
+                "matchFirstTeam": {
+                    "description": "id команды хозяев",
+                    "type": "string"
+                },
+                "matchFirstTeamName": {
+                    "type": "string"
+                },
+                "matchSecondTeam": {
+                    "description": "id команды гостей",
+                    "type": "string"
+                },
+                "matchSecondTeamName": {
+                    "type": "string"
+                },
+                "player": {
+                    "type": "string"
+                },
+                "playerName": {
+                    "description": "Свойства узлов графа из каталога: записываются при создании записи, обновляются relay outbox\nи возвращаются при чтении, чтобы страницы наград обходились без запросов к каталогу",
+                    "type": "string"
+                },
+                "playerSurname": {
+                    "type": "string"
+                },
+                "playerTeam": {
+                    "description": "id команды",
+                    "type": "string"
+                },
+                "playerTeamName": {
+                    "description": "Названия команд в граф не пишутся: команды не публикуются в outbox, и названия устаревали бы.\nОни берутся из каталога при чтении",
+                    "type": "string"
+                },
+                "reward": {
+                    "type": "string"
+                },
+                "rewardTitle": {
+                    "type": "string"
+                },
+                "tournament": {
+                    "type": "string"
+                },
+                "tournamentName": {
+                    "description": "название лиги",
+                    "type": "string"
+                },
+                "tournamentSeason": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Schedule": {
+            "type": "object",
+            "properties": {
+                "away_games": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "games": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ScheduledGame"
+                    }
+                },
+                "home_games": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "entity.ScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "blackout_dates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "24.12.23",
+                        "25.12.23"
+                    ]
+                },
+                "dry_run": {
+                    "description": "только построить расписание, не сохраняя матчи",
+                    "type": "boolean"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "14.04.24"
+                },
+                "max_games_per_week": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "rounds": {
+                    "description": "сколько раз каждая пара встречается, по умолчанию 2 (дома и в гостях)",
+                    "type": "integer",
+                    "example": 2
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "24.10.23"
+                },
+                "teams": {
+                    "description": "id команд, по умолчанию все команды лиги",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entity.ScheduledGame": {
+            "type": "object",
+            "properties": {
+                "game": {
+                    "$ref": "#/definitions/entity.Game"
+                },
+                "game_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.SeasonAverages": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string",
-                    "default": "MVP of season 2024"
+                "assists": {
+                    "type": "number"
                 },
-                "surname": {
-                    "type": "string",
-                    "default": "Best player of season 2024"
+                "blocks": {
+                    "type": "number"
+                },
+                "defensiveRebounds": {
+                    "type": "number"
+                },
+                "fga": {
+                    "type": "number"
+                },
+                "fgm": {
+                    "type": "number"
+                },
+                "fta": {
+                    "type": "number"
+                },
+                "ftm": {
+                    "type": "number"
+                },
+                "minutes": {
+                    "type": "number"
+                },
+                "offensiveRebounds": {
+                    "type": "number"
+                },
+                "personalFouls": {
+                    "type": "number"
+                },
+                "plusMinus": {
+                    "type": "number"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "rebounds": {
+                    "type": "number"
+                },
+                "steals": {
+                    "type": "number"
+                },
+                "threePa": {
+                    "type": "number"
+                },
+                "threePm": {
+                    "type": "number"
+                },
+                "turnovers": {
+                    "type": "number"
                 }
             }
         },
-        "entity.Game": {
+        "entity.SeasonTotals": {
             "type": "object",
             "properties": {
-                "date": {
-                    "type": "string",
-                    "default": "12.03.24"
+                "assists": {
+                    "type": "integer"
                 },
-                "first_team": {
-                    "type": "string",
-                    "default": "LA Lakers"
+                "blocks": {
+                    "type": "integer"
                 },
-                "league": {
-                    "type": "string",
-                    "default": "NBA"
+                "defensiveRebounds": {
+                    "type": "integer"
                 },
-                "second_team": {
-                    "type": "string",
-                    "default": "Chicago Bulls"
+                "fga": {
+                    "type": "integer"
                 },
-                "type": {
-                    "type": "string",
-                    "default": "final"
+                "fgm": {
+                    "type": "integer"
+                },
+                "fta": {
+                    "type": "integer"
+                },
+                "ftm": {
+                    "type": "integer"
+                },
+                "minutes": {
+                    "type": "number"
+                },
+                "offensiveRebounds": {
+                    "type": "integer"
+                },
+                "personalFouls": {
+                    "type": "integer"
+                },
+                "plusMinus": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "rebounds": {
+                    "type": "integer"
+                },
+                "steals": {
+                    "type": "integer"
+                },
+                "threePa": {
+                    "type": "integer"
+                },
+                "threePm": {
+                    "type": "integer"
+                },
+                "turnovers": {
+                    "type": "integer"
                 }
             }
         },
-        "entity.League": {
+        "entity.Separation": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string",
-                    "default": "NBA"
+                "degrees": {
+                    "type": "integer"
                 },
-                "season": {
-                    "type": "string",
-                    "default": "2023/2024"
+                "path": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.SeparationStep"
+                    }
                 }
             }
         },
-        "entity.Player": {
+        "entity.SeparationStep": {
             "type": "object",
             "properties": {
-                "age": {
-                    "type": "integer",
-                    "default": 34
+                "match": {
+                    "type": "string"
                 },
-                "citizenship": {
-                    "type": "string",
-                    "default": "USA"
+                "name": {
+                    "type": "string"
                 },
-                "height": {
-                    "type": "integer",
-                    "default": 201
+                "player": {
+                    "type": "string"
                 },
-                "middle_name": {
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Standing": {
+            "type": "object",
+            "properties": {
+                "away": {
+                    "$ref": "#/definitions/entity.Record"
+                },
+                "conference": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "default": "Jimmi"
+                "conference_record": {
+                    "$ref": "#/definitions/entity.Record"
                 },
-                "role": {
-                    "type": "string",
-                    "default": "heavy forward"
+                "games_behind": {
+                    "type": "number"
                 },
-                "surname": {
+                "home": {
+                    "$ref": "#/definitions/entity.Record"
+                },
+                "last_ten": {
+                    "$ref": "#/definitions/entity.Record"
+                },
+                "losses": {
+                    "type": "integer"
+                },
+                "point_diff": {
+                    "type": "integer"
+                },
+                "points_against": {
+                    "type": "integer"
+                },
+                "points_for": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "streak": {
                     "type": "string",
-                    "default": "Butler"
+                    "example": "W3"
                 },
                 "team": {
-                    "type": "string",
-                    "default": "Miami Heat"
+                    "description": "id команды",
+                    "type": "string"
                 },
-                "weight": {
-                    "type": "integer",
-                    "default": 104
+                "team_name": {
+                    "type": "string"
+                },
+                "win_pct": {
+                    "type": "number"
+                },
+                "wins": {
+                    "type": "integer"
                 }
             }
         },
-        "entity.PlayerStat": {
+        "entity.StatBatchError": {
             "type": "object",
             "properties": {
-                "assists": {
+                "error": {
+                    "type": "string"
+                },
+                "row": {
                     "type": "integer"
+                }
+            }
+        },
+        "entity.StatBatchResult": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.StatBatchError"
+                    }
                 },
-                "avgGoals": {
-                    "type": "number"
+                "failed": {
+                    "type": "integer"
                 },
-                "goals": {
+                "inserted": {
                     "type": "integer"
                 },
-                "interceptions": {
+                "received": {
                     "type": "integer"
+                }
+            }
+        },
+        "entity.StatLine": {
+            "type": "object",
+            "properties": {
+                "assists": {
+                    "type": "number"
+                },
+                "blocks": {
+                    "type": "number"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "rebounds": {
+                    "type": "number"
+                },
+                "steals": {
+                    "type": "number"
+                },
+                "turnovers": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.StatScope": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "включительно, в формате GameDateLayout",
+                    "type": "string",
+                    "example": "01.10.23"
                 },
                 "matchId": {
                     "type": "string"
                 },
-                "playerId": {
+                "season": {
+                    "type": "string",
+                    "example": "2023/2024"
+                },
+                "to": {
+                    "description": "включительно, в формате GameDateLayout",
+                    "type": "string",
+                    "example": "30.06.24"
+                }
+            }
+        },
+        "entity.Team": {
+            "type": "object",
+            "properties": {
+                "abbreviation": {
+                    "type": "string",
+                    "default": "MIA"
+                },
+                "city": {
+                    "type": "string",
+                    "default": "Miami"
+                },
+                "conference": {
+                    "type": "string",
+                    "default": "East"
+                },
+                "id": {
+                    "description": "заполняется при чтении из хранилища",
                     "type": "string"
                 },
-                "rebounds": {
+                "league": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "default": "Miami Heat"
+                }
+            }
+        },
+        "entity.TeamSeasonStat": {
+            "type": "object",
+            "properties": {
+                "games": {
                     "type": "integer"
                 },
-                "totalAvgStats": {
-                    "type": "number"
+                "league": {
+                    "type": "string"
+                },
+                "perGame": {
+                    "$ref": "#/definitions/entity.SeasonAverages"
+                },
+                "season": {
+                    "type": "string"
+                },
+                "teamId": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/entity.SeasonTotals"
                 }
             }
         },
-        "entity.RewardStat": {
+        "entity.Teammate": {
             "type": "object",
             "properties": {
-                "match": {
+                "current": {
+                    "description": "оба сейчас в составе одной из команд",
+                    "type": "boolean"
+                },
+                "name": {
                     "type": "string"
                 },
                 "player": {
                     "type": "string"
                 },
-                "reward": {
+                "surname": {
                     "type": "string"
                 },
-                "tournament": {
-                    "type": "string"
+                "teams": {
+                    "description": "id общих команд",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -1493,6 +4502,14 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.createTeamResp": {
+            "type": "object",
+            "properties": {
+                "team_id": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.errResponse": {
             "type": "object",
             "properties": {
@@ -1515,6 +4532,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "Basket no-sql lab",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -11,53 +12,50 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/romeros69/basket/config"
 	v1 "github.com/romeros69/basket/internal/controller/http/v1"
+	"github.com/romeros69/basket/internal/entity"
 	"github.com/romeros69/basket/internal/usecase"
-	"github.com/romeros69/basket/internal/usecase/repo/chouse_rp.go"
-	"github.com/romeros69/basket/internal/usecase/repo/mongo_rp"
-	"github.com/romeros69/basket/internal/usecase/repo/neo4j_rp"
-	"github.com/romeros69/basket/pkg/chouse"
 	"github.com/romeros69/basket/pkg/httpserver"
 	"github.com/romeros69/basket/pkg/logger"
-	"github.com/romeros69/basket/pkg/mongo"
-	"github.com/romeros69/basket/pkg/neo4j"
 )
 
+// storageCloseTimeout - сколько ждать при остановке, пока хранилища допишут буферизованные данные и закроют соединения
+const storageCloseTimeout = 30 * time.Second
+
 func Run(cfg *config.Config) {
 	l := logger.New(cfg.Log.Level)
 
-	// MongoDB
-	mongoDB, err := mongo.New(cfg)
-	if err != nil {
-		panic(err)
-	}
-
-	// Neo4J
-	neoDB, err := neo4j.New(cfg)
-	if err != nil {
-		panic(err)
-	}
-
-	// Clickhouse
-	chous, err := chouse.New(cfg)
+	// Repository
+	repos, err := newRepositories(cfg)
 	if err != nil {
 		panic(err)
 	}
-
-	// Repository
-	playerRepo := mongo_rp.NewPlayerRepo(mongoDB, "players")
-	awardRepo := mongo_rp.NewAwardRepo(mongoDB, "awards")
-	gameRepo := mongo_rp.NewGameRepo(mongoDB, "games")
-	leagueRepo := mongo_rp.NewLeagueRepo(mongoDB, "leagues")
-	statsAwardsRepo := neo4j_rp.NewStatAwardsRepo(neoDB)
-	statsPlayerRepo := chouse_rp.NewChouseRepo(chous)
+	l.Info("storage: catalog=%s, awards_graph=%s, player_stats=%s",
+		cfg.Storage.Catalog, cfg.Storage.AwardsGraph, cfg.Storage.PlayerStats)
 
 	// Use case
-	playerUseCase := usecase.NewPlayerUC(playerRepo)
-	awardUseCase := usecase.NewAwardUC(awardRepo)
-	gameUseCase := usecase.NewGameUC(gameRepo)
-	leagueUseCase := usecase.NewLeagueUC(leagueRepo)
-	statsAwardsUseCase := usecase.NewStatAwardsUC(statsAwardsRepo)
-	statsPlayerUseCase := usecase.NewStatPlayerUC(statsPlayerRepo)
+	deletionUseCase := usecase.NewDeletionUC(repos.deletionJob, repos.player, repos.game, repos.gameEvent,
+		repos.statsPlayer, repos.statsAwards, usecase.DeletionPolicies{
+			Player: entity.DeletionPolicy(cfg.Deletion.PlayerPolicy),
+			Game:   entity.DeletionPolicy(cfg.Deletion.GamePolicy),
+		})
+	playerUseCase := usecase.NewPlayerUC(repos.player, repos.team, repos.career, deletionUseCase)
+	teamUseCase := usecase.NewTeamUC(repos.team, repos.league, repos.player, repos.game)
+	awardUseCase := usecase.NewAwardUC(repos.award, repos.statsAwards)
+	gameUseCase := usecase.NewGameUC(repos.game, repos.team, repos.league, deletionUseCase)
+	leagueUseCase := usecase.NewLeagueUC(repos.league, repos.team, repos.game)
+	schedulerUseCase := usecase.NewSchedulerUC(repos.league, repos.team, repos.game)
+	playoffUseCase := usecase.NewPlayoffUC(repos.playoff, repos.game, leagueUseCase)
+	statsPlayerUseCase := usecase.NewStatPlayerUC(repos.statsPlayer, repos.game, repos.player, repos.team, repos.league, repos.career)
+	gameEventUseCase := usecase.NewGameEventUC(repos.gameEvent, repos.game, repos.player, statsPlayerUseCase)
+	gameUseCase.AddResultListener(playoffUseCase)
+	gameUseCase.AddResultListener(gameEventUseCase)
+	statsAwardsUseCase := usecase.NewStatAwardsUC(repos.statsAwards, repos.player, repos.game, repos.award, repos.league, repos.team)
+	careerUseCase := usecase.NewCareerUC(repos.career, repos.player, repos.team, repos.game, repos.statsPlayer)
+	profileUseCase := usecase.NewProfileUC(repos.player, repos.statsPlayer, repos.statsAwards, usecase.ProfileTimeouts{
+		Catalog: cfg.Profile.CatalogTimeout,
+		Stats:   cfg.Profile.StatsTimeout,
+		Awards:  cfg.Profile.AwardsTimeout,
+	})
 
 	// HTTP Server
 	handler := gin.New()
@@ -69,11 +67,25 @@ func Run(cfg *config.Config) {
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
-	v1.NewRouter(handler, playerUseCase, awardUseCase, gameUseCase, leagueUseCase, statsAwardsUseCase, statsPlayerUseCase, l)
+	v1.NewRouter(handler, playerUseCase, teamUseCase, awardUseCase, gameUseCase, gameEventUseCase, leagueUseCase, schedulerUseCase, playoffUseCase, statsAwardsUseCase, statsPlayerUseCase, profileUseCase, careerUseCase, deletionUseCase, l)
 	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port))
 
 	l.Info("server is start")
 
+	// Фоновые задачи: повтор упавших удалений
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go retryDeletionJobs(workersCtx, deletionUseCase, cfg.Deletion.RetryInterval, l)
+
+	// Relay событий каталога в граф наград и ClickHouse
+	if repos.outbox != nil && len(repos.projections) != 0 {
+		relayUseCase := usecase.NewOutboxRelayUC(repos.outbox, repos.projections, usecase.OutboxRelaySettings{
+			BatchSize:   cfg.Outbox.BatchSize,
+			MaxAttempts: cfg.Outbox.MaxAttempts,
+		})
+		go relayOutbox(workersCtx, relayUseCase, cfg.Outbox.RelayInterval, l)
+	}
+
 	// Waiting signal
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
@@ -83,10 +95,60 @@ func Run(cfg *config.Config) {
 		l.Info("app - Run - signal: " + s.String())
 	case err := <-httpServer.Notify():
 		l.Error(fmt.Errorf("app - Run - httpServer.Notify: %w", err))
+	}
+
+	// Сначала дожидаемся запросов в работе и останавливаем фоновые задачи, потом дописываем то, что они оставили
+	// в буферах хранилищ, и закрываем соединения
+	err = httpServer.Shutdown()
+	if err != nil {
+		l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
+	}
+	stopWorkers()
+
+	closeCtx, cancel := context.WithTimeout(context.Background(), storageCloseTimeout)
+	defer cancel()
+	if err = repos.close(closeCtx); err != nil {
+		l.Error(fmt.Errorf("app - Run - repos.close: %w", err))
+	}
+}
+
+// retryDeletionJobs - раз в interval повторяет задачи удаления, упавшие на одном из хранилищ
+func retryDeletionJobs(ctx context.Context, deletion usecase.Deletion, interval time.Duration, l logger.Interface) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := deletion.RetryFailedJobs(ctx); err != nil {
+				l.Error(fmt.Errorf("app - retryDeletionJobs: %w", err))
+			}
+		}
+	}
+}
+
+// relayOutbox - раз в interval переносит события каталога в проекции, пока outbox не опустеет
+func relayOutbox(ctx context.Context, relay usecase.OutboxRelay, interval time.Duration, l logger.Interface) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-		err = httpServer.Shutdown()
-		if err != nil {
-			l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				applied, err := relay.RelayPending(ctx)
+				if err != nil {
+					l.Error(fmt.Errorf("app - relayOutbox: %w", err))
+					break
+				}
+				if applied == 0 {
+					break
+				}
+			}
 		}
 	}
 }
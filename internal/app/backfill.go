@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/romeros69/basket/config"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/internal/usecase/repo/neo4j_rp"
+	"github.com/romeros69/basket/pkg/neo4j"
+)
+
+const backfillUsage = "usage: backfill awarded-in | career"
+
+// Backfill - команда backfill: дозаполняет граф наград и карьеры данными, которые появились в схеме графа позже записей.
+// awarded-in восстанавливает связи вручений AWARDED_IN для старых записей о наградах, career - связи игроков
+// с матчами по статистике и составы команд по этим матчам. Повторный запуск безопасен
+func Backfill(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(backfillUsage)
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "awarded-in":
+		return backfillAwardedIn(ctx, cfg)
+	case "career":
+		return backfillCareer(ctx, cfg)
+	default:
+		return fmt.Errorf("unknown backfill command %q, %s", args[0], backfillUsage)
+	}
+}
+
+func backfillAwardedIn(ctx context.Context, cfg *config.Config) error {
+	if cfg.Storage.AwardsGraph != config.StorageNeo4j {
+		return fmt.Errorf("awarded-in works with the neo4j awards graph, storage.awards_graph is %q", cfg.Storage.AwardsGraph)
+	}
+
+	neoDB, err := neo4j.New(cfg)
+	if err != nil {
+		return fmt.Errorf("neo4j: %w", err)
+	}
+	defer neoDB.Close(ctx)
+
+	created, ambiguous, err := neo4j_rp.NewStatAwardsRepo(neoDB).BackfillAwardedIn(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("created %d AWARDED_IN relationships\n", created)
+	for _, reward := range ambiguous {
+		fmt.Printf("reward %s: grants are ambiguous, re-create its records\n", reward)
+	}
+	return nil
+}
+
+// backfillCareer - читает статистику и каталог из хранилищ, выбранных в cfg.Storage
+func backfillCareer(ctx context.Context, cfg *config.Config) (err error) {
+	repos, err := newRepositories(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, repos.close(ctx))
+	}()
+
+	career := usecase.NewCareerUC(repos.career, repos.player, repos.team, repos.game, repos.statsPlayer)
+	result, err := career.Backfill(ctx)
+	if result != nil {
+		fmt.Printf("recorded %d appearances, rebuilt memberships of %d players\n", result.Appearances, result.Players)
+	}
+	return err
+}
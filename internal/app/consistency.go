@@ -0,0 +1,26 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romeros69/basket/config"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+// CheckConsistency - сверяет каталог, статистику и граф наград хранилищ из cfg.Storage
+func CheckConsistency(ctx context.Context, cfg *config.Config, req entity.ConsistencyRequest) (*entity.ConsistencyReport, error) {
+	repos, err := newRepositories(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	consistencyUseCase := usecase.NewConsistencyUC(repos.player, repos.game, repos.statsPlayer, repos.statsAwards)
+	report, err := consistencyUseCase.Check(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("app - CheckConsistency: %w", err)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/romeros69/basket/config"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/internal/usecase/repo/chouse_rp.go"
+	"github.com/romeros69/basket/internal/usecase/repo/memory_rp"
+	"github.com/romeros69/basket/internal/usecase/repo/mongo_rp"
+	"github.com/romeros69/basket/internal/usecase/repo/neo4j_rp"
+	"github.com/romeros69/basket/pkg/chouse"
+	"github.com/romeros69/basket/pkg/mongo"
+	"github.com/romeros69/basket/pkg/neo4j"
+)
+
+// indexTimeout - сколько ждать создания индексов при запуске
+const indexTimeout = 30 * time.Second
+
+type repositories struct {
+	player      usecase.PlayerRp
+	team        usecase.TeamRp
+	award       usecase.AwardRp
+	game        usecase.GameRp
+	gameEvent   usecase.GameEventRp
+	league      usecase.LeagueRp
+	playoff     usecase.PlayoffRp
+	deletionJob usecase.DeletionJobRp
+	statsAwards usecase.StatAwardsRp
+	career      usecase.CareerRp
+	statsPlayer usecase.StatPlayerRp
+
+	// outbox и projections есть только у каталога в mongo
+	outbox      usecase.OutboxRp
+	projections []usecase.OutboxProjection
+
+	// closers - дописывают буферизованные данные и закрывают соединения при остановке приложения
+	closers []func(ctx context.Context) error
+}
+
+// newRepositories - подключается только к тем хранилищам, которые выбраны в cfg.Storage
+func newRepositories(cfg *config.Config) (_ *repositories, err error) {
+	repos := &repositories{}
+	// хранилище не подключилось - закрываем уже подключённые
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, repos.close(context.Background()))
+		}
+	}()
+
+	switch cfg.Storage.Catalog {
+	case config.StorageMongo:
+		mongoDB, err := mongo.New(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("mongo: %w", err)
+		}
+		repos.player = mongo_rp.NewPlayerRepo(mongoDB, "players")
+		repos.team = mongo_rp.NewTeamRepo(mongoDB, "teams")
+		repos.award = mongo_rp.NewAwardRepo(mongoDB, "awards")
+		repos.game = mongo_rp.NewGameRepo(mongoDB, "games")
+		repos.gameEvent = mongo_rp.NewGameEventRepo(mongoDB, "game_events")
+		repos.league = mongo_rp.NewLeagueRepo(mongoDB, "leagues")
+		repos.playoff = mongo_rp.NewPlayoffRepo(mongoDB, "playoffs")
+		repos.deletionJob = mongo_rp.NewDeletionJobRepo(mongoDB, "deletion_jobs")
+		outbox := mongo_rp.NewOutboxRepo(mongoDB)
+		ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+		err = outbox.EnsureIndexes(ctx, cfg.Outbox.Retention)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("mongo: %w", err)
+		}
+		repos.outbox = outbox
+	case config.StorageMemory:
+		repos.player = memory_rp.NewPlayerRepo()
+		repos.team = memory_rp.NewTeamRepo()
+		repos.award = memory_rp.NewAwardRepo()
+		repos.game = memory_rp.NewGameRepo()
+		repos.gameEvent = memory_rp.NewGameEventRepo()
+		repos.league = memory_rp.NewLeagueRepo()
+		repos.playoff = memory_rp.NewPlayoffRepo()
+		repos.deletionJob = memory_rp.NewDeletionJobRepo()
+	default:
+		return nil, fmt.Errorf("unknown catalog storage %q", cfg.Storage.Catalog)
+	}
+
+	switch cfg.Storage.AwardsGraph {
+	case config.StorageNeo4j:
+		neoDB, err := neo4j.New(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("neo4j: %w", err)
+		}
+		statsAwards := neo4j_rp.NewStatAwardsRepo(neoDB)
+		repos.statsAwards = statsAwards
+		repos.career = statsAwards
+		repos.projections = append(repos.projections, statsAwards)
+		repos.closers = append(repos.closers, neoDB.Close)
+	case config.StorageMemory:
+		statsAwards := memory_rp.NewStatAwardsRepo()
+		repos.statsAwards = statsAwards
+		repos.career = statsAwards
+	default:
+		return nil, fmt.Errorf("unknown awards graph storage %q", cfg.Storage.AwardsGraph)
+	}
+
+	switch cfg.Storage.PlayerStats {
+	case config.StorageClickHouse:
+		chous, err := chouse.New(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("clickhouse: %w", err)
+		}
+		statsPlayer := chouse_rp.NewChouseRepo(chous)
+		repos.statsPlayer = statsPlayer
+		repos.projections = append(repos.projections, statsPlayer)
+		repos.closers = append(repos.closers, statsPlayer.Close)
+	case config.StorageMemory:
+		repos.statsPlayer = memory_rp.NewStatPlayerRepo()
+	default:
+		return nil, fmt.Errorf("unknown player stats storage %q", cfg.Storage.PlayerStats)
+	}
+
+	return repos, nil
+}
+
+// close - дописывает буферизованные данные всех хранилищ и закрывает соединения
+func (r *repositories) close(ctx context.Context) error {
+	var errs []error
+	for _, closeRepo := range r.closers {
+		errs = append(errs, closeRepo(ctx))
+	}
+	return errors.Join(errs...)
+}
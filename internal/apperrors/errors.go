@@ -1,6 +1,9 @@
 package apperrors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrPlayerNotFound          = errors.New("player not found")
@@ -19,4 +22,56 @@ var (
 	ErrInvalidLeagueID         = errors.New("invalid league id")
 	ErrInvalidLeaguePageSize   = errors.New("invalid page size fir listing league")
 	ErrInvalidLeaguePageNumber = errors.New("invalid page number for listing league")
+	ErrTeamNotFound            = errors.New("team not found")
+	ErrInvalidTeamID           = errors.New("invalid team id")
+	ErrInvalidTeamPageSize     = errors.New("invalid page size for listing team")
+	ErrInvalidTeamPageNumber   = errors.New("invalid page number for listing team")
+	ErrSameTeams               = errors.New("first and second team must be different")
+	ErrInvalidGameResult       = errors.New("invalid game result")
+	ErrGameAlreadyFinal        = errors.New("game is already final, use correction instead")
+	ErrGameNotFinal            = errors.New("game is not final yet, nothing to correct")
+	ErrInvalidTieBreaker       = errors.New("unknown tie-breaker rule")
+	ErrInvalidSchedule         = errors.New("invalid schedule request")
+	ErrScheduleDoesNotFit      = errors.New("schedule does not fit into the date window")
+	ErrScheduleExists          = errors.New("league already has regular season games")
+	ErrPlayoffsNotFound        = errors.New("playoffs not found")
+	ErrPlayoffsAlreadyExist    = errors.New("playoffs already exist for the league")
+	ErrInvalidPlayoffs         = errors.New("invalid playoffs request")
+	ErrPlayoffWinnerChange     = errors.New("correction can't change the winner of a playoff game")
+	ErrPlayoffGameChange       = errors.New("teams, type and league of a playoff game are set by its bracket")
+	ErrInvalidGameEvent        = errors.New("invalid game event")
+	ErrGameEventsClosed        = errors.New("game is final, its events can't be changed")
+	ErrInvalidPlayerStat       = errors.New("invalid player stat")
+	ErrInvalidStatScope        = errors.New("invalid stat scope")
+	ErrPlayerStatsNotFound     = errors.New("player has no stats in the scope")
+	ErrInvalidLeaders          = errors.New("invalid leaders request")
+	ErrInvalidAwardAnalytics   = errors.New("invalid award analytics request")
+	ErrPlayersNotConnected     = errors.New("players are not connected")
+	ErrUnresolvedReference     = errors.New("unresolved reference")
+	ErrEntityReferenced        = errors.New("entity is still referenced by other records")
+	ErrDeletionJobNotFound     = errors.New("deletion job not found")
+	ErrInvalidDeletionJobID    = errors.New("invalid deletion job id")
+	ErrDeletionJobRunning      = errors.New("deletion job is already running")
+	ErrCareerGraphNotUpdated   = errors.New("career graph is not updated")
 )
+
+// ReferenceError - запись ссылается на сущность, которой нет в хранилище
+type ReferenceError struct {
+	Field string
+	ID    string
+}
+
+func NewReferenceError(field, id string) *ReferenceError {
+	return &ReferenceError{
+		Field: field,
+		ID:    id,
+	}
+}
+
+func (e *ReferenceError) Error() string {
+	return fmt.Sprintf("%s: %s %q does not exist", ErrUnresolvedReference, e.Field, e.ID)
+}
+
+func (e *ReferenceError) Unwrap() error {
+	return ErrUnresolvedReference
+}
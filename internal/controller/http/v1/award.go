@@ -27,6 +27,7 @@ func newAwardRoutes(handler *gin.RouterGroup, a usecase.Award, l logger.Interfac
 		h.PUT("/:id", r.updateAward)
 		h.DELETE("/:id", r.deleteAward)
 		h.GET("/list", r.listAwards)
+		h.GET("/grants", r.listAwardGrants)
 	}
 }
 
@@ -127,6 +128,7 @@ func (ar *awardRoutes) updateAward(c *gin.Context) {
 // @Success 204 {object} nil
 // @Failure 400 {object} errResponse
 // @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
 // @Failure 500 {object} errResponse
 // @Router /award/{id} [delete]
 func (ar *awardRoutes) deleteAward(c *gin.Context) {
@@ -176,3 +178,38 @@ func (ar *awardRoutes) listAwards(c *gin.Context) {
 
 	c.JSON(http.StatusOK, awards)
 }
+
+// @Summary Get award grants
+// @Tags award
+// @Description Get award list from the catalog with how many times each award was granted and to whom
+// @ID get-award-grants
+// @Produce json
+// @Param page_size query string false "Enter page size" example="10"
+// @Param page_number query string false "Enter page number" example="1"
+// @Success 200 {object} []entity.AwardGrants
+// @Failure 500 {object} errResponse
+// @Router /award/grants [get]
+func (ar *awardRoutes) listAwardGrants(c *gin.Context) {
+	var pageSize, pageNumber int64
+
+	pageSize, err := strconv.ParseInt(c.Query("page_size"), 10, 64)
+	if err != nil {
+		ar.l.Warn("use default page size 10, because: %s", err.Error())
+		pageSize = defaultPageSize
+	}
+
+	pageNumber, err = strconv.ParseInt(c.Query("page_number"), 10, 64)
+	if err != nil {
+		ar.l.Warn("use default page number 1, because: %s", err.Error())
+		pageNumber = defaultPageNumber
+	}
+
+	grants, err := ar.a.GetAwardGrants(c.Request.Context(), pageSize, pageNumber)
+	if err != nil {
+		ar.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, grants)
+}
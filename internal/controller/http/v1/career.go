@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/pkg/logger"
+)
+
+type careerRoutes struct {
+	cr usecase.Career
+	l  logger.Interface
+}
+
+func newCareerRoutes(handler *gin.RouterGroup, cr usecase.Career, l logger.Interface) {
+	r := careerRoutes{
+		cr: cr,
+		l:  l,
+	}
+
+	h := handler.Group("/player")
+	{
+		h.GET("/:id/teammates", r.getTeammates)
+		h.GET("/:id/separation/:other", r.getSeparation)
+		h.GET("/:id/common_opponents/:other", r.getCommonOpponents)
+	}
+}
+
+// @Summary Get teammates
+// @Tags player
+// @Description Get current and former teammates: players on the same team roster at the same time or on the same team in a match
+// @ID get-player-teammates
+// @Produce json
+// @Param id path string true "Enter player id"
+// @Success 200 {object} []entity.Teammate
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /player/{id}/teammates [get]
+func (cr *careerRoutes) getTeammates(c *gin.Context) {
+	teammates, err := cr.cr.GetTeammates(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		cr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, teammates)
+}
+
+// @Summary Get degrees of separation
+// @Tags player
+// @Description Get the shortest chain of players between two players, where neighbours played in the same match
+// @ID get-player-separation
+// @Produce json
+// @Param id path string true "Enter player id"
+// @Param other path string true "Enter other player id"
+// @Success 200 {object} entity.Separation
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /player/{id}/separation/{other} [get]
+func (cr *careerRoutes) getSeparation(c *gin.Context) {
+	separation, err := cr.cr.GetSeparation(c.Request.Context(), c.Param("id"), c.Param("other"))
+	if err != nil {
+		cr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, separation)
+}
+
+// @Summary Get common opponents
+// @Tags player
+// @Description Get teams both players played against and how many games each played against them
+// @ID get-player-common-opponents
+// @Produce json
+// @Param id path string true "Enter player id"
+// @Param other path string true "Enter other player id"
+// @Success 200 {object} []entity.CommonOpponent
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /player/{id}/common_opponents/{other} [get]
+func (cr *careerRoutes) getCommonOpponents(c *gin.Context) {
+	opponents, err := cr.cr.GetCommonOpponents(c.Request.Context(), c.Param("id"), c.Param("other"))
+	if err != nil {
+		cr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, opponents)
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/pkg/logger"
+)
+
+type deletionJobRoutes struct {
+	d usecase.Deletion
+	l logger.Interface
+}
+
+func newDeletionJobRoutes(handler *gin.RouterGroup, d usecase.Deletion, l logger.Interface) {
+	r := deletionJobRoutes{
+		d: d,
+		l: l,
+	}
+
+	h := handler.Group("/deletion_job")
+	{
+		h.GET("/:id", r.getJob)
+		h.POST("/:id/retry", r.retryJob)
+	}
+}
+
+// @Summary Get deletion job
+// @Tags deletion-job
+// @Description Get deletion job with the state of each store step
+// @ID get-deletion-job
+// @Produce json
+// @Param id path string true "Enter deletion job id"
+// @Success 200 {object} entity.DeletionJob
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /deletion_job/{id} [get]
+func (dr *deletionJobRoutes) getJob(c *gin.Context) {
+	jobID := c.Param("id")
+
+	job, err := dr.d.GetJob(c.Request.Context(), jobID)
+	if err != nil {
+		dr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// @Summary Retry deletion job
+// @Tags deletion-job
+// @Description Continue a failed deletion job from its first unfinished step. A finished job is returned as is
+// @Description and a job that is being run right now is rejected
+// @ID retry-deletion-job
+// @Produce json
+// @Param id path string true "Enter deletion job id"
+// @Success 200 {object} entity.DeletionJob
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /deletion_job/{id}/retry [post]
+func (dr *deletionJobRoutes) retryJob(c *gin.Context) {
+	jobID := c.Param("id")
+
+	job, err := dr.d.RetryJob(c.Request.Context(), jobID)
+	if err != nil {
+		dr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
@@ -19,7 +19,13 @@ func prepareError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, apperrors.ErrPlayerNotFound) ||
 		errors.Is(err, apperrors.ErrAwardNotFound) ||
-		errors.Is(err, apperrors.ErrGameNotFound):
+		errors.Is(err, apperrors.ErrGameNotFound) ||
+		errors.Is(err, apperrors.ErrLeagueNotFound) ||
+		errors.Is(err, apperrors.ErrTeamNotFound) ||
+		errors.Is(err, apperrors.ErrPlayoffsNotFound) ||
+		errors.Is(err, apperrors.ErrPlayerStatsNotFound) ||
+		errors.Is(err, apperrors.ErrDeletionJobNotFound) ||
+		errors.Is(err, apperrors.ErrPlayersNotConnected):
 		errorResponse(c, http.StatusNotFound, err.Error())
 	case errors.Is(err, apperrors.ErrInvalidPlayerID) ||
 		errors.Is(err, apperrors.ErrInvalidPlayerPageSize) ||
@@ -29,8 +35,38 @@ func prepareError(c *gin.Context, err error) {
 		errors.Is(err, apperrors.ErrInvalidAwardPageSize) ||
 		errors.Is(err, apperrors.ErrInvalidGameID) ||
 		errors.Is(err, apperrors.ErrInvalidGamePageSize) ||
-		errors.Is(err, apperrors.ErrInvalidGamePageNumber):
+		errors.Is(err, apperrors.ErrInvalidGamePageNumber) ||
+		errors.Is(err, apperrors.ErrInvalidLeagueID) ||
+		errors.Is(err, apperrors.ErrInvalidLeaguePageSize) ||
+		errors.Is(err, apperrors.ErrInvalidLeaguePageNumber) ||
+		errors.Is(err, apperrors.ErrInvalidTeamID) ||
+		errors.Is(err, apperrors.ErrInvalidTeamPageSize) ||
+		errors.Is(err, apperrors.ErrInvalidTeamPageNumber) ||
+		errors.Is(err, apperrors.ErrInvalidGameResult) ||
+		errors.Is(err, apperrors.ErrInvalidTieBreaker) ||
+		errors.Is(err, apperrors.ErrInvalidSchedule) ||
+		errors.Is(err, apperrors.ErrInvalidPlayoffs) ||
+		errors.Is(err, apperrors.ErrInvalidGameEvent) ||
+		errors.Is(err, apperrors.ErrInvalidPlayerStat) ||
+		errors.Is(err, apperrors.ErrInvalidStatScope) ||
+		errors.Is(err, apperrors.ErrInvalidLeaders) ||
+		errors.Is(err, apperrors.ErrInvalidAwardAnalytics) ||
+		errors.Is(err, apperrors.ErrInvalidDeletionJobID):
 		errorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, apperrors.ErrGameAlreadyFinal) ||
+		errors.Is(err, apperrors.ErrGameNotFinal) ||
+		errors.Is(err, apperrors.ErrPlayoffsAlreadyExist) ||
+		errors.Is(err, apperrors.ErrPlayoffWinnerChange) ||
+		errors.Is(err, apperrors.ErrGameEventsClosed) ||
+		errors.Is(err, apperrors.ErrEntityReferenced) ||
+		errors.Is(err, apperrors.ErrScheduleExists) ||
+		errors.Is(err, apperrors.ErrPlayoffGameChange) ||
+		errors.Is(err, apperrors.ErrDeletionJobRunning):
+		errorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, apperrors.ErrUnresolvedReference) ||
+		errors.Is(err, apperrors.ErrSameTeams) ||
+		errors.Is(err, apperrors.ErrScheduleDoesNotFit):
+		errorResponse(c, http.StatusUnprocessableEntity, err.Error())
 	default:
 		errorResponse(c, http.StatusInternalServerError, "internal error")
 	}
@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/romeros69/basket/internal/apperrors"
 	"github.com/romeros69/basket/internal/entity"
 	"github.com/romeros69/basket/internal/usecase"
 	"github.com/romeros69/basket/pkg/logger"
@@ -27,6 +29,8 @@ func newGameRoutes(handler *gin.RouterGroup, g usecase.Game, l logger.Interface)
 		h.PUT("/:id", r.updateGame)
 		h.DELETE("/:id", r.deleteGame)
 		h.GET("/list", r.listGames)
+		h.POST("/:id/result", r.recordResult)
+		h.PUT("/:id/result", r.correctResult)
 	}
 }
 
@@ -36,7 +40,7 @@ type createGameResp struct {
 
 // @Summary Create game
 // @Tags game
-// @Description Create new game
+// @Description Create new scheduled or postponed game. Results are recorded through /game/{id}/result
 // @ID create-game
 // @Accept json
 // @Produce json
@@ -88,7 +92,7 @@ func (gr *gameRoutes) getGame(c *gin.Context) {
 
 // @Summary Update game
 // @Tags game
-// @Description Update game by id
+// @Description Update game schedule, teams and league by id. Status, score and periods are kept, they change only through /game/{id}/result
 // @ID update-game
 // @Accept json
 // @Produce json
@@ -97,6 +101,7 @@ func (gr *gameRoutes) getGame(c *gin.Context) {
 // @Success 200 {object} entity.Game
 // @Failure 400 {object} errResponse
 // @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
 // @Failure 500 {object} errResponse
 // @Router /game/{id} [put]
 func (gr *gameRoutes) updateGame(c *gin.Context) {
@@ -121,23 +126,34 @@ func (gr *gameRoutes) updateGame(c *gin.Context) {
 
 // @Summary Delete game
 // @Tags game
-// @Description Delete game by id
+// @Description Delete game by id according to its deletion policy. Stats and award graph records are removed or kept by the policy.
+// @Description If a store fails, the deletion job is returned with 202 and retried in the background
 // @ID delete-game
+// @Produce json
 // @Param id path string true "Enter id game"
 // @Success 204 {object} nil
+// @Success 202 {object} entity.DeletionJob
 // @Failure 400 {object} errResponse
 // @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
 // @Failure 500 {object} errResponse
 // @Router /game/{id} [delete]
 func (gr *gameRoutes) deleteGame(c *gin.Context) {
 	gameID := c.Param("id")
 
-	if err := gr.g.DeleteGame(c.Request.Context(), gameID); err != nil {
+	job, err := gr.g.DeleteGame(c.Request.Context(), gameID)
+	if err != nil {
 		gr.l.Error(err.Error())
 		prepareError(c, err)
 		return
 	}
 
+	if job.Status != entity.DeletionJobDone {
+		gr.l.Warn("game %s deletion job %s is %s", gameID, job.ID, job.Status)
+		c.JSON(http.StatusAccepted, job)
+		return
+	}
+
 	c.JSON(http.StatusNoContent, nil)
 }
 
@@ -176,3 +192,81 @@ func (gr *gameRoutes) listGames(c *gin.Context) {
 
 	c.JSON(http.StatusOK, games)
 }
+
+// @Summary Record game result
+// @Tags game
+// @Description Record score, periods and status of the game that is not final yet
+// @ID record-game-result
+// @Accept json
+// @Produce json
+// @Param id path string true "Enter id game"
+// @Param result body entity.GameResult true "Enter game result"
+// @Success 200 {object} entity.Game
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /game/{id}/result [post]
+func (gr *gameRoutes) recordResult(c *gin.Context) {
+	gameID := c.Param("id")
+
+	var resultParam entity.GameResult
+	if err := c.ShouldBindJSON(&resultParam); err != nil {
+		gr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	game, err := gr.g.RecordResult(c.Request.Context(), gameID, &resultParam)
+	if errors.Is(err, apperrors.ErrCareerGraphNotUpdated) {
+		// результат и статистика сохранены, граф карьеры догоняется командой backfill career
+		gr.l.Warn(err.Error())
+		err = nil
+	}
+	if err != nil {
+		gr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, game)
+}
+
+// @Summary Correct game result
+// @Tags game
+// @Description Correct score and periods of the final game
+// @ID correct-game-result
+// @Accept json
+// @Produce json
+// @Param id path string true "Enter id game"
+// @Param result body entity.GameResult true "Enter corrected game result"
+// @Success 200 {object} entity.Game
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /game/{id}/result [put]
+func (gr *gameRoutes) correctResult(c *gin.Context) {
+	gameID := c.Param("id")
+
+	var resultParam entity.GameResult
+	if err := c.ShouldBindJSON(&resultParam); err != nil {
+		gr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	game, err := gr.g.CorrectResult(c.Request.Context(), gameID, &resultParam)
+	if errors.Is(err, apperrors.ErrCareerGraphNotUpdated) {
+		// результат и статистика сохранены, граф карьеры догоняется командой backfill career
+		gr.l.Warn(err.Error())
+		err = nil
+	}
+	if err != nil {
+		gr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, game)
+}
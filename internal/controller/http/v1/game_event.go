@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/pkg/logger"
+)
+
+type gameEventRoutes struct {
+	ge usecase.GameEvent
+	l  logger.Interface
+}
+
+func newGameEventRoutes(handler *gin.RouterGroup, ge usecase.GameEvent, l logger.Interface) {
+	r := gameEventRoutes{
+		ge: ge,
+		l:  l,
+	}
+
+	h := handler.Group("/game")
+	{
+		h.POST("/:id/events", r.addEvents)
+		h.GET("/:id/events", r.getEvents)
+		h.GET("/:id/box_score", r.getBoxScore)
+	}
+}
+
+// @Summary Add game events
+// @Tags game
+// @Description Append play-by-play events to the game. When the game goes final, player stats are derived from its events
+// @ID add-game-events
+// @Accept json
+// @Produce json
+// @Param id path string true "Enter game id"
+// @Param events body []entity.GameEvent true "Enter events in order they happened"
+// @Success 201 {object} []entity.GameEvent
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
+// @Failure 422 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /game/{id}/events [post]
+func (gr *gameEventRoutes) addEvents(c *gin.Context) {
+	gameID := c.Param("id")
+
+	var eventsParam []*entity.GameEvent
+	if err := c.ShouldBindJSON(&eventsParam); err != nil {
+		gr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	events, err := gr.ge.AddEvents(c.Request.Context(), gameID, eventsParam)
+	if err != nil {
+		gr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, events)
+}
+
+// @Summary Get game events
+// @Tags game
+// @Description Get play-by-play events of the game
+// @ID get-game-events
+// @Produce json
+// @Param id path string true "Enter game id"
+// @Success 200 {object} []entity.GameEvent
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /game/{id}/events [get]
+func (gr *gameEventRoutes) getEvents(c *gin.Context) {
+	gameID := c.Param("id")
+
+	events, err := gr.ge.GetEvents(c.Request.Context(), gameID)
+	if err != nil {
+		gr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// @Summary Get game box score
+// @Tags game
+// @Description Get player stats derived from the game events so far
+// @ID get-game-box-score
+// @Produce json
+// @Param id path string true "Enter game id"
+// @Success 200 {object} []entity.PlayerStat
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /game/{id}/box_score [get]
+func (gr *gameEventRoutes) getBoxScore(c *gin.Context) {
+	gameID := c.Param("id")
+
+	stats, err := gr.ge.GetBoxScore(c.Request.Context(), gameID)
+	if err != nil {
+		gr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
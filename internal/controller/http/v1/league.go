@@ -7,6 +7,7 @@ import (
 	"github.com/romeros69/basket/pkg/logger"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 type leagueRoutes struct {
@@ -27,6 +28,7 @@ func newLeagueRoutes(handler *gin.RouterGroup, lg usecase.League, l logger.Inter
 		h.PUT("/:id", r.updateLeague)
 		h.DELETE("/:id", r.deleteLeague)
 		h.GET("/list", r.listLeagues)
+		h.GET("/:id/standings", r.getStandings)
 	}
 }
 
@@ -176,3 +178,34 @@ func (lr *leagueRoutes) listLeagues(c *gin.Context) {
 
 	c.JSON(http.StatusOK, leagues)
 }
+
+// @Summary Get league standings
+// @Tags league
+// @Description Get standings computed from final games of the league
+// @ID get-league-standings
+// @Produce json
+// @Param id path string true "Enter league id"
+// @Param conference query string false "Enter conference to get standings of" example="East"
+// @Param tie_breakers query string false "Enter comma separated tie-breaker rules: head_to_head, point_differential, conference_record" example="head_to_head,point_differential"
+// @Success 200 {object} []entity.Standing
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /league/{id}/standings [get]
+func (lr *leagueRoutes) getStandings(c *gin.Context) {
+	leagueID := c.Param("id")
+
+	var tieBreakers []string
+	if rules := c.Query("tie_breakers"); rules != "" {
+		tieBreakers = strings.Split(rules, ",")
+	}
+
+	standings, err := lr.lg.GetStandings(c.Request.Context(), leagueID, c.Query("conference"), tieBreakers)
+	if err != nil {
+		lr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, standings)
+}
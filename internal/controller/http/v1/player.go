@@ -1,10 +1,12 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/romeros69/basket/internal/apperrors"
 	"github.com/romeros69/basket/internal/entity"
 	"github.com/romeros69/basket/internal/usecase"
 	"github.com/romeros69/basket/pkg/logger"
@@ -59,6 +61,11 @@ func (pr *playerRoutes) createPlayer(c *gin.Context) {
 	}
 
 	playerID, err := pr.p.CreatePlayer(c.Request.Context(), &playerParam)
+	if errors.Is(err, apperrors.ErrCareerGraphNotUpdated) {
+		// игрок уже в каталоге, граф карьеры догоняется командой backfill career
+		pr.l.Warn(err.Error())
+		err = nil
+	}
 	if err != nil {
 		pr.l.Error(err.Error())
 		prepareError(c, err)
@@ -116,6 +123,10 @@ func (pr *playerRoutes) updatePlayer(c *gin.Context) {
 	}
 
 	newPlayer, err := pr.p.UpdatePlayer(c.Request.Context(), playerID, &playerParam)
+	if errors.Is(err, apperrors.ErrCareerGraphNotUpdated) {
+		pr.l.Warn(err.Error())
+		err = nil
+	}
 	if err != nil {
 		pr.l.Error(err.Error())
 		prepareError(c, err)
@@ -127,23 +138,34 @@ func (pr *playerRoutes) updatePlayer(c *gin.Context) {
 
 // @Summary Delete player
 // @Tags player
-// @Description Delete player by id
+// @Description Delete player by id according to its deletion policy. Stats and award graph records are removed or kept by the policy.
+// @Description If a store fails, the deletion job is returned with 202 and retried in the background
 // @ID delete-player
+// @Produce json
 // @Param id path string true "Enter id player"
 // @Success 204 {object} nil
+// @Success 202 {object} entity.DeletionJob
 // @Failure 400 {object} errResponse
 // @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
 // @Failure 500 {object} errResponse
 // @Router /player/{id} [delete]
 func (pr *playerRoutes) deletePlayer(c *gin.Context) {
 	playerID := c.Param("id")
 
-	if err := pr.p.DeletePlayer(c.Request.Context(), playerID); err != nil {
+	job, err := pr.p.DeletePlayer(c.Request.Context(), playerID)
+	if err != nil {
 		pr.l.Error(err.Error())
 		prepareError(c, err)
 		return
 	}
 
+	if job.Status != entity.DeletionJobDone {
+		pr.l.Warn("player %s deletion job %s is %s", playerID, job.ID, job.Status)
+		c.JSON(http.StatusAccepted, job)
+		return
+	}
+
 	c.JSON(http.StatusNoContent, nil)
 }
 
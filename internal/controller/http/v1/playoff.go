@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/pkg/logger"
+)
+
+type playoffRoutes struct {
+	po usecase.Playoff
+	l  logger.Interface
+}
+
+func newPlayoffRoutes(handler *gin.RouterGroup, po usecase.Playoff, l logger.Interface) {
+	r := playoffRoutes{
+		po: po,
+		l:  l,
+	}
+
+	h := handler.Group("/league")
+	{
+		h.POST("/:id/playoffs", r.createPlayoffs)
+		h.GET("/:id/playoffs", r.getPlayoffs)
+		h.POST("/:id/playoffs/sync", r.syncPlayoffs)
+	}
+}
+
+// @Summary Create playoffs
+// @Tags league
+// @Description Seed top teams of the regular season standings into a bracket and create the first games of every series
+// @ID create-league-playoffs
+// @Accept json
+// @Produce json
+// @Param id path string true "Enter league id"
+// @Param playoffs body entity.PlayoffRequest true "Enter playoff format"
+// @Success 201 {object} entity.PlayoffBracket
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /league/{id}/playoffs [post]
+func (pr *playoffRoutes) createPlayoffs(c *gin.Context) {
+	leagueID := c.Param("id")
+
+	var playoffParam entity.PlayoffRequest
+	if err := c.ShouldBindJSON(&playoffParam); err != nil {
+		pr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	bracket, err := pr.po.CreatePlayoffs(c.Request.Context(), leagueID, &playoffParam)
+	if err != nil {
+		pr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, bracket)
+}
+
+// @Summary Get playoffs
+// @Tags league
+// @Description Get playoff bracket of the league
+// @ID get-league-playoffs
+// @Produce json
+// @Param id path string true "Enter league id"
+// @Success 200 {object} entity.PlayoffBracket
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /league/{id}/playoffs [get]
+func (pr *playoffRoutes) getPlayoffs(c *gin.Context) {
+	leagueID := c.Param("id")
+
+	bracket, err := pr.po.GetPlayoffs(c.Request.Context(), leagueID)
+	if err != nil {
+		pr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bracket)
+}
+
+// @Summary Sync playoffs
+// @Tags league
+// @Description Recount series wins from games, advance winners and create missing series games
+// @ID sync-league-playoffs
+// @Produce json
+// @Param id path string true "Enter league id"
+// @Success 200 {object} entity.PlayoffBracket
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /league/{id}/playoffs/sync [post]
+func (pr *playoffRoutes) syncPlayoffs(c *gin.Context) {
+	leagueID := c.Param("id")
+
+	bracket, err := pr.po.SyncPlayoffs(c.Request.Context(), leagueID)
+	if err != nil {
+		pr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bracket)
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/pkg/logger"
+)
+
+type profileRoutes struct {
+	pf usecase.Profile
+	l  logger.Interface
+}
+
+func newProfileRoutes(handler *gin.RouterGroup, pf usecase.Profile, l logger.Interface) {
+	r := profileRoutes{
+		pf: pf,
+		l:  l,
+	}
+
+	h := handler.Group("/player")
+	{
+		h.GET("/:id/profile", r.getPlayerProfile)
+	}
+}
+
+// @Summary Get player profile
+// @Tags player
+// @Description Get player, career and season stat lines and award history in one response. If a store fails, its part is omitted and the reason is listed in warnings
+// @ID get-player-profile
+// @Produce json
+// @Param id path string true "Enter player id"
+// @Success 200 {object} entity.PlayerProfile
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /player/{id}/profile [get]
+func (pr *profileRoutes) getPlayerProfile(c *gin.Context) {
+	playerID := c.Param("id")
+
+	profile, err := pr.pf.GetPlayerProfile(c.Request.Context(), playerID)
+	if err != nil {
+		pr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	for _, warning := range profile.Warnings {
+		pr.l.Warn("player %s profile: %s", playerID, warning)
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
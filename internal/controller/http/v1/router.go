@@ -17,7 +17,7 @@ import (
 // @host        localhost:8080
 // @schemes 	http
 // @BasePath    /v1
-func NewRouter(handler *gin.Engine, p usecase.Player, a usecase.Award, g usecase.Game, lg usecase.League, as usecase.StatAwards, sp usecase.StatPlayer, l logger.Interface) {
+func NewRouter(handler *gin.Engine, p usecase.Player, t usecase.Team, a usecase.Award, g usecase.Game, ge usecase.GameEvent, lg usecase.League, sc usecase.Scheduler, po usecase.Playoff, as usecase.StatAwards, sp usecase.StatPlayer, pf usecase.Profile, cr usecase.Career, d usecase.Deletion, l logger.Interface) {
 	handler.Use(gin.Logger())
 	handler.Use(gin.Recovery())
 
@@ -27,10 +27,17 @@ func NewRouter(handler *gin.Engine, p usecase.Player, a usecase.Award, g usecase
 	h := handler.Group("/v1")
 	{
 		newPlayerRoutes(h, p, l)
+		newTeamRoutes(h, t, l)
 		newAwardRoutes(h, a, l)
 		newGameRoutes(h, g, l)
+		newGameEventRoutes(h, ge, l)
 		newLeagueRoutes(h, lg, l)
+		newScheduleRoutes(h, sc, l)
+		newPlayoffRoutes(h, po, l)
 		newStatAwardsRoutes(h, as, l)
 		newStatPlayerRoutes(h, sp, l)
+		newProfileRoutes(h, pf, l)
+		newCareerRoutes(h, cr, l)
+		newDeletionJobRoutes(h, d, l)
 	}
 }
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/pkg/logger"
+)
+
+type scheduleRoutes struct {
+	sc usecase.Scheduler
+	l  logger.Interface
+}
+
+func newScheduleRoutes(handler *gin.RouterGroup, sc usecase.Scheduler, l logger.Interface) {
+	r := scheduleRoutes{
+		sc: sc,
+		l:  l,
+	}
+
+	h := handler.Group("/league")
+	{
+		h.POST("/:id/schedule", r.generateSchedule)
+	}
+}
+
+// @Summary Generate season schedule
+// @Tags league
+// @Description Generate balanced round-robin regular season for the league and create its games
+// @ID generate-league-schedule
+// @Accept json
+// @Produce json
+// @Param id path string true "Enter league id"
+// @Param schedule body entity.ScheduleRequest true "Enter schedule constraints"
+// @Success 201 {object} entity.Schedule
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
+// @Failure 422 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /league/{id}/schedule [post]
+func (sr *scheduleRoutes) generateSchedule(c *gin.Context) {
+	leagueID := c.Param("id")
+
+	var scheduleParam entity.ScheduleRequest
+	if err := c.ShouldBindJSON(&scheduleParam); err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	schedule, err := sr.sc.GenerateSchedule(c.Request.Context(), leagueID, &scheduleParam)
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if scheduleParam.DryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, schedule)
+}
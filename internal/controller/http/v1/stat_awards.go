@@ -1,9 +1,12 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/romeros69/basket/internal/apperrors"
 	"github.com/romeros69/basket/internal/entity"
 	"github.com/romeros69/basket/internal/usecase"
 	"github.com/romeros69/basket/pkg/logger"
@@ -27,6 +30,10 @@ func newStatAwardsRoutes(handler *gin.RouterGroup, sa usecase.StatAwards, l logg
 		h.GET("/match/:id", r.viewPlayersAndRewardsInMatch)
 		h.GET("/player/:id", r.ViewRewardsForPlayer)
 		h.GET("/reward/:id", r.ViewWhoGotSpecificReward)
+		h.GET("/player/:id/co_awarded", r.getCoAwardedPlayers)
+		h.GET("/tournament/:id/most_decorated", r.getMostDecoratedPlayers)
+		h.GET("/co_occurrence", r.getAwardCoOccurrence)
+		h.GET("/centrality", r.getDegreeCentrality)
 	}
 }
 
@@ -37,7 +44,9 @@ func newStatAwardsRoutes(handler *gin.RouterGroup, sa usecase.StatAwards, l logg
 // @Accept json
 // @Produce json
 // @Param player body entity.RewardStat true "Enter new record info"
+// @Param skip_ref_check query bool false "Don't check that player, match, reward and tournament exist (bulk backfills)"
 // @Success 201 {object} nil
+// @Failure 422 {object} errResponse
 // @Failure 500 {object} errResponse
 // @Router /stat_awards [post]
 func (sr *statAwardsRoutes) createRecord(c *gin.Context) {
@@ -48,7 +57,7 @@ func (sr *statAwardsRoutes) createRecord(c *gin.Context) {
 		return
 	}
 
-	err := sr.sa.CreateRecord(c.Request.Context(), statParam)
+	err := sr.sa.CreateRecord(referenceCheckContext(c, sr.l), statParam)
 	if err != nil {
 		sr.l.Error(err.Error())
 		prepareError(c, err)
@@ -145,3 +154,131 @@ func (sr *statAwardsRoutes) ViewWhoGotSpecificReward(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// analyticsLimit - параметр limit запроса аналитики, 0 - значение по умолчанию
+func analyticsLimit(c *gin.Context) (int, error) {
+	limit := c.Query("limit")
+	if limit == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil {
+		return 0, fmt.Errorf("%w: limit: %s", apperrors.ErrInvalidAwardAnalytics, err)
+	}
+	return n, nil
+}
+
+// @Summary Get co-awarded players
+// @Tags stat
+// @Description Get players awarded in the same matches or tournaments as the player
+// @ID get-stat-co-awarded
+// @Produce json
+// @Param id path string true "Enter player id"
+// @Param limit query string false "Enter number of players, 10 by default" example="10"
+// @Success 200 {object} []entity.CoAwardedPlayer
+// @Failure 400 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /stat_awards/player/{id}/co_awarded [get]
+func (sr *statAwardsRoutes) getCoAwardedPlayers(c *gin.Context) {
+	limit, err := analyticsLimit(c)
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	result, err := sr.sa.GetCoAwardedPlayers(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Get most decorated players
+// @Tags stat
+// @Description Get players of the tournament with the most awards
+// @ID get-stat-most-decorated
+// @Produce json
+// @Param id path string true "Enter tournament id"
+// @Param limit query string false "Enter number of players, 10 by default" example="10"
+// @Success 200 {object} []entity.DecoratedPlayer
+// @Failure 400 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /stat_awards/tournament/{id}/most_decorated [get]
+func (sr *statAwardsRoutes) getMostDecoratedPlayers(c *gin.Context) {
+	limit, err := analyticsLimit(c)
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	result, err := sr.sa.GetMostDecoratedPlayers(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Get award co-occurrence
+// @Tags stat
+// @Description Get pairs of awards that go to the same players
+// @ID get-stat-co-occurrence
+// @Produce json
+// @Param award query string false "Enter award id to get only pairs with this award"
+// @Param limit query string false "Enter number of pairs, 10 by default" example="10"
+// @Success 200 {object} []entity.AwardCoOccurrence
+// @Failure 400 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /stat_awards/co_occurrence [get]
+func (sr *statAwardsRoutes) getAwardCoOccurrence(c *gin.Context) {
+	limit, err := analyticsLimit(c)
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	result, err := sr.sa.GetAwardCoOccurrence(c.Request.Context(), c.Query("award"), limit)
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Get degree centrality
+// @Tags stat
+// @Description Get players with the most distinct co-awarded players in the same matches
+// @ID get-stat-centrality
+// @Produce json
+// @Param limit query string false "Enter number of players, 10 by default" example="10"
+// @Success 200 {object} []entity.PlayerCentrality
+// @Failure 400 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /stat_awards/centrality [get]
+func (sr *statAwardsRoutes) getDegreeCentrality(c *gin.Context) {
+	limit, err := analyticsLimit(c)
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	result, err := sr.sa.GetDegreeCentrality(c.Request.Context(), limit)
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
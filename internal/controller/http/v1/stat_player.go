@@ -1,15 +1,27 @@
 package v1
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"sort"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/romeros69/basket/internal/apperrors"
 	"github.com/romeros69/basket/internal/entity"
 	"github.com/romeros69/basket/internal/usecase"
 	"github.com/romeros69/basket/pkg/logger"
 )
 
+// maxNDJSONLine - самая длинная строка NDJSON в пакетной вставке статистики
+const maxNDJSONLine = 1 << 20
+
 type statPlayerRoutes struct {
 	sp usecase.StatPlayer
 	l  logger.Interface
@@ -24,20 +36,37 @@ func newStatPlayerRoutes(handler *gin.RouterGroup, sp usecase.StatPlayer, l logg
 	h := handler.Group("/stat_player")
 	{
 		h.POST("", r.insertPlayer)
+		h.POST("/batch", r.insertPlayerBatch)
 		h.GET("/:pid/:mid", r.getPlayerStatsByIDAndMatch)
 		h.GET("/goals/:mid", r.getPlayersWithAvgGoalsGreaterThanByMatch)
 		h.GET("/all_points/:mid", r.getPlayersWithTotalAvgStatsGreaterThanByMatch)
 	}
+
+	p := handler.Group("/player")
+	{
+		p.GET("/:id/metrics", r.getPlayerMetrics)
+		p.GET("/:id/seasons", r.getPlayerSeasonStats)
+	}
+
+	t := handler.Group("/team")
+	{
+		t.GET("/:id/seasons", r.getTeamSeasonStats)
+	}
+
+	handler.GET("/leaders", r.getLeaders)
 }
 
 // @Summary Create stat player
 // @Tags stat-player
-// @Description Create new stat player
+// @Description Create new stat player. Points and total rebounds are calculated when omitted
 // @ID create-stat-player
 // @Accept json
 // @Produce json
 // @Param player body entity.PlayerStat true "Enter new player stat"
+// @Param skip_ref_check query bool false "Don't check that player, match, team and league exist (bulk backfills)"
 // @Success 201 {object} nil
+// @Failure 400 {object} errResponse
+// @Failure 422 {object} errResponse
 // @Failure 500 {object} errResponse
 // @Router /stat_player [post]
 func (sr *statPlayerRoutes) insertPlayer(c *gin.Context) {
@@ -48,7 +77,12 @@ func (sr *statPlayerRoutes) insertPlayer(c *gin.Context) {
 		return
 	}
 
-	err := sr.sp.InsertPlayerStat(c.Request.Context(), statPlayerParam)
+	err := sr.sp.InsertPlayerStat(referenceCheckContext(c, sr.l), statPlayerParam)
+	if errors.Is(err, apperrors.ErrCareerGraphNotUpdated) {
+		// статистика уже записана, граф карьеры догоняется командой backfill career
+		sr.l.Warn(err.Error())
+		err = nil
+	}
 	if err != nil {
 		sr.l.Error(err.Error())
 		prepareError(c, err)
@@ -58,6 +92,116 @@ func (sr *statPlayerRoutes) insertPlayer(c *gin.Context) {
 	c.JSON(http.StatusCreated, nil)
 }
 
+// @Summary Create stat players in batch
+// @Tags stat-player
+// @Description Create many stat players at once. The body is a JSON array of stats or NDJSON, one stat per line.
+// @Description Rows are validated one by one and written to storage in batches; rows are numbered from zero
+// @ID create-stat-player-batch
+// @Accept json
+// @Produce json
+// @Param stats body []entity.PlayerStat true "Enter player stats"
+// @Param skip_ref_check query bool false "Don't check that players, matches, teams and leagues exist (bulk backfills)"
+// @Success 200 {object} entity.StatBatchResult
+// @Failure 422 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /stat_player/batch [post]
+func (sr *statPlayerRoutes) insertPlayerBatch(c *gin.Context) {
+	stats, rows, decodeErrors, err := decodeStatBatch(c.Request.Body)
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	result, err := sr.sp.InsertPlayerStats(referenceCheckContext(c, sr.l), stats)
+	if errors.Is(err, apperrors.ErrCareerGraphNotUpdated) {
+		// строки уже записаны, граф карьеры догоняется командой backfill career
+		sr.l.Warn(err.Error())
+		err = nil
+	}
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	// номера строк в итоге - позиции среди разобранных строк, переводим их в позиции в теле запроса
+	for i := range result.Errors {
+		result.Errors[i].Row = rows[result.Errors[i].Row]
+	}
+	result.Received += len(decodeErrors)
+	result.Failed += len(decodeErrors)
+	result.Errors = append(result.Errors, decodeErrors...)
+	sort.Slice(result.Errors, func(i, j int) bool { return result.Errors[i].Row < result.Errors[j].Row })
+
+	c.JSON(http.StatusOK, result)
+}
+
+// decodeStatBatch - разбирает JSON-массив или NDJSON. Строка, которую не удалось разобрать, попадает в ошибки,
+// остальные возвращаются вместе с их номерами в теле запроса
+func decodeStatBatch(body io.Reader) ([]entity.PlayerStat, []int, []entity.StatBatchError, error) {
+	reader := bufio.NewReader(body)
+	first, err := firstNonSpace(reader)
+	if err == io.EOF {
+		return nil, nil, nil, fmt.Errorf("%w: empty batch", apperrors.ErrInvalidPlayerStat)
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var records []json.RawMessage
+	if first == '[' {
+		if err = json.NewDecoder(reader).Decode(&records); err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: malformed JSON array: %s", apperrors.ErrInvalidPlayerStat, err.Error())
+		}
+	} else {
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLine)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			records = append(records, json.RawMessage(bytes.Clone(line)))
+		}
+		if err = scanner.Err(); err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: malformed NDJSON: %s", apperrors.ErrInvalidPlayerStat, err.Error())
+		}
+	}
+
+	var (
+		stats  []entity.PlayerStat
+		rows   []int
+		failed []entity.StatBatchError
+	)
+	for i, record := range records {
+		var stat entity.PlayerStat
+		if err := json.Unmarshal(record, &stat); err != nil {
+			failed = append(failed, entity.StatBatchError{Row: i, Error: err.Error()})
+			continue
+		}
+		stats = append(stats, stat)
+		rows = append(rows, i)
+	}
+
+	return stats, rows, failed, nil
+}
+
+// firstNonSpace - первый значимый символ тела; он остаётся в reader
+func firstNonSpace(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, reader.UnreadByte()
+	}
+}
+
 // @Summary Get player stats by player id and match id
 // @Tags player-stats
 // @Description Get player stats by player id and match id
@@ -147,3 +291,152 @@ func (sr *statPlayerRoutes) getPlayersWithTotalAvgStatsGreaterThanByMatch(c *gin
 
 	c.JSON(http.StatusOK, result)
 }
+
+// @Summary Get player advanced metrics
+// @Tags player-stats
+// @Description Get TS%, eFG%, usage, PER, assist/turnover ratio and per-36/per-100-possession numbers over a match, a date range or a season
+// @ID get-player-metrics
+// @Produce json
+// @Param id path string true "Enter player id"
+// @Param match query string false "Enter match id"
+// @Param from query string false "Enter first game date" example="01.10.23"
+// @Param to query string false "Enter last game date" example="30.06.24"
+// @Param season query string false "Enter season" example="2023/2024"
+// @Success 200 {object} entity.PlayerMetrics
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /player/{id}/metrics [get]
+func (sr *statPlayerRoutes) getPlayerMetrics(c *gin.Context) {
+	playerID := c.Param("id")
+	scope := entity.StatScope{
+		MatchID: c.Query("match"),
+		From:    c.Query("from"),
+		To:      c.Query("to"),
+		Season:  c.Query("season"),
+	}
+
+	metrics, err := sr.sp.GetPlayerMetrics(c.Request.Context(), playerID, scope)
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
+
+// @Summary Get player season stats
+// @Tags player-stats
+// @Description Get player season totals and per-game averages, kept up to date on every stat insert
+// @ID get-player-season-stats
+// @Produce json
+// @Param id path string true "Enter player id"
+// @Param season query string false "Enter season, all seasons by default" example="2023/2024"
+// @Success 200 {object} []entity.PlayerSeasonStat
+// @Failure 500 {object} errResponse
+// @Router /player/{id}/seasons [get]
+func (sr *statPlayerRoutes) getPlayerSeasonStats(c *gin.Context) {
+	playerID := c.Param("id")
+
+	stats, err := sr.sp.GetPlayerSeasonStats(c.Request.Context(), playerID, c.Query("season"))
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// @Summary Get team season stats
+// @Tags player-stats
+// @Description Get team season totals and per-game averages, kept up to date on every stat insert
+// @ID get-team-season-stats
+// @Produce json
+// @Param id path string true "Enter team id"
+// @Param season query string false "Enter season, all seasons by default" example="2023/2024"
+// @Success 200 {object} []entity.TeamSeasonStat
+// @Failure 500 {object} errResponse
+// @Router /team/{id}/seasons [get]
+func (sr *statPlayerRoutes) getTeamSeasonStats(c *gin.Context) {
+	teamID := c.Param("id")
+
+	stats, err := sr.sp.GetTeamSeasonStats(c.Request.Context(), teamID, c.Query("season"))
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// @Summary Get stat leaders
+// @Tags player-stats
+// @Description Get players ranked by a stat; players with equal values share the rank
+// @ID get-leaders
+// @Produce json
+// @Param stat query string true "Enter stat" example="points"
+// @Param per query string false "Enter game, total or 36, game by default" example="game"
+// @Param season query string false "Enter season" example="2023/2024"
+// @Param league query string false "Enter league id"
+// @Param team query string false "Enter team id"
+// @Param min_games query string false "Enter minimum games played" example="10"
+// @Param limit query string false "Enter number of leaders, 10 by default" example="10"
+// @Success 200 {object} []entity.Leader
+// @Failure 400 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /leaders [get]
+func (sr *statPlayerRoutes) getLeaders(c *gin.Context) {
+	req := entity.LeadersRequest{
+		Stat:   c.Query("stat"),
+		Per:    c.Query("per"),
+		Season: c.Query("season"),
+		League: c.Query("league"),
+		Team:   c.Query("team"),
+	}
+
+	var err error
+	if minGames := c.Query("min_games"); minGames != "" {
+		if req.MinGames, err = strconv.Atoi(minGames); err != nil {
+			sr.l.Error(err.Error())
+			prepareError(c, fmt.Errorf("%w: min_games: %s", apperrors.ErrInvalidLeaders, err))
+			return
+		}
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if req.Limit, err = strconv.Atoi(limit); err != nil {
+			sr.l.Error(err.Error())
+			prepareError(c, fmt.Errorf("%w: limit: %s", apperrors.ErrInvalidLeaders, err))
+			return
+		}
+	}
+
+	leaders, err := sr.sp.GetLeaders(c.Request.Context(), req)
+	if err != nil {
+		sr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, leaders)
+}
+
+// referenceCheckContext - контекст запроса; skip_ref_check=true отключает проверку ссылок на каталог
+func referenceCheckContext(c *gin.Context, l logger.Interface) context.Context {
+	ctx := c.Request.Context()
+	param := c.Query("skip_ref_check")
+	if param == "" {
+		return ctx
+	}
+	skip, err := strconv.ParseBool(param)
+	if err != nil {
+		l.Warn("check references, because skip_ref_check is not a bool: %s", err.Error())
+		return ctx
+	}
+	if skip {
+		return usecase.WithoutReferenceCheck(ctx)
+	}
+	return ctx
+}
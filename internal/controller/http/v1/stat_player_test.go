@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+func TestDecodeStatBatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStats  []entity.PlayerStat
+		wantRows   []int
+		wantFailed []int
+	}{
+		{
+			name:      "json array",
+			body:      `[{"playerId":"p1","fgm":1},{"playerId":"p2","fgm":2}]`,
+			wantStats: []entity.PlayerStat{{PlayerID: "p1", FGM: 1}, {PlayerID: "p2", FGM: 2}},
+			wantRows:  []int{0, 1},
+		},
+		{
+			name:      "json array after leading whitespace",
+			body:      "\n\t [{\"playerId\":\"p1\"}]",
+			wantStats: []entity.PlayerStat{{PlayerID: "p1"}},
+			wantRows:  []int{0},
+		},
+		{
+			name:       "json array with a bad row",
+			body:       `[{"playerId":"p1"},{"playerId":"p2","fgm":"three"},{"playerId":"p3"}]`,
+			wantStats:  []entity.PlayerStat{{PlayerID: "p1"}, {PlayerID: "p3"}},
+			wantRows:   []int{0, 2},
+			wantFailed: []int{1},
+		},
+		{
+			name:      "ndjson",
+			body:      "{\"playerId\":\"p1\",\"fgm\":1}\n{\"playerId\":\"p2\",\"fgm\":2}\n",
+			wantStats: []entity.PlayerStat{{PlayerID: "p1", FGM: 1}, {PlayerID: "p2", FGM: 2}},
+			wantRows:  []int{0, 1},
+		},
+		{
+			name:      "ndjson skips blank lines and crlf",
+			body:      "{\"playerId\":\"p1\"}\r\n\r\n   \n{\"playerId\":\"p2\"}",
+			wantStats: []entity.PlayerStat{{PlayerID: "p1"}, {PlayerID: "p2"}},
+			wantRows:  []int{0, 1},
+		},
+		{
+			name:       "ndjson with bad lines",
+			body:       "{\"playerId\":\"p1\"}\nnot json\n{\"playerId\":\"p3\"}\n{\"playerId\":\n",
+			wantStats:  []entity.PlayerStat{{PlayerID: "p1"}, {PlayerID: "p3"}},
+			wantRows:   []int{0, 2},
+			wantFailed: []int{1, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, rows, failed, err := decodeStatBatch(strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("decodeStatBatch() error = %v", err)
+			}
+			if !reflect.DeepEqual(stats, tt.wantStats) {
+				t.Errorf("stats = %+v, want %+v", stats, tt.wantStats)
+			}
+			if !reflect.DeepEqual(rows, tt.wantRows) {
+				t.Errorf("rows = %v, want %v", rows, tt.wantRows)
+			}
+
+			var failedRows []int
+			for _, f := range failed {
+				if f.Error == "" {
+					t.Errorf("row %d failed without a message", f.Row)
+				}
+				failedRows = append(failedRows, f.Row)
+			}
+			if !reflect.DeepEqual(failedRows, tt.wantFailed) {
+				t.Errorf("failed rows = %v, want %v", failedRows, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestDecodeStatBatchRejectsBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "empty body", body: ""},
+		{name: "whitespace only", body: " \n\t"},
+		{name: "unterminated json array", body: `[{"playerId":"p1"}`},
+		{name: "ndjson line over the limit", body: `{"playerId":"` + strings.Repeat("x", maxNDJSONLine) + `"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := decodeStatBatch(strings.NewReader(tt.body))
+			if !errors.Is(err, apperrors.ErrInvalidPlayerStat) {
+				t.Errorf("decodeStatBatch() error = %v, want %v", err, apperrors.ErrInvalidPlayerStat)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/pkg/logger"
+	"net/http"
+	"strconv"
+)
+
+type teamRoutes struct {
+	t usecase.Team
+	l logger.Interface
+}
+
+func newTeamRoutes(handler *gin.RouterGroup, t usecase.Team, l logger.Interface) {
+	r := teamRoutes{
+		t: t,
+		l: l,
+	}
+
+	h := handler.Group("/team")
+	{
+		h.POST("", r.createTeam)
+		h.GET("/:id", r.getTeam)
+		h.PUT("/:id", r.updateTeam)
+		h.DELETE("/:id", r.deleteTeam)
+		h.GET("/list", r.listTeams)
+		h.GET("/:id/players", r.getRoster)
+		h.GET("/:id/games", r.getSchedule)
+	}
+}
+
+type createTeamResp struct {
+	TeamID string `json:"team_id"`
+}
+
+// @Summary Create team
+// @Tags team
+// @Description Create new team
+// @ID create-team
+// @Accept json
+// @Produce json
+// @Param team body entity.Team true "Enter new team info"
+// @Success 201 {object} createTeamResp
+// @Failure 500 {object} errResponse
+// @Router /team [post]
+func (tr *teamRoutes) createTeam(c *gin.Context) {
+	var teamParam entity.Team
+	if err := c.ShouldBindJSON(&teamParam); err != nil {
+		tr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	teamID, err := tr.t.CreateTeam(c.Request.Context(), &teamParam)
+	if err != nil {
+		tr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, createTeamResp{TeamID: teamID})
+}
+
+// @Summary Get team
+// @Tags team
+// @Description Get team by id
+// @ID get-team
+// @Produce json
+// @Param id path string true "Enter team id"
+// @Success 200 {object} entity.Team
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /team/{id} [get]
+func (tr *teamRoutes) getTeam(c *gin.Context) {
+	teamID := c.Param("id")
+
+	team, err := tr.t.GetTeam(c.Request.Context(), teamID)
+	if err != nil {
+		tr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+// @Summary Update team
+// @Tags team
+// @Description Update team by id
+// @ID update-team
+// @Accept json
+// @Produce json
+// @Param id path string true "Enter id team"
+// @Param team body entity.Team true "Enter new team info for update"
+// @Success 200 {object} entity.Team
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /team/{id} [put]
+func (tr *teamRoutes) updateTeam(c *gin.Context) {
+	teamID := c.Param("id")
+
+	var teamParam entity.Team
+	if err := c.ShouldBindJSON(&teamParam); err != nil {
+		tr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	newTeam, err := tr.t.UpdateTeam(c.Request.Context(), teamID, &teamParam)
+	if err != nil {
+		tr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newTeam)
+}
+
+// @Summary Delete team
+// @Tags team
+// @Description Delete team by id. A team with players or games can't be deleted
+// @ID delete-team
+// @Param id path string true "Enter id team"
+// @Success 204 {object} nil
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 409 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /team/{id} [delete]
+func (tr *teamRoutes) deleteTeam(c *gin.Context) {
+	teamID := c.Param("id")
+
+	if err := tr.t.DeleteTeam(c.Request.Context(), teamID); err != nil {
+		tr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// @Summary Get team list
+// @Tags team
+// @Description Get team list
+// @ID get-team-list
+// @Produce json
+// @Param page_size query string false "Enter page size" example="10"
+// @Param page_number query string false "Enter page number" example="1"
+// @Success 200 {object} entity.Team
+// @Failure 400 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /team/list [get]
+func (tr *teamRoutes) listTeams(c *gin.Context) {
+	var pageSize, pageNumber int64
+
+	pageSize, err := strconv.ParseInt(c.Query("page_size"), 10, 64)
+	if err != nil {
+		tr.l.Warn("use default page size 10, because: %s", err.Error())
+		pageSize = defaultPageSize
+	}
+
+	pageNumber, err = strconv.ParseInt(c.Query("page_number"), 10, 64)
+	if err != nil {
+		tr.l.Warn("use default page number 1, because: %s", err.Error())
+		pageNumber = defaultPageNumber
+	}
+
+	teams, err := tr.t.GetTeamList(c.Request.Context(), pageSize, pageNumber)
+	if err != nil {
+		tr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, teams)
+}
+
+// @Summary Get team roster
+// @Tags team
+// @Description Get players of the team
+// @ID get-team-roster
+// @Produce json
+// @Param id path string true "Enter team id"
+// @Success 200 {object} []entity.Player
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /team/{id}/players [get]
+func (tr *teamRoutes) getRoster(c *gin.Context) {
+	teamID := c.Param("id")
+
+	players, err := tr.t.GetRoster(c.Request.Context(), teamID)
+	if err != nil {
+		tr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, players)
+}
+
+// @Summary Get team schedule
+// @Tags team
+// @Description Get games of the team
+// @ID get-team-schedule
+// @Produce json
+// @Param id path string true "Enter team id"
+// @Success 200 {object} []entity.Game
+// @Failure 400 {object} errResponse
+// @Failure 404 {object} errResponse
+// @Failure 500 {object} errResponse
+// @Router /team/{id}/games [get]
+func (tr *teamRoutes) getSchedule(c *gin.Context) {
+	teamID := c.Param("id")
+
+	games, err := tr.t.GetSchedule(c.Request.Context(), teamID)
+	if err != nil {
+		tr.l.Error(err.Error())
+		prepareError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, games)
+}
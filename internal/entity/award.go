@@ -1,6 +1,22 @@
 package entity
 
 type Award struct {
+	ID          string `json:"id,omitempty" bson:"-"` // заполняется при чтении из хранилища
 	Tittle      string `json:"name,omitempty" default:"MVP of season 2024"`
 	Description string `json:"surname,omitempty" default:"Best player of season 2024"`
 }
+
+// AwardGrants - награда из каталога и кому она вручалась по графу наград
+type AwardGrants struct {
+	Award      *Award           `json:"award"`
+	Grants     int              `json:"grants"` // сколько раз награда вручалась
+	Recipients []AwardRecipient `json:"recipients"`
+}
+
+// AwardRecipient - игрок, получавший награду, по убыванию числа вручений
+type AwardRecipient struct {
+	Player  string `json:"player"`
+	Name    string `json:"name,omitempty"`
+	Surname string `json:"surname,omitempty"`
+	Grants  int    `json:"grants"`
+}
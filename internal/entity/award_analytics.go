@@ -0,0 +1,39 @@
+package entity
+
+// CoAwardedPlayer - игрок, награждённый в тех же матчах или турнирах, что и заданный
+type CoAwardedPlayer struct {
+	Player            string `json:"player"`
+	Name              string `json:"name,omitempty"`
+	Surname           string `json:"surname,omitempty"`
+	SharedMatches     int    `json:"shared_matches"`
+	SharedTournaments int    `json:"shared_tournaments"`
+}
+
+// DecoratedPlayer - место игрока по числу наград в турнире; игроки с равным числом наград делят место
+type DecoratedPlayer struct {
+	Rank           int    `json:"rank"`
+	Player         string `json:"player"`
+	Name           string `json:"name,omitempty"`
+	Surname        string `json:"surname,omitempty"`
+	Awards         int    `json:"awards"`          // сколько раз награждался
+	DistinctAwards int    `json:"distinct_awards"` // сколько разных наград получил
+}
+
+// AwardCoOccurrence - пара наград и сколько игроков получили обе
+type AwardCoOccurrence struct {
+	Award           string `json:"award"`
+	AwardTitle      string `json:"award_title,omitempty"`
+	OtherAward      string `json:"other_award"`
+	OtherAwardTitle string `json:"other_award_title,omitempty"`
+	Players         int    `json:"players"`
+}
+
+// PlayerCentrality - степень игрока в сети совместных награждений: со сколькими разными игроками
+// он награждался в одних матчах. Centrality - степень, делённая на число остальных игроков сети
+type PlayerCentrality struct {
+	Player     string  `json:"player"`
+	Name       string  `json:"name,omitempty"`
+	Surname    string  `json:"surname,omitempty"`
+	Degree     int     `json:"degree"`
+	Centrality float64 `json:"centrality"`
+}
@@ -0,0 +1,60 @@
+package entity
+
+// CareerDateLayout - формат дат на связях графа карьеры, сравнивается как строка
+const CareerDateLayout = "2006-01-02"
+
+// TeamMembership - игрок состоит в команде начиная с Since; пустая Team - игрок ушёл из команды (свободный агент)
+type TeamMembership struct {
+	Player  string
+	Name    string
+	Surname string
+	Team    string
+	Since   string // в формате CareerDateLayout
+	Until   string // пустая у текущей команды
+}
+
+// CareerBackfill - итог восстановления графа карьеры по статистике и каталогу
+type CareerBackfill struct {
+	Appearances int // записанных связей игрока с матчем
+	Players     int // игроков, у которых пересобран состав
+}
+
+// Appearance - игрок сыграл матч за команду Team против Opponent
+type Appearance struct {
+	Player   string
+	Match    string
+	Team     string
+	Opponent string
+	Date     string // в формате CareerDateLayout
+}
+
+// Teammate - игрок, который был в одной команде с заданным: по составу в одно время или в одном матче
+type Teammate struct {
+	Player  string   `json:"player"`
+	Name    string   `json:"name,omitempty"`
+	Surname string   `json:"surname,omitempty"`
+	Teams   []string `json:"teams"`   // id общих команд
+	Current bool     `json:"current"` // оба сейчас в составе одной из команд
+}
+
+// Separation - кратчайшая цепочка игроков, где соседи играли в одном матче
+type Separation struct {
+	Degrees int              `json:"degrees"`
+	Path    []SeparationStep `json:"path"`
+}
+
+// SeparationStep - игрок цепочки и матч, в котором он играл со следующим игроком
+type SeparationStep struct {
+	Player  string `json:"player"`
+	Name    string `json:"name,omitempty"`
+	Surname string `json:"surname,omitempty"`
+	Match   string `json:"match,omitempty"`
+}
+
+// CommonOpponent - команда, против которой играли оба игрока, и сколько матчей против неё у каждого
+type CommonOpponent struct {
+	Team       string `json:"team"`
+	TeamName   string `json:"team_name,omitempty"`
+	Games      int    `json:"games"`
+	OtherGames int    `json:"other_games"`
+}
@@ -0,0 +1,51 @@
+package entity
+
+// Виды расхождений между каталогом, статистикой и графом наград
+const (
+	FindingOrphanStats          = "orphan_stats"            // строки статистики ссылаются на сущность, которой нет в каталоге
+	FindingOrphanNodes          = "orphan_nodes"            // узлы графа наград без сущности в каталоге
+	FindingUnmarkedTombstone    = "unmarked_tombstone"      // сущность удалена с сохранением истории, а узел графа не помечен удалённым
+	FindingDeletedNodeInCatalog = "deleted_node_in_catalog" // узел графа помечен удалённым, а сущность есть в каталоге
+	FindingNoStats              = "no_stats"                // справочно: сущность каталога без статистики
+	FindingNoAwardNode          = "no_award_node"           // справочно: сущность каталога без узла в графе наград
+)
+
+// ConsistencyRequest - параметры проверки: Fix - политика исправления (cascade или tombstone), пустая - только отчёт
+type ConsistencyRequest struct {
+	Fix   DeletionPolicy
+	Limit int // сколько id каждой находки выводить в отчёт, 0 - все
+}
+
+// ConsistencyCounts - количество id сущности в каждом хранилище
+type ConsistencyCounts struct {
+	Catalog    int `json:"catalog"`
+	Tombstones int `json:"tombstones"`
+	Stats      int `json:"stats"`
+	Graph      int `json:"graph"`
+}
+
+// ConsistencyFinding - одно расхождение и id, которых оно касается
+type ConsistencyFinding struct {
+	Entity string   `json:"entity"`
+	Kind   string   `json:"kind"`
+	Store  string   `json:"store"` // хранилище, в котором найдены id
+	Count  int      `json:"count"`
+	IDs    []string `json:"ids"`
+	Fixed  int      `json:"fixed,omitempty"`
+	Info   bool     `json:"info,omitempty"` // справочная находка, не нарушение согласованности
+}
+
+type ConsistencyReport struct {
+	Counts   map[string]ConsistencyCounts `json:"counts"` // по сущностям
+	Findings []ConsistencyFinding         `json:"findings"`
+}
+
+// Consistent - нет неисправленных нарушений
+func (r *ConsistencyReport) Consistent() bool {
+	for _, finding := range r.Findings {
+		if !finding.Info && finding.Fixed < finding.Count {
+			return false
+		}
+	}
+	return true
+}
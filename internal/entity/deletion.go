@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// DeletionPolicy - что делать с записями в других хранилищах при удалении сущности каталога
+type DeletionPolicy string
+
+const (
+	DeletionReject    DeletionPolicy = "reject"    // отказать, если на сущность ссылаются статистика, награды или события
+	DeletionCascade   DeletionPolicy = "cascade"   // удалить вместе со статистикой, узлом графа наград и событиями
+	DeletionTombstone DeletionPolicy = "tombstone" // убрать из каталога, сохранив историю; узел графа помечается удалённым
+)
+
+// Удаляемые сущности каталога
+const (
+	DeletionEntityPlayer = "player"
+	DeletionEntityGame   = "game"
+)
+
+const (
+	DeletionJobPending = "pending"
+	DeletionJobDone    = "done"
+	DeletionJobFailed  = "failed" // шаг не выполнился, задача будет повторена
+)
+
+// Шаги задачи удаления, по одному на хранилище
+const (
+	DeletionStepStats   = "player_stats" // строки статистики в ClickHouse
+	DeletionStepAwards  = "awards_graph" // узел в графе наград
+	DeletionStepEvents  = "game_events"  // события матча или события с участием игрока
+	DeletionStepCatalog = "catalog"      // документ каталога, всегда последний шаг
+)
+
+// DeletionStep - шаг задачи; выполненные шаги при повторе пропускаются
+type DeletionStep struct {
+	Store string `json:"store" example:"player_stats"`
+	Done  bool   `json:"done"`
+	Error string `json:"error,omitempty"` // ошибка последней попытки
+}
+
+// DeletionJob - удаление сущности из всех хранилищ. Документ каталога удаляется последним,
+// поэтому при частичном сбое сущность остаётся видна, а повтор продолжает с невыполненного шага
+type DeletionJob struct {
+	ID        string         `json:"id" bson:"-"`
+	Entity    string         `json:"entity" bson:"entity" example:"player"`
+	EntityID  string         `json:"entity_id" bson:"entity_id"`
+	Policy    DeletionPolicy `json:"policy" bson:"policy" example:"cascade"`
+	Status    string         `json:"status" bson:"status" example:"done"`
+	Steps     []DeletionStep `json:"steps" bson:"steps"`
+	Attempts  int            `json:"attempts" bson:"attempts"`
+	CreatedAt time.Time      `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at" bson:"updated_at"`
+	// LockedUntil - до этого момента задачу выполняет захватившая её копия приложения
+	LockedUntil time.Time `json:"-" bson:"locked_until"`
+}
@@ -1,9 +1,75 @@
 package entity
 
+import "time"
+
+// GameDateLayout - формат даты матча, например 12.03.24
+const GameDateLayout = "02.01.06"
+
+const (
+	GameStatusScheduled = "scheduled"
+	GameStatusLive      = "live"
+	GameStatusFinal     = "final"
+	GameStatusPostponed = "postponed"
+)
+
+// RegulationPeriods - количество четвертей в основное время, периоды после них - овертаймы
+const RegulationPeriods = 4
+
 type Game struct {
-	FirstTeam  string `json:"first_team,omitempty" default:"LA Lakers"`
-	SecondTeam string `json:"second_team,omitempty" default:"Chicago Bulls"`
-	Date       string `json:"date,omitempty" default:"12.03.24"`
-	Type       string `json:"type,omitempty" default:"final"`
-	League     string `json:"league,omitempty" default:"NBA"`
+	FirstTeam       string        `json:"first_team,omitempty"`  // id команды хозяев
+	SecondTeam      string        `json:"second_team,omitempty"` // id команды гостей
+	Date            string        `json:"date,omitempty" default:"12.03.24"`
+	Type            string        `json:"type,omitempty" default:"final"`
+	League          string        `json:"league,omitempty"` // id лиги
+	Status          string        `json:"status,omitempty" default:"scheduled"`
+	FirstTeamScore  int           `json:"first_team_score,omitempty"`
+	SecondTeamScore int           `json:"second_team_score,omitempty"`
+	Periods         []PeriodScore `json:"periods,omitempty"`
+	Winner          string        `json:"winner,omitempty"` // id команды-победителя, только для final
+	Round           int           `json:"round,omitempty"`  // раунд плей-офф
+	Series          string        `json:"series,omitempty"` // id серии плей-офф
+}
+
+// PeriodScore - очки команд за период: 1-4 - четверти, 5 и далее - овертаймы
+type PeriodScore struct {
+	Period     int `json:"period" example:"1"`
+	FirstTeam  int `json:"first_team" example:"28"`
+	SecondTeam int `json:"second_team" example:"24"`
+}
+
+// GameResult - результат матча для записи и исправления через /game/{id}/result
+type GameResult struct {
+	Status          string        `json:"status" example:"final"`
+	FirstTeamScore  int           `json:"first_team_score,omitempty" example:"102"`
+	SecondTeamScore int           `json:"second_team_score,omitempty" example:"98"`
+	Periods         []PeriodScore `json:"periods,omitempty"`
+}
+
+// Result - текущий результат матча
+func (g *Game) Result() GameResult {
+	return GameResult{
+		Status:          g.Status,
+		FirstTeamScore:  g.FirstTeamScore,
+		SecondTeamScore: g.SecondTeamScore,
+		Periods:         g.Periods,
+	}
+}
+
+// Opponent - соперник команды в матче; пустая строка, если команда в матче не играла
+func (g *Game) Opponent(teamID string) string {
+	switch teamID {
+	case "":
+		return ""
+	case g.FirstTeam:
+		return g.SecondTeam
+	case g.SecondTeam:
+		return g.FirstTeam
+	default:
+		return ""
+	}
+}
+
+// ParseDate - дата матча в формате GameDateLayout
+func (g *Game) ParseDate() (time.Time, error) {
+	return time.Parse(GameDateLayout, g.Date)
 }
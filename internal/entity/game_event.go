@@ -0,0 +1,38 @@
+package entity
+
+const (
+	EventShotMade     = "shot_made"
+	EventShotMissed   = "shot_missed"
+	EventRebound      = "rebound"
+	EventAssist       = "assist"
+	EventSteal        = "steal"
+	EventBlock        = "block"
+	EventTurnover     = "turnover"
+	EventFoul         = "foul"
+	EventSubstitution = "substitution"
+)
+
+const (
+	ShotTwoPoint   = "two_point"
+	ShotThreePoint = "three_point"
+	ShotFreeThrow  = "free_throw"
+)
+
+const (
+	ReboundOffensive = "offensive"
+	ReboundDefensive = "defensive"
+)
+
+// GameEvent - событие play-by-play; последовательность событий матча определяет его box score
+type GameEvent struct {
+	Game        string `json:"game,omitempty"`                          // id матча
+	Sequence    int    `json:"sequence,omitempty"`                      // порядковый номер в матче, проставляется при записи
+	Period      int    `json:"period" example:"1"`                      // 5 и дальше - овертаймы
+	Clock       string `json:"clock" example:"11:42"`                   // оставшееся время периода, mm:ss
+	Type        string `json:"type" example:"shot_made"`                // shot_made, shot_missed, rebound, assist, steal, block, turnover, foul, substitution
+	Team        string `json:"team"`                                    // id команды игрока
+	Player      string `json:"player"`                                  // id игрока; для замены - выходящий на площадку
+	ShotType    string `json:"shot_type,omitempty" example:"two_point"` // two_point, three_point, free_throw
+	ReboundType string `json:"rebound_type,omitempty" example:"defensive"`
+	Replaced    string `json:"replaced,omitempty"` // id игрока, уходящего с площадки при замене
+}
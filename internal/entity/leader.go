@@ -0,0 +1,34 @@
+package entity
+
+const (
+	LeaderPerGame  = "game"  // среднее за матч
+	LeaderTotal    = "total" // сумма
+	LeaderPer36Min = "36"    // на 36 минут
+)
+
+// LeaderStats - показатели, по которым строятся рейтинги, в тех же названиях, что и поля PlayerStat
+var LeaderStats = []string{
+	"points", "rebounds", "assists", "steals", "blocks", "turnovers", "fgm", "fga", "threePm", "threePa",
+	"ftm", "fta", "offensiveRebounds", "defensiveRebounds", "personalFouls", "plusMinus", "minutes",
+}
+
+// LeadersRequest - фильтры рейтинга; пустые season, league и team не ограничивают выборку
+type LeadersRequest struct {
+	Stat     string `json:"stat" example:"points"`
+	Per      string `json:"per" example:"game"` // game, total или 36
+	Season   string `json:"season,omitempty" example:"2023/2024"`
+	League   string `json:"league,omitempty"`
+	Team     string `json:"team,omitempty"`
+	MinGames int    `json:"min_games,omitempty" example:"10"`
+	Limit    int    `json:"limit,omitempty" example:"10"`
+}
+
+// Leader - место игрока в рейтинге; игроки с равным значением делят место, следующее место пропускается
+type Leader struct {
+	Rank     int     `json:"rank"`
+	PlayerID string  `json:"playerId"`
+	Name     string  `json:"name,omitempty"`
+	Surname  string  `json:"surname,omitempty"`
+	Games    int     `json:"games"`
+	Value    float64 `json:"value"`
+}
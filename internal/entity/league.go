@@ -1,6 +1,16 @@
 package entity
 
+const (
+	TieBreakerHeadToHead        = "head_to_head"
+	TieBreakerPointDifferential = "point_differential"
+	TieBreakerConferenceRecord  = "conference_record"
+)
+
+// DefaultTieBreakers - порядок тай-брейков, если в лиге он не задан
+var DefaultTieBreakers = []string{TieBreakerHeadToHead, TieBreakerPointDifferential}
+
 type League struct {
-	Name   string `json:"name,omitempty" default:"NBA"`
-	Season string `json:"season,omitempty" default:"2023/2024"`
+	Name        string   `json:"name,omitempty" default:"NBA"`
+	Season      string   `json:"season,omitempty" default:"2023/2024"`
+	TieBreakers []string `json:"tie_breakers,omitempty" example:"head_to_head,point_differential"`
 }
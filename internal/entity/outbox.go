@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// Сущности каталога, изменения которых публикуются в outbox
+const (
+	OutboxEntityPlayer = "player"
+	OutboxEntityGame   = "game"
+	OutboxEntityLeague = "league"
+	OutboxEntityAward  = "award"
+)
+
+const (
+	OutboxEventUpsert = "upsert" // создание или изменение, событие несёт документ целиком
+	OutboxEventDelete = "delete" // удаление или удаление с сохранением истории
+)
+
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusProcessed = "processed"
+	OutboxStatusParked    = "parked" // не применилось за MaxAttempts попыток, relay идёт дальше
+)
+
+// OutboxEvent - доменное событие каталога, записанное в одной транзакции с изменением документа.
+// Version растёт с каждым изменением сущности: проекции применяют событие, только если его версия новее,
+// поэтому повторная доставка и доставка устаревшего события ничего не меняют
+type OutboxEvent struct {
+	ID         string    `json:"id" bson:"-"`
+	Entity     string    `json:"entity" bson:"entity"`
+	EntityID   string    `json:"entity_id" bson:"entity_id"`
+	Type       string    `json:"type" bson:"type"`
+	Version    int64     `json:"version" bson:"version"`
+	OccurredAt time.Time `json:"occurred_at" bson:"occurred_at"`
+
+	// документ сущности после изменения, заполнен только для upsert
+	Player *Player `json:"player,omitempty" bson:"player,omitempty"`
+	Game   *Game   `json:"game,omitempty" bson:"game,omitempty"`
+	League *League `json:"league,omitempty" bson:"league,omitempty"`
+	Award  *Award  `json:"award,omitempty" bson:"award,omitempty"`
+
+	Status      string     `json:"status" bson:"status"`
+	Attempts    int        `json:"attempts" bson:"attempts"`
+	LastError   string     `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty" bson:"processed_at,omitempty"` // по нему удаляются обработанные события
+}
@@ -7,7 +7,7 @@ type Player struct {
 	Age         int    `json:"age,omitempty" default:"34"`
 	Height      int    `json:"height,omitempty" default:"201"`
 	Weight      int    `json:"weight,omitempty" default:"104"`
-	Team        string `json:"team,omitempty" default:"Miami Heat"`
+	Team        string `json:"team,omitempty"` // id команды
 	Role        string `json:"role,omitempty" default:"heavy forward"`
 	Citizenship string `json:"citizenship,omitempty" default:"USA"`
 }
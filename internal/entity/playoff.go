@@ -0,0 +1,51 @@
+package entity
+
+const GameTypePlayoff = "playoff"
+
+// PlayoffRequest - параметры посева и сетки плей-офф
+type PlayoffRequest struct {
+	Teams            int    `json:"teams" example:"8"` // степень двойки: 2, 4, 8, 16
+	BestOf           int    `json:"best_of" example:"7"`
+	StartDate        string `json:"start_date" example:"20.04.24"`
+	DaysBetweenGames int    `json:"days_between_games,omitempty" example:"2"`
+}
+
+// PlayoffSeed - посеянная команда
+type PlayoffSeed struct {
+	Seed     int    `json:"seed"`
+	Team     string `json:"team"` // id команды
+	TeamName string `json:"team_name,omitempty"`
+}
+
+// PlayoffSeries - серия до BestOf/2+1 побед; Top и Bottom пустые, пока не сыграны серии предыдущего раунда
+type PlayoffSeries struct {
+	ID         string       `json:"id" example:"R1S1"`
+	Round      int          `json:"round"`
+	Top        *PlayoffSeed `json:"top,omitempty"`
+	Bottom     *PlayoffSeed `json:"bottom,omitempty"`
+	TopWins    int          `json:"top_wins"`
+	BottomWins int          `json:"bottom_wins"`
+	Games      []string     `json:"games,omitempty"` // id матчей серии по порядку
+	Winner     string       `json:"winner,omitempty"`
+}
+
+type PlayoffRound struct {
+	Round  int             `json:"round"`
+	Series []PlayoffSeries `json:"series"`
+}
+
+// PlayoffBracket - сетка плей-офф лиги: победитель серии i раунда r попадает в серию i/2 раунда r+1
+type PlayoffBracket struct {
+	League           string         `json:"league"`
+	BestOf           int            `json:"best_of"`
+	StartDate        string         `json:"start_date"`
+	DaysBetweenGames int            `json:"days_between_games"`
+	Seeds            []PlayoffSeed  `json:"seeds"`
+	Rounds           []PlayoffRound `json:"rounds"`
+	Champion         string         `json:"champion,omitempty"`
+}
+
+// WinsNeeded - побед для выигрыша серии
+func (b *PlayoffBracket) WinsNeeded() int {
+	return b.BestOf/2 + 1
+}
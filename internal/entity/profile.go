@@ -0,0 +1,19 @@
+package entity
+
+// CareerStat - суммы и средние игрока за все сезоны
+type CareerStat struct {
+	Games   int            `json:"games"`
+	Totals  SeasonTotals   `json:"totals"`
+	PerGame SeasonAverages `json:"perGame"`
+}
+
+// PlayerProfile - игрок из каталога, его статистика и награды.
+// Если какое-то хранилище не ответило, его часть пустая, а причина есть в Warnings
+type PlayerProfile struct {
+	PlayerID string             `json:"playerId"`
+	Player   *Player            `json:"player,omitempty"`
+	Career   *CareerStat        `json:"career,omitempty"`
+	Seasons  []PlayerSeasonStat `json:"seasons,omitempty"`
+	Awards   []RewardStat       `json:"awards,omitempty"`
+	Warnings []string           `json:"warnings,omitempty"`
+}
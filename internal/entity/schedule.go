@@ -0,0 +1,29 @@
+package entity
+
+const (
+	GameTypeRegular = "regular"
+)
+
+// ScheduleRequest - параметры генерации регулярного сезона лиги
+type ScheduleRequest struct {
+	Teams           []string `json:"teams,omitempty"` // id команд, по умолчанию все команды лиги
+	StartDate       string   `json:"start_date" example:"24.10.23"`
+	EndDate         string   `json:"end_date" example:"14.04.24"`
+	Rounds          int      `json:"rounds,omitempty" example:"2"` // сколько раз каждая пара встречается, по умолчанию 2 (дома и в гостях)
+	MaxGamesPerWeek int      `json:"max_games_per_week,omitempty" example:"3"`
+	BlackoutDates   []string `json:"blackout_dates,omitempty" example:"24.12.23,25.12.23"`
+	DryRun          bool     `json:"dry_run,omitempty"` // только построить расписание, не сохраняя матчи
+}
+
+// ScheduledGame - матч сгенерированного расписания, GameID пустой при dry_run
+type ScheduledGame struct {
+	GameID string `json:"game_id,omitempty"`
+	Game   Game   `json:"game"`
+}
+
+// Schedule - сгенерированное расписание и баланс домашних матчей команд
+type Schedule struct {
+	Games     []ScheduledGame `json:"games"`
+	HomeGames map[string]int  `json:"home_games"`
+	AwayGames map[string]int  `json:"away_games"`
+}
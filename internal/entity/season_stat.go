@@ -0,0 +1,113 @@
+package entity
+
+// SeasonTotals - суммы box score за сезон
+type SeasonTotals struct {
+	Minutes           float64 `json:"minutes"`
+	Points            int     `json:"points"`
+	FGM               int     `json:"fgm"`
+	FGA               int     `json:"fga"`
+	ThreePM           int     `json:"threePm"`
+	ThreePA           int     `json:"threePa"`
+	FTM               int     `json:"ftm"`
+	FTA               int     `json:"fta"`
+	OffensiveRebounds int     `json:"offensiveRebounds"`
+	DefensiveRebounds int     `json:"defensiveRebounds"`
+	Rebounds          int     `json:"rebounds"`
+	Assists           int     `json:"assists"`
+	Steals            int     `json:"steals"`
+	Blocks            int     `json:"blocks"`
+	Turnovers         int     `json:"turnovers"`
+	PersonalFouls     int     `json:"personalFouls"`
+	PlusMinus         int     `json:"plusMinus"`
+}
+
+// SeasonAverages - средние за матч
+type SeasonAverages struct {
+	Minutes           float64 `json:"minutes"`
+	Points            float64 `json:"points"`
+	FGM               float64 `json:"fgm"`
+	FGA               float64 `json:"fga"`
+	ThreePM           float64 `json:"threePm"`
+	ThreePA           float64 `json:"threePa"`
+	FTM               float64 `json:"ftm"`
+	FTA               float64 `json:"fta"`
+	OffensiveRebounds float64 `json:"offensiveRebounds"`
+	DefensiveRebounds float64 `json:"defensiveRebounds"`
+	Rebounds          float64 `json:"rebounds"`
+	Assists           float64 `json:"assists"`
+	Steals            float64 `json:"steals"`
+	Blocks            float64 `json:"blocks"`
+	Turnovers         float64 `json:"turnovers"`
+	PersonalFouls     float64 `json:"personalFouls"`
+	PlusMinus         float64 `json:"plusMinus"`
+}
+
+// Add - суммы двух периодов
+func (t SeasonTotals) Add(other SeasonTotals) SeasonTotals {
+	t.Minutes += other.Minutes
+	t.Points += other.Points
+	t.FGM += other.FGM
+	t.FGA += other.FGA
+	t.ThreePM += other.ThreePM
+	t.ThreePA += other.ThreePA
+	t.FTM += other.FTM
+	t.FTA += other.FTA
+	t.OffensiveRebounds += other.OffensiveRebounds
+	t.DefensiveRebounds += other.DefensiveRebounds
+	t.Rebounds += other.Rebounds
+	t.Assists += other.Assists
+	t.Steals += other.Steals
+	t.Blocks += other.Blocks
+	t.Turnovers += other.Turnovers
+	t.PersonalFouls += other.PersonalFouls
+	t.PlusMinus += other.PlusMinus
+	return t
+}
+
+// PerGame - средние за матч по суммам за games матчей
+func (t SeasonTotals) PerGame(games int) SeasonAverages {
+	if games == 0 {
+		return SeasonAverages{}
+	}
+	n := float64(games)
+	return SeasonAverages{
+		Minutes:           t.Minutes / n,
+		Points:            float64(t.Points) / n,
+		FGM:               float64(t.FGM) / n,
+		FGA:               float64(t.FGA) / n,
+		ThreePM:           float64(t.ThreePM) / n,
+		ThreePA:           float64(t.ThreePA) / n,
+		FTM:               float64(t.FTM) / n,
+		FTA:               float64(t.FTA) / n,
+		OffensiveRebounds: float64(t.OffensiveRebounds) / n,
+		DefensiveRebounds: float64(t.DefensiveRebounds) / n,
+		Rebounds:          float64(t.Rebounds) / n,
+		Assists:           float64(t.Assists) / n,
+		Steals:            float64(t.Steals) / n,
+		Blocks:            float64(t.Blocks) / n,
+		Turnovers:         float64(t.Turnovers) / n,
+		PersonalFouls:     float64(t.PersonalFouls) / n,
+		PlusMinus:         float64(t.PlusMinus) / n,
+	}
+}
+
+// PlayerSeasonStat - сезон игрока в лиге; Teams - команды, за которые он играл в этом сезоне
+type PlayerSeasonStat struct {
+	PlayerID string         `json:"playerId"`
+	Season   string         `json:"season"`
+	League   string         `json:"league,omitempty"`
+	Teams    []string       `json:"teams,omitempty"`
+	Games    int            `json:"games"`
+	Totals   SeasonTotals   `json:"totals"`
+	PerGame  SeasonAverages `json:"perGame"`
+}
+
+// TeamSeasonStat - суммарная статистика игроков команды за сезон
+type TeamSeasonStat struct {
+	TeamID  string         `json:"teamId"`
+	Season  string         `json:"season"`
+	League  string         `json:"league,omitempty"`
+	Games   int            `json:"games"`
+	Totals  SeasonTotals   `json:"totals"`
+	PerGame SeasonAverages `json:"perGame"`
+}
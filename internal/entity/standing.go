@@ -0,0 +1,27 @@
+package entity
+
+// Record - баланс побед и поражений
+type Record struct {
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+}
+
+// Standing - строка турнирной таблицы лиги
+type Standing struct {
+	Rank             int     `json:"rank"`
+	Team             string  `json:"team"` // id команды
+	TeamName         string  `json:"team_name"`
+	Conference       string  `json:"conference,omitempty"`
+	Wins             int     `json:"wins"`
+	Losses           int     `json:"losses"`
+	WinPct           float64 `json:"win_pct"`
+	GamesBehind      float64 `json:"games_behind"`
+	Home             Record  `json:"home"`
+	Away             Record  `json:"away"`
+	ConferenceRecord Record  `json:"conference_record"`
+	LastTen          Record  `json:"last_ten"`
+	Streak           string  `json:"streak,omitempty" example:"W3"`
+	PointsFor        int     `json:"points_for"`
+	PointsAgainst    int     `json:"points_against"`
+	PointDiff        int     `json:"point_diff"`
+}
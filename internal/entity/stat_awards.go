@@ -5,4 +5,22 @@ type RewardStat struct {
 	Tournament string
 	Match      string
 	Reward     string
-}
\ No newline at end of file
+
+	// Свойства узлов графа из каталога: записываются при создании записи, обновляются relay outbox
+	// и возвращаются при чтении, чтобы страницы наград обходились без запросов к каталогу
+	PlayerName       string `json:",omitempty"`
+	PlayerSurname    string `json:",omitempty"`
+	PlayerTeam       string `json:",omitempty"` // id команды
+	MatchFirstTeam   string `json:",omitempty"` // id команды хозяев
+	MatchSecondTeam  string `json:",omitempty"` // id команды гостей
+	MatchDate        string `json:",omitempty"`
+	RewardTitle      string `json:",omitempty"`
+	TournamentName   string `json:",omitempty"` // название лиги
+	TournamentSeason string `json:",omitempty"`
+
+	// Названия команд в граф не пишутся: команды не публикуются в outbox, и названия устаревали бы.
+	// Они берутся из каталога при чтении
+	PlayerTeamName      string `json:",omitempty"`
+	MatchFirstTeamName  string `json:",omitempty"`
+	MatchSecondTeamName string `json:",omitempty"`
+}
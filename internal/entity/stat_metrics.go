@@ -0,0 +1,38 @@
+package entity
+
+// StatScope - выборка матчей для статистики: матч, интервал дат и сезон; пустые поля не ограничивают выборку
+type StatScope struct {
+	MatchID string `json:"matchId,omitempty"`
+	From    string `json:"from,omitempty" example:"01.10.23"` // включительно, в формате GameDateLayout
+	To      string `json:"to,omitempty" example:"30.06.24"`   // включительно, в формате GameDateLayout
+	Season  string `json:"season,omitempty" example:"2023/2024"`
+}
+
+// StatLine - основные показатели, приведённые к 36 минутам или 100 владениям
+type StatLine struct {
+	Points    float64 `json:"points"`
+	Rebounds  float64 `json:"rebounds"`
+	Assists   float64 `json:"assists"`
+	Steals    float64 `json:"steals"`
+	Blocks    float64 `json:"blocks"`
+	Turnovers float64 `json:"turnovers"`
+}
+
+// PlayerMetrics - продвинутые метрики игрока по выборке матчей.
+// Usage и per-100 считаются по суммам его команды в тех же матчах, PER - по Холлинджеру без поправки на темп,
+// нормированный так, что среднее по выборке равно 15. Строки с неизвестными попытками бросков (FGA 0 при FGM > 0)
+// не участвуют в TS% и eFG%
+type PlayerMetrics struct {
+	PlayerID       string    `json:"playerId"`
+	Scope          StatScope `json:"scope"`
+	Games          int       `json:"games"`
+	Minutes        float64   `json:"minutes"`
+	Points         int       `json:"points"`
+	TrueShooting   float64   `json:"trueShooting"`   // TS%, доля
+	EffectiveFG    float64   `json:"effectiveFg"`    // eFG%, доля
+	Usage          float64   `json:"usage"`          // USG%, доля владений команды на площадке
+	PER            float64   `json:"per"`            // player efficiency rating
+	AssistTurnover float64   `json:"assistTurnover"` // AST/TO
+	Per36          StatLine  `json:"per36"`
+	Per100         StatLine  `json:"per100"`
+}
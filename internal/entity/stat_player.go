@@ -1,12 +1,47 @@
 package entity
 
+// PlayerStat - box score игрока за матч.
+// Goals и Interceptions - прежние названия для FGM и Steals, сохраняются для старых клиентов
 type PlayerStat struct {
-	PlayerID      string  `json:"playerId,omitempty"`
-	MatchID       string  `json:"matchId,omitempty"`
-	Goals         int     `json:"goals,omitempty"`
-	Assists       int     `json:"assists,omitempty"`
-	Interceptions int     `json:"interceptions,omitempty"`
-	Rebounds      int     `json:"rebounds,omitempty"`
-	AVGGoals      float64 `json:"avgGoals,omitempty"`
-	TotalAVGStats float64 `json:"totalAvgStats,omitempty"`
+	PlayerID          string  `json:"playerId,omitempty"`
+	MatchID           string  `json:"matchId,omitempty"`
+	TeamID            string  `json:"teamId,omitempty"`   // по умолчанию текущая команда игрока
+	GameDate          string  `json:"gameDate,omitempty"` // по умолчанию дата матча, в формате GameDateLayout
+	Season            string  `json:"season,omitempty"`   // по умолчанию сезон лиги матча
+	LeagueID          string  `json:"leagueId,omitempty"` // по умолчанию лига матча
+	Points            int     `json:"points,omitempty"`   // по умолчанию считается по броскам
+	FGM               int     `json:"fgm,omitempty"`      // забитые броски с игры, включая трёхочковые
+	FGA               int     `json:"fga,omitempty"`      // 0 при FGM > 0 - попытки неизвестны
+	ThreePM           int     `json:"threePm,omitempty"`
+	ThreePA           int     `json:"threePa,omitempty"`
+	FTM               int     `json:"ftm,omitempty"`
+	FTA               int     `json:"fta,omitempty"`
+	OffensiveRebounds int     `json:"offensiveRebounds,omitempty"`
+	DefensiveRebounds int     `json:"defensiveRebounds,omitempty"`
+	Rebounds          int     `json:"rebounds,omitempty"` // всего подборов
+	Assists           int     `json:"assists,omitempty"`
+	Steals            int     `json:"steals,omitempty"`
+	Blocks            int     `json:"blocks,omitempty"`
+	Turnovers         int     `json:"turnovers,omitempty"`
+	PersonalFouls     int     `json:"personalFouls,omitempty"`
+	Minutes           float64 `json:"minutes,omitempty"`
+	PlusMinus         int     `json:"plusMinus,omitempty"`
+	Goals             int     `json:"goals,omitempty"`         // устарело, то же что FGM
+	Interceptions     int     `json:"interceptions,omitempty"` // устарело, то же что Steals
+	AVGGoals          float64 `json:"avgGoals,omitempty"`
+	TotalAVGStats     float64 `json:"totalAvgStats,omitempty"`
+}
+
+// StatBatchResult - итог пакетной вставки статистики; строки нумеруются с нуля в порядке тела запроса
+type StatBatchResult struct {
+	Received int              `json:"received"`
+	Inserted int              `json:"inserted"`
+	Failed   int              `json:"failed"`
+	Errors   []StatBatchError `json:"errors,omitempty"`
+}
+
+// StatBatchError - почему не вставлена строка пачки
+type StatBatchError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
 }
@@ -0,0 +1,10 @@
+package entity
+
+type Team struct {
+	ID           string `json:"id,omitempty" bson:"-"` // заполняется при чтении из хранилища
+	Name         string `json:"name,omitempty" default:"Miami Heat"`
+	City         string `json:"city,omitempty" default:"Miami"`
+	Abbreviation string `json:"abbreviation,omitempty" default:"MIA"`
+	Conference   string `json:"conference,omitempty" default:"East"`
+	League       string `json:"league,omitempty"`
+}
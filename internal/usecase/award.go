@@ -2,17 +2,22 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
+	"github.com/romeros69/basket/internal/apperrors"
 	"github.com/romeros69/basket/internal/entity"
 )
 
 type AwardUC struct {
-	awardRp AwardRp
+	awardRp      AwardRp
+	statAwardsRp StatAwardsRp
 }
 
-func NewAwardUC(awardRp AwardRp) *AwardUC {
+func NewAwardUC(awardRp AwardRp, statAwardsRp StatAwardsRp) *AwardUC {
 	return &AwardUC{
-		awardRp: awardRp,
+		awardRp:      awardRp,
+		statAwardsRp: statAwardsRp,
 	}
 }
 
@@ -30,10 +35,95 @@ func (a *AwardUC) GetAward(ctx context.Context, awardID string) (*entity.Award,
 	return a.awardRp.GetAward(ctx, awardID)
 }
 
+// DeleteAward - награду, которая уже вручалась, удалить нельзя: записи графа ссылаются на неё по id.
+// Проверка и отметка удаления делаются одной операцией в графе наград (RetireReward), поэтому вручение,
+// пришедшее одновременно с удалением, либо не даст удалить награду, либо само завершится ErrAwardNotFound
 func (a *AwardUC) DeleteAward(ctx context.Context, awardID string) error {
-	return a.awardRp.DeleteAward(ctx, awardID)
+	if _, err := a.awardRp.GetAward(ctx, awardID); err != nil {
+		return err
+	}
+
+	granted, err := a.statAwardsRp.RetireReward(ctx, awardID)
+	if err != nil {
+		return err
+	}
+	if granted {
+		return fmt.Errorf("%w: award %s was granted", apperrors.ErrEntityReferenced, awardID)
+	}
+
+	if err = a.awardRp.DeleteAward(ctx, awardID); err != nil {
+		if restoreErr := a.statAwardsRp.RestoreReward(ctx, awardID); restoreErr != nil {
+			return fmt.Errorf("%w (restore award %s in graph: %v)", err, awardID, restoreErr)
+		}
+		return err
+	}
+
+	return nil
 }
 
 func (a *AwardUC) GetAwardList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Award, error) {
 	return a.awardRp.GetAwardList(ctx, pageSize, pageNumber)
 }
+
+// GetAwardGrants - страница наград каталога с числом вручений и получателями из графа наград.
+// Награды, которые ни разу не вручались, тоже попадают в список
+func (a *AwardUC) GetAwardGrants(ctx context.Context, pageSize, pageNumber int64) ([]*entity.AwardGrants, error) {
+	awards, err := a.awardRp.GetAwardList(ctx, pageSize, pageNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(awards))
+	for _, award := range awards {
+		ids = append(ids, award.ID)
+	}
+	records, err := a.statAwardsRp.ViewWhoGotRewards(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byAward := make(map[string][]entity.RewardStat)
+	for _, record := range records {
+		byAward[record.Reward] = append(byAward[record.Reward], record)
+	}
+
+	grants := make([]*entity.AwardGrants, 0, len(awards))
+	for _, award := range awards {
+		grants = append(grants, &entity.AwardGrants{
+			Award:      award,
+			Grants:     len(byAward[award.ID]),
+			Recipients: awardRecipients(byAward[award.ID]),
+		})
+	}
+
+	return grants, nil
+}
+
+// awardRecipients - получатели награды по убыванию числа вручений, при равенстве - по id игрока
+func awardRecipients(records []entity.RewardStat) []entity.AwardRecipient {
+	byPlayer := make(map[string]*entity.AwardRecipient)
+	recipients := make([]entity.AwardRecipient, 0)
+	for _, record := range records {
+		recipient, ok := byPlayer[record.Player]
+		if !ok {
+			recipient = &entity.AwardRecipient{
+				Player:  record.Player,
+				Name:    record.PlayerName,
+				Surname: record.PlayerSurname,
+			}
+			byPlayer[record.Player] = recipient
+		}
+		recipient.Grants++
+	}
+	for _, recipient := range byPlayer {
+		recipients = append(recipients, *recipient)
+	}
+
+	sort.Slice(recipients, func(i, j int) bool {
+		if recipients[i].Grants != recipients[j].Grants {
+			return recipients[i].Grants > recipients[j].Grants
+		}
+		return recipients[i].Player < recipients[j].Player
+	})
+
+	return recipients
+}
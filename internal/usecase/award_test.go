@@ -0,0 +1,51 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/internal/usecase/repo/memory_rp"
+)
+
+func TestDeleteAwardRetiresRewardNode(t *testing.T) {
+	ctx := context.Background()
+	awards, graph := memory_rp.NewAwardRepo(), memory_rp.NewStatAwardsRepo()
+	uc := usecase.NewAwardUC(awards, graph)
+
+	granted, err := awards.CreateAward(ctx, &entity.Award{Tittle: "MVP"})
+	if err != nil {
+		t.Fatalf("CreateAward() error = %v", err)
+	}
+	record := entity.RewardStat{Player: "p1", Match: "m1", Tournament: "t1", Reward: granted}
+	if err = graph.CreateRecord(ctx, record); err != nil {
+		t.Fatalf("CreateRecord() error = %v", err)
+	}
+	if err = uc.DeleteAward(ctx, granted); !errors.Is(err, apperrors.ErrEntityReferenced) {
+		t.Fatalf("DeleteAward() of a granted award error = %v, want %v", err, apperrors.ErrEntityReferenced)
+	}
+	// неудачное удаление не мешает вручать награду дальше
+	record.Player = "p2"
+	if err = graph.CreateRecord(ctx, record); err != nil {
+		t.Fatalf("CreateRecord() after a refused delete error = %v", err)
+	}
+
+	unused, err := awards.CreateAward(ctx, &entity.Award{Tittle: "Rookie"})
+	if err != nil {
+		t.Fatalf("CreateAward() error = %v", err)
+	}
+	if err = uc.DeleteAward(ctx, unused); err != nil {
+		t.Fatalf("DeleteAward() error = %v", err)
+	}
+	// вручение, которое проверило каталог до удаления, не создаёт узел удалённой награды
+	record.Reward = unused
+	if err = graph.CreateRecord(ctx, record); !errors.Is(err, apperrors.ErrAwardNotFound) {
+		t.Fatalf("CreateRecord() of a deleted award error = %v, want %v", err, apperrors.ErrAwardNotFound)
+	}
+	if records, _ := graph.ViewWhoGotSpecificReward(ctx, unused); len(records) != 0 {
+		t.Errorf("deleted award has %d records", len(records))
+	}
+}
@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// boxScore - сводит события матча в статистику игроков.
+// В box score попадает каждый игрок, упомянутый в событиях, даже с нулевой статистикой.
+// Минуты и плюс-минус считаются по отрезкам на площадке, см. courtStints
+func boxScore(gameID string, events []*entity.GameEvent) []entity.PlayerStat {
+	stats := make(map[string]*entity.PlayerStat)
+	line := func(playerID, teamID string) *entity.PlayerStat {
+		stat, ok := stats[playerID]
+		if !ok {
+			stat = &entity.PlayerStat{PlayerID: playerID, MatchID: gameID, TeamID: teamID}
+			stats[playerID] = stat
+		}
+		return stat
+	}
+
+	for _, event := range events {
+		stat := line(event.Player, event.Team)
+		switch event.Type {
+		case entity.EventShotMade, entity.EventShotMissed:
+			made := event.Type == entity.EventShotMade
+			switch event.ShotType {
+			case entity.ShotFreeThrow:
+				stat.FTA++
+				if made {
+					stat.FTM++
+				}
+			case entity.ShotThreePoint:
+				stat.ThreePA++
+				stat.FGA++
+				if made {
+					stat.ThreePM++
+					stat.FGM++
+				}
+			default:
+				stat.FGA++
+				if made {
+					stat.FGM++
+				}
+			}
+		case entity.EventRebound:
+			if event.ReboundType == entity.ReboundOffensive {
+				stat.OffensiveRebounds++
+			} else {
+				stat.DefensiveRebounds++
+			}
+		case entity.EventAssist:
+			stat.Assists++
+		case entity.EventSteal:
+			stat.Steals++
+		case entity.EventBlock:
+			stat.Blocks++
+		case entity.EventTurnover:
+			stat.Turnovers++
+		case entity.EventFoul:
+			stat.PersonalFouls++
+		case entity.EventSubstitution:
+			line(event.Replaced, event.Team)
+		}
+	}
+
+	stints := courtStints(events)
+	for playerID, playerStints := range stints {
+		stat := stats[playerID]
+		var seconds int
+		for _, stint := range playerStints {
+			seconds += stint.toSecond - stint.fromSecond
+		}
+		stat.Minutes = math.Round(float64(seconds)/60*10) / 10
+	}
+	for i, event := range events {
+		if event.Type != entity.EventShotMade {
+			continue
+		}
+		points := shotPoints(event.ShotType)
+		for playerID, playerStints := range stints {
+			for _, stint := range playerStints {
+				if stint.from > i || i >= stint.to {
+					continue
+				}
+				if stint.team == event.Team {
+					stats[playerID].PlusMinus += points
+				} else {
+					stats[playerID].PlusMinus -= points
+				}
+			}
+		}
+	}
+
+	result := make([]entity.PlayerStat, 0, len(stats))
+	for _, stat := range stats {
+		normalized, _ := normalizePlayerStat(*stat) // счётчики из событий всегда согласованы
+		result = append(result, normalized)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PlayerID < result[j].PlayerID
+	})
+
+	return result
+}
+
+const (
+	periodSeconds   = 12 * 60 // длина четверти
+	overtimeSeconds = 5 * 60  // длина овертайма, периоды с пятого
+)
+
+// courtStint - отрезок, который игрок провёл на площадке: события с номерами [from, to) и секунды матча
+type courtStint struct {
+	team                 string
+	from, to             int
+	fromSecond, toSecond int
+}
+
+// courtStints - отрезки на площадке по игрокам, восстановленные по заменам.
+// Игрок, который действует в событии, не выйдя на площадку заменой, считается вышедшим в начале периода
+// или в момент, когда его заменили, если это было позже; так определяются стартовые пятёрки.
+// Отрезки не прерываются между периодами, поэтому замены в перерывах тоже должны приходить событиями
+func courtStints(events []*entity.GameEvent) map[string][]courtStint {
+	type presence struct {
+		onCourt          bool
+		left, leftSecond int
+	}
+	stints := make(map[string][]courtStint)
+	players := make(map[string]*presence)
+
+	var periodStart, periodStartSecond, period, second int
+	enter := func(playerID, team string, from, fromSecond int) {
+		stints[playerID] = append(stints[playerID], courtStint{team: team, from: from, fromSecond: fromSecond})
+		players[playerID] = &presence{onCourt: true}
+	}
+	// onCourt - игрок, упомянутый в событии i, к этому моменту на площадке
+	onCourt := func(playerID, team string) {
+		p, ok := players[playerID]
+		if ok && p.onCourt {
+			return
+		}
+		from, fromSecond := periodStart, periodStartSecond
+		if ok && p.left > from {
+			from, fromSecond = p.left, p.leftSecond
+		}
+		enter(playerID, team, from, fromSecond)
+	}
+	leave := func(playerID string, to, toSecond int) {
+		playerStints := stints[playerID]
+		playerStints[len(playerStints)-1].to = to
+		playerStints[len(playerStints)-1].toSecond = toSecond
+		players[playerID] = &presence{left: to, leftSecond: toSecond}
+	}
+
+	for i, event := range events {
+		if event.Period > period {
+			periodStart, periodStartSecond = i, periodEnd(event.Period-1)
+			period = event.Period
+		}
+		second = gameSecond(event.Period, event.Clock)
+
+		if event.Type != entity.EventSubstitution {
+			onCourt(event.Player, event.Team)
+			continue
+		}
+		onCourt(event.Replaced, event.Team)
+		leave(event.Replaced, i, second)
+		if p, ok := players[event.Player]; ok && p.onCourt {
+			continue
+		}
+		enter(event.Player, event.Team, i, second)
+	}
+
+	for playerID, p := range players {
+		if p.onCourt {
+			leave(playerID, len(events), periodEnd(period))
+		}
+	}
+
+	return stints
+}
+
+// periodEnd - секунда матча, на которой заканчивается период
+func periodEnd(period int) int {
+	if period <= 4 {
+		return period * periodSeconds
+	}
+	return 4*periodSeconds + (period-4)*overtimeSeconds
+}
+
+// gameSecond - секунда матча по периоду и оставшемуся времени периода в формате mm:ss
+func gameSecond(period int, clock string) int {
+	length := periodSeconds
+	if period > 4 {
+		length = overtimeSeconds
+	}
+
+	var remaining int
+	if minutes, seconds, ok := strings.Cut(clock, ":"); ok {
+		m, _ := strconv.Atoi(minutes)
+		s, _ := strconv.Atoi(seconds)
+		remaining = min(m*60+s, length)
+	}
+
+	return periodEnd(period) - remaining
+}
+
+// shotPoints - очки за забитый бросок
+func shotPoints(shotType string) int {
+	switch shotType {
+	case entity.ShotFreeThrow:
+		return 1
+	case entity.ShotThreePoint:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// normalizePlayerStat - проверяет box score и досчитывает производные поля: очки, общие подборы и устаревшие Goals/Interceptions.
+// Старые клиенты присылают только goals и interceptions, они переносятся в FGM и Steals.
+// FGA 0 при забитых бросках значит, что попытки неизвестны: такие строки не участвуют в процентах реализации
+func normalizePlayerStat(stat entity.PlayerStat) (entity.PlayerStat, error) {
+	if stat.FGM == 0 {
+		stat.FGM = stat.Goals
+	}
+	if stat.Steals == 0 {
+		stat.Steals = stat.Interceptions
+	}
+
+	counters := map[string]int{
+		"points": stat.Points, "fgm": stat.FGM, "fga": stat.FGA, "threePm": stat.ThreePM, "threePa": stat.ThreePA,
+		"ftm": stat.FTM, "fta": stat.FTA, "offensiveRebounds": stat.OffensiveRebounds, "defensiveRebounds": stat.DefensiveRebounds,
+		"rebounds": stat.Rebounds, "assists": stat.Assists, "steals": stat.Steals, "blocks": stat.Blocks,
+		"turnovers": stat.Turnovers, "personalFouls": stat.PersonalFouls,
+	}
+	for name, value := range counters {
+		if value < 0 {
+			return stat, fmt.Errorf("%w: %s can't be negative", apperrors.ErrInvalidPlayerStat, name)
+		}
+	}
+	if stat.Minutes < 0 {
+		return stat, fmt.Errorf("%w: minutes can't be negative", apperrors.ErrInvalidPlayerStat)
+	}
+
+	switch {
+	case stat.FGA != 0 && stat.FGM > stat.FGA:
+		return stat, fmt.Errorf("%w: fgm %d is greater than fga %d", apperrors.ErrInvalidPlayerStat, stat.FGM, stat.FGA)
+	case stat.ThreePM > stat.ThreePA:
+		return stat, fmt.Errorf("%w: threePm %d is greater than threePa %d", apperrors.ErrInvalidPlayerStat, stat.ThreePM, stat.ThreePA)
+	case stat.FTM > stat.FTA:
+		return stat, fmt.Errorf("%w: ftm %d is greater than fta %d", apperrors.ErrInvalidPlayerStat, stat.FTM, stat.FTA)
+	case stat.ThreePM > stat.FGM || stat.ThreePA > stat.FGA:
+		return stat, fmt.Errorf("%w: three-pointers must be included in field goals", apperrors.ErrInvalidPlayerStat)
+	}
+
+	points := 2*(stat.FGM-stat.ThreePM) + 3*stat.ThreePM + stat.FTM
+	if stat.Points == 0 {
+		stat.Points = points
+	} else if stat.Points != points {
+		return stat, fmt.Errorf("%w: points %d don't match shots, expected %d", apperrors.ErrInvalidPlayerStat, stat.Points, points)
+	}
+
+	rebounds := stat.OffensiveRebounds + stat.DefensiveRebounds
+	if rebounds != 0 && stat.Rebounds != 0 && stat.Rebounds != rebounds {
+		return stat, fmt.Errorf("%w: rebounds %d don't match offensive and defensive sum %d", apperrors.ErrInvalidPlayerStat, stat.Rebounds, rebounds)
+	}
+	if rebounds == 0 {
+		// разбивка неизвестна, как и при миграции старых строк все подборы считаются подборами в защите
+		stat.DefensiveRebounds = stat.Rebounds
+	}
+	stat.Rebounds = stat.OffensiveRebounds + stat.DefensiveRebounds
+
+	stat.Goals = stat.FGM
+	stat.Interceptions = stat.Steals
+	stat.AVGGoals, stat.TotalAVGStats = 0, 0
+
+	return stat, nil
+}
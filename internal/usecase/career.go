@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// maxSeparationDegrees - дальше шести рукопожатий цепочку не ищем
+const maxSeparationDegrees = 6
+
+// backfillChunk - сколько связей с матчами Backfill пишет в граф одним запросом
+const backfillChunk = 1000
+
+// CareerUC - связи игроков в графе карьеры: составы команд и сыгранные матчи
+type CareerUC struct {
+	careerRp     CareerRp
+	playerRp     PlayerRp
+	teamRp       TeamRp
+	gameRp       GameRp
+	statPlayerRp StatPlayerRp
+}
+
+func NewCareerUC(careerRp CareerRp, playerRp PlayerRp, teamRp TeamRp, gameRp GameRp, statPlayerRp StatPlayerRp) *CareerUC {
+	return &CareerUC{
+		careerRp:     careerRp,
+		playerRp:     playerRp,
+		teamRp:       teamRp,
+		gameRp:       gameRp,
+		statPlayerRp: statPlayerRp,
+	}
+}
+
+var _ Career = (*CareerUC)(nil)
+
+// GetTeammates - все, кто был с игроком в одной команде, включая нынешних партнёров
+func (c *CareerUC) GetTeammates(ctx context.Context, playerID string) ([]entity.Teammate, error) {
+	if _, err := c.playerRp.GetPlayer(ctx, playerID); err != nil {
+		return nil, err
+	}
+	return c.careerRp.GetTeammates(ctx, playerID)
+}
+
+// GetSeparation - степени разделения двух игроков через совместно сыгранные матчи
+func (c *CareerUC) GetSeparation(ctx context.Context, playerID, otherID string) (*entity.Separation, error) {
+	player, err := c.playerRp.GetPlayer(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.playerRp.GetPlayer(ctx, otherID); err != nil {
+		return nil, err
+	}
+
+	if playerID == otherID {
+		return &entity.Separation{
+			Path: []entity.SeparationStep{{Player: playerID, Name: player.Name, Surname: player.Surname}},
+		}, nil
+	}
+
+	separation, err := c.careerRp.GetSeparation(ctx, playerID, otherID, maxSeparationDegrees)
+	if err != nil {
+		return nil, err
+	}
+	if separation == nil {
+		return nil, fmt.Errorf("%w: %s and %s are not within %d degrees",
+			apperrors.ErrPlayersNotConnected, playerID, otherID, maxSeparationDegrees)
+	}
+
+	return separation, nil
+}
+
+// GetCommonOpponents - команды, против которых играли оба игрока. Названия команд берутся из каталога:
+// команды не публикуются в outbox, и в графе их названия устаревали бы
+func (c *CareerUC) GetCommonOpponents(ctx context.Context, playerID, otherID string) ([]entity.CommonOpponent, error) {
+	for _, id := range []string{playerID, otherID} {
+		if _, err := c.playerRp.GetPlayer(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	opponents, err := c.careerRp.GetCommonOpponents(ctx, playerID, otherID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range opponents {
+		team, err := c.teamRp.GetTeam(ctx, opponents[i].Team)
+		switch {
+		case err == nil:
+			opponents[i].TeamName = team.Name
+		case !errors.Is(err, apperrors.ErrTeamNotFound) && !errors.Is(err, apperrors.ErrInvalidTeamID):
+			return nil, err
+		}
+	}
+
+	return opponents, nil
+}
+
+// Backfill - восстанавливает граф карьеры для данных, записанных до него или мимо него. Сначала по статистике
+// записываются связи игроков с матчами, затем состав каждого игрока каталога пересобирается по этим матчам,
+// см. careerHistory. Повторный запуск безопасен: результат зависит только от статистики, каталога и текущего членства
+func (c *CareerUC) Backfill(ctx context.Context) (*entity.CareerBackfill, error) {
+	result := &entity.CareerBackfill{}
+
+	games := make(map[string]*entity.Game)
+	byPlayer := make(map[string][]entity.Appearance)
+	pending := make([]entity.Appearance, 0, backfillChunk)
+	flush := func() error {
+		if err := c.careerRp.RecordAppearances(ctx, pending); err != nil {
+			return err
+		}
+		result.Appearances += len(pending)
+		pending = pending[:0]
+		return nil
+	}
+	err := c.statPlayerRp.StreamAppearances(ctx, func(appearance entity.Appearance) error {
+		game, ok := games[appearance.Match]
+		if !ok {
+			var err error
+			game, err = c.gameRp.GetGame(ctx, appearance.Match)
+			if err != nil && !errors.Is(err, apperrors.ErrGameNotFound) && !errors.Is(err, apperrors.ErrInvalidGameID) {
+				return err
+			}
+			games[appearance.Match] = game
+		}
+		if game != nil {
+			appearance.Opponent = game.Opponent(appearance.Team)
+		}
+
+		pending = append(pending, appearance)
+		byPlayer[appearance.Player] = append(byPlayer[appearance.Player], appearance)
+		if len(pending) < backfillChunk {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return result, fmt.Errorf("appearances: %w", err)
+	}
+
+	today := time.Now().UTC().Format(entity.CareerDateLayout)
+	err = c.playerRp.StreamPlayerIDs(ctx, false, func(playerID string) error {
+		player, err := c.playerRp.GetPlayer(ctx, playerID)
+		if err != nil {
+			return err
+		}
+		current, err := c.careerRp.GetMemberships(ctx, playerID)
+		if err != nil {
+			return err
+		}
+
+		history := careerHistory(playerID, player, byPlayer[playerID], current, today)
+		if err = c.careerRp.ReplaceMemberships(ctx, playerID, history); err != nil {
+			return fmt.Errorf("player %s: %w", playerID, err)
+		}
+		result.Players++
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("memberships: %w", err)
+	}
+
+	return result, nil
+}
+
+// careerHistory - членства игрока по сыгранным матчам: подряд идущие по дате матчи за одну команду - одно членство
+// с первого по последний матч. Членство в текущей команде из каталога остаётся открытым и начинается с первого
+// матча за неё или с даты уже записанного открытого членства, если она раньше. Прежние закрытые членства
+// заменяются восстановленными: дата ухода из команды - дата последнего матча за неё
+func careerHistory(playerID string, player *entity.Player, appearances []entity.Appearance,
+	current []entity.TeamMembership, today string) []entity.TeamMembership {
+	dated := make([]entity.Appearance, 0, len(appearances))
+	for _, a := range appearances {
+		if a.Date != "" && a.Team != "" {
+			dated = append(dated, a)
+		}
+	}
+	sort.Slice(dated, func(i, j int) bool { return dated[i].Date < dated[j].Date })
+
+	var history []entity.TeamMembership
+	for _, a := range dated {
+		if n := len(history); n != 0 && history[n-1].Team == a.Team {
+			history[n-1].Until = a.Date
+			continue
+		}
+		history = append(history, entity.TeamMembership{Team: a.Team, Since: a.Date, Until: a.Date})
+	}
+
+	if player.Team != "" {
+		var openSince string
+		for _, m := range current {
+			if m.Until == "" && m.Team == player.Team {
+				openSince = m.Since
+			}
+		}
+
+		if n := len(history); n != 0 && history[n-1].Team == player.Team {
+			history[n-1].Until = ""
+			if openSince != "" && openSince < history[n-1].Since {
+				history[n-1].Since = openSince
+			}
+		} else {
+			since := openSince
+			if since == "" {
+				since = today
+			}
+			if n != 0 && since < history[n-1].Until {
+				since = history[n-1].Until
+			}
+			history = append(history, entity.TeamMembership{Team: player.Team, Since: since})
+		}
+	}
+
+	for i := range history {
+		history[i].Player, history[i].Name, history[i].Surname = playerID, player.Name, player.Surname
+	}
+	return history
+}
+
+// recordMembership - переносит команду игрока из каталога в граф карьеры, начиная с сегодняшнего дня.
+// Членства игроков, созданных раньше графа карьеры, восстанавливает команда backfill career
+func recordMembership(ctx context.Context, careerRp CareerRp, playerID string, player *entity.Player) error {
+	return careerRp.SetPlayerTeam(ctx, entity.TeamMembership{
+		Player:  playerID,
+		Name:    player.Name,
+		Surname: player.Surname,
+		Team:    player.Team,
+		Since:   time.Now().UTC().Format(entity.CareerDateLayout),
+	})
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// ConsistencyUC - сверяет id игроков и матчей в каталоге, статистике и графе наград
+type ConsistencyUC struct {
+	playerRp     PlayerRp
+	gameRp       GameRp
+	statPlayerRp StatPlayerRp
+	statAwardsRp StatAwardsRp
+}
+
+func NewConsistencyUC(playerRp PlayerRp, gameRp GameRp, statPlayerRp StatPlayerRp, statAwardsRp StatAwardsRp) *ConsistencyUC {
+	return &ConsistencyUC{
+		playerRp:     playerRp,
+		gameRp:       gameRp,
+		statPlayerRp: statPlayerRp,
+		statAwardsRp: statAwardsRp,
+	}
+}
+
+var _ Consistency = (*ConsistencyUC)(nil)
+
+// entityIDs - id одной сущности во всех хранилищах
+type entityIDs struct {
+	catalog    map[string]struct{}
+	tombstones map[string]struct{}
+	stats      map[string]struct{}
+	nodes      map[string]bool // id узла -> помечен удалённым
+}
+
+// Check - находит расхождения между хранилищами и, если задана политика, исправляет их:
+// cascade удаляет осиротевшие строки статистики и узлы графа, tombstone помечает осиротевшие узлы удалёнными.
+// Непомеченные узлы сущностей, удалённых с сохранением истории, помечаются при любой политике
+func (c *ConsistencyUC) Check(ctx context.Context, req entity.ConsistencyRequest) (*entity.ConsistencyReport, error) {
+	switch req.Fix {
+	case "", entity.DeletionCascade, entity.DeletionTombstone:
+	default:
+		return nil, fmt.Errorf("fix policy must be %q or %q, got %q", entity.DeletionCascade, entity.DeletionTombstone, req.Fix)
+	}
+
+	report := &entity.ConsistencyReport{Counts: make(map[string]entity.ConsistencyCounts)}
+	for _, entityKind := range []string{entity.DeletionEntityPlayer, entity.DeletionEntityGame} {
+		ids, err := c.collect(ctx, entityKind)
+		if err != nil {
+			return nil, err
+		}
+		report.Counts[entityKind] = entity.ConsistencyCounts{
+			Catalog:    len(ids.catalog),
+			Tombstones: len(ids.tombstones),
+			Stats:      len(ids.stats),
+			Graph:      len(ids.nodes),
+		}
+
+		findings, err := c.compare(ctx, entityKind, ids, req)
+		if err != nil {
+			return nil, err
+		}
+		report.Findings = append(report.Findings, findings...)
+	}
+
+	return report, nil
+}
+
+func (c *ConsistencyUC) collect(ctx context.Context, entityKind string) (*entityIDs, error) {
+	ids := &entityIDs{
+		catalog:    make(map[string]struct{}),
+		tombstones: make(map[string]struct{}),
+		stats:      make(map[string]struct{}),
+		nodes:      make(map[string]bool),
+	}
+	add := func(set map[string]struct{}) func(string) error {
+		return func(id string) error {
+			set[id] = struct{}{}
+			return nil
+		}
+	}
+
+	streamCatalog := c.playerRp.StreamPlayerIDs
+	if entityKind == entity.DeletionEntityGame {
+		streamCatalog = c.gameRp.StreamGameIDs
+	}
+	if err := streamCatalog(ctx, false, add(ids.catalog)); err != nil {
+		return nil, fmt.Errorf("%s catalog ids: %w", entityKind, err)
+	}
+	if err := streamCatalog(ctx, true, add(ids.tombstones)); err != nil {
+		return nil, fmt.Errorf("%s tombstone ids: %w", entityKind, err)
+	}
+	if err := c.statPlayerRp.StreamStatIDs(ctx, entityKind, add(ids.stats)); err != nil {
+		return nil, fmt.Errorf("%s player stats ids: %w", entityKind, err)
+	}
+	err := c.statAwardsRp.StreamNodeIDs(ctx, entityKind, func(id string, deleted bool) error {
+		ids.nodes[id] = deleted
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s awards graph ids: %w", entityKind, err)
+	}
+
+	return ids, nil
+}
+
+func (c *ConsistencyUC) compare(ctx context.Context, entityKind string, ids *entityIDs, req entity.ConsistencyRequest) ([]entity.ConsistencyFinding, error) {
+	known := func(id string) bool {
+		_, live := ids.catalog[id]
+		_, buried := ids.tombstones[id]
+		return live || buried
+	}
+
+	var orphanStats, orphanNodes, unmarked, deletedInCatalog, noStats, noNode []string
+	for id := range ids.stats {
+		if !known(id) {
+			orphanStats = append(orphanStats, id)
+		}
+	}
+	for id, deleted := range ids.nodes {
+		_, live := ids.catalog[id]
+		_, buried := ids.tombstones[id]
+		switch {
+		case live && deleted:
+			deletedInCatalog = append(deletedInCatalog, id)
+		case buried && !deleted:
+			unmarked = append(unmarked, id)
+		case !live && !buried && !deleted:
+			orphanNodes = append(orphanNodes, id)
+		}
+	}
+	for id := range ids.catalog {
+		if _, ok := ids.stats[id]; !ok {
+			noStats = append(noStats, id)
+		}
+		if _, ok := ids.nodes[id]; !ok {
+			noNode = append(noNode, id)
+		}
+	}
+
+	var repairStats, repairNodes func(ctx context.Context, entityKind, id string) error
+	switch req.Fix {
+	case entity.DeletionCascade:
+		repairStats, repairNodes = c.statPlayerRp.DeleteStats, c.statAwardsRp.DeleteNode
+	case entity.DeletionTombstone:
+		repairNodes = c.statAwardsRp.TombstoneNode
+	}
+	var markTombstones func(ctx context.Context, entityKind, id string) error
+	if req.Fix != "" {
+		markTombstones = c.statAwardsRp.TombstoneNode
+	}
+
+	var findings []entity.ConsistencyFinding
+	for _, f := range []struct {
+		kind   string
+		store  string
+		ids    []string
+		repair func(ctx context.Context, entityKind, id string) error
+		info   bool
+	}{
+		{entity.FindingOrphanStats, entity.DeletionStepStats, orphanStats, repairStats, false},
+		{entity.FindingOrphanNodes, entity.DeletionStepAwards, orphanNodes, repairNodes, false},
+		{entity.FindingUnmarkedTombstone, entity.DeletionStepAwards, unmarked, markTombstones, false},
+		{entity.FindingDeletedNodeInCatalog, entity.DeletionStepAwards, deletedInCatalog, nil, false},
+		{entity.FindingNoStats, entity.DeletionStepCatalog, noStats, nil, true},
+		{entity.FindingNoAwardNode, entity.DeletionStepCatalog, noNode, nil, true},
+	} {
+		if len(f.ids) == 0 {
+			continue
+		}
+		sort.Strings(f.ids)
+
+		finding := entity.ConsistencyFinding{
+			Entity: entityKind,
+			Kind:   f.kind,
+			Store:  f.store,
+			Count:  len(f.ids),
+			IDs:    f.ids,
+			Info:   f.info,
+		}
+		if f.repair != nil {
+			for _, id := range f.ids {
+				if err := f.repair(ctx, entityKind, id); err != nil {
+					return nil, fmt.Errorf("fix %s %s %s: %w", entityKind, f.kind, id, err)
+				}
+				finding.Fixed++
+			}
+		}
+		if req.Limit > 0 && len(finding.IDs) > req.Limit {
+			finding.IDs = finding.IDs[:req.Limit]
+		}
+		findings = append(findings, finding)
+	}
+
+	return findings, nil
+}
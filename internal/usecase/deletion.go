@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// DeletionPolicies - политика удаления для каждой сущности каталога
+type DeletionPolicies struct {
+	Player entity.DeletionPolicy
+	Game   entity.DeletionPolicy
+}
+
+// deletionJobLease - на сколько задача захватывается для выполнения; должно хватать на самую долгую задачу,
+// после этого задачу, оставшуюся от упавшей копии приложения, можно повторить
+const deletionJobLease = 10 * time.Minute
+
+// DeletionUC - удаление игроков и матчей из каталога, статистики и графа наград как отслеживаемая задача.
+// Задачу одновременно выполняет только одна копия приложения: перед выполнением она захватывается в хранилище задач
+type DeletionUC struct {
+	jobRp        DeletionJobRp
+	playerRp     PlayerRp
+	gameRp       GameRp
+	gameEventRp  GameEventRp
+	statPlayerRp StatPlayerRp
+	statAwardsRp StatAwardsRp
+	policies     DeletionPolicies
+}
+
+func NewDeletionUC(jobRp DeletionJobRp, playerRp PlayerRp, gameRp GameRp, gameEventRp GameEventRp,
+	statPlayerRp StatPlayerRp, statAwardsRp StatAwardsRp, policies DeletionPolicies) *DeletionUC {
+	return &DeletionUC{
+		jobRp:        jobRp,
+		playerRp:     playerRp,
+		gameRp:       gameRp,
+		gameEventRp:  gameEventRp,
+		statPlayerRp: statPlayerRp,
+		statAwardsRp: statAwardsRp,
+		policies:     policies,
+	}
+}
+
+var _ Deletion = (*DeletionUC)(nil)
+
+func (d *DeletionUC) DeletePlayer(ctx context.Context, playerID string) (*entity.DeletionJob, error) {
+	if _, err := d.playerRp.GetPlayer(ctx, playerID); err != nil {
+		return nil, err
+	}
+	return d.start(ctx, entity.DeletionEntityPlayer, playerID, d.policies.Player)
+}
+
+func (d *DeletionUC) DeleteGame(ctx context.Context, gameID string) (*entity.DeletionJob, error) {
+	if _, err := d.gameRp.GetGame(ctx, gameID); err != nil {
+		return nil, err
+	}
+	return d.start(ctx, entity.DeletionEntityGame, gameID, d.policies.Game)
+}
+
+func (d *DeletionUC) GetJob(ctx context.Context, jobID string) (*entity.DeletionJob, error) {
+	return d.jobRp.GetJob(ctx, jobID)
+}
+
+// RetryJob - продолжает задачу с невыполненного шага; завершённая задача возвращается как есть,
+// а выполняемая сейчас - ErrDeletionJobRunning
+func (d *DeletionUC) RetryJob(ctx context.Context, jobID string) (*entity.DeletionJob, error) {
+	job, err := d.jobRp.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == entity.DeletionJobDone {
+		return job, nil
+	}
+
+	now := time.Now().UTC()
+	if job, err = d.jobRp.ClaimJob(ctx, jobID, now, now.Add(deletionJobLease)); err != nil {
+		return nil, err
+	}
+	return d.run(ctx, job)
+}
+
+// RetryFailedJobs - повторяет все задачи, упавшие на одном из шагов; задачи, которые уже выполняются, пропускаются
+func (d *DeletionUC) RetryFailedJobs(ctx context.Context) error {
+	jobs, err := d.jobRp.GetJobsByStatus(ctx, entity.DeletionJobFailed)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, job := range jobs {
+		now := time.Now().UTC()
+		claimed, err := d.jobRp.ClaimJob(ctx, job.ID, now, now.Add(deletionJobLease))
+		if errors.Is(err, apperrors.ErrDeletionJobRunning) {
+			continue
+		}
+		if err == nil {
+			_, err = d.run(ctx, claimed)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("deletion job %s: %w", job.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// start - проверяет политику, сохраняет задачу и сразу выполняет её
+func (d *DeletionUC) start(ctx context.Context, entityKind, id string, policy entity.DeletionPolicy) (*entity.DeletionJob, error) {
+	var stores []string
+	switch policy {
+	case entity.DeletionReject:
+		if err := d.checkUnreferenced(ctx, entityKind, id); err != nil {
+			return nil, err
+		}
+		stores = []string{entity.DeletionStepCatalog}
+	case entity.DeletionCascade:
+		stores = []string{entity.DeletionStepStats, entity.DeletionStepAwards, entity.DeletionStepEvents, entity.DeletionStepCatalog}
+	case entity.DeletionTombstone:
+		stores = []string{entity.DeletionStepAwards, entity.DeletionStepCatalog}
+	default:
+		return nil, fmt.Errorf("unknown deletion policy %q for %s", policy, entityKind)
+	}
+
+	now := time.Now().UTC()
+	job := &entity.DeletionJob{
+		Entity:    entityKind,
+		EntityID:  id,
+		Policy:    policy,
+		Status:    entity.DeletionJobPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+		// новая задача сразу захвачена: её выполняет тот же запрос
+		LockedUntil: now.Add(deletionJobLease),
+	}
+	for _, store := range stores {
+		job.Steps = append(job.Steps, entity.DeletionStep{Store: store})
+	}
+
+	jobID, err := d.jobRp.CreateJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	job.ID = jobID
+
+	return d.run(ctx, job)
+}
+
+// checkUnreferenced - для политики reject: на сущность не должны ссылаться другие хранилища
+func (d *DeletionUC) checkUnreferenced(ctx context.Context, entityKind, id string) error {
+	hasStats, err := d.statPlayerRp.HasStats(ctx, entityKind, id)
+	if err != nil {
+		return err
+	}
+	if hasStats {
+		return fmt.Errorf("%w: %s %s has player stats", apperrors.ErrEntityReferenced, entityKind, id)
+	}
+
+	hasRecords, err := d.statAwardsRp.HasRecords(ctx, entityKind, id)
+	if err != nil {
+		return err
+	}
+	if hasRecords {
+		return fmt.Errorf("%w: %s %s has award records", apperrors.ErrEntityReferenced, entityKind, id)
+	}
+
+	hasEvents, err := d.hasEvents(ctx, entityKind, id)
+	if err != nil {
+		return err
+	}
+	if hasEvents {
+		return fmt.Errorf("%w: %s %s has game events", apperrors.ErrEntityReferenced, entityKind, id)
+	}
+
+	return nil
+}
+
+// hasEvents - события матча или события, в которых участвует игрок
+func (d *DeletionUC) hasEvents(ctx context.Context, entityKind, id string) (bool, error) {
+	switch entityKind {
+	case entity.DeletionEntityPlayer:
+		return d.gameEventRp.HasEventsByPlayer(ctx, id)
+	case entity.DeletionEntityGame:
+		events, err := d.gameEventRp.GetEventsByGame(ctx, id)
+		return len(events) != 0, err
+	default:
+		return false, fmt.Errorf("unknown deletion entity %q", entityKind)
+	}
+}
+
+// run - выполняет невыполненные шаги захваченной задачи по порядку, сохраняет её состояние и отпускает её.
+// Ошибка шага не возвращается, а записывается в задачу: задача остаётся failed до повтора
+func (d *DeletionUC) run(ctx context.Context, job *entity.DeletionJob) (*entity.DeletionJob, error) {
+	job.Attempts++
+	job.Status = entity.DeletionJobDone
+	for i := range job.Steps {
+		step := &job.Steps[i]
+		if step.Done {
+			continue
+		}
+		if err := d.runStep(ctx, job, step.Store); err != nil {
+			step.Error = err.Error()
+			job.Status = entity.DeletionJobFailed
+			break
+		}
+		step.Done, step.Error = true, ""
+	}
+	job.UpdatedAt = time.Now().UTC()
+	job.LockedUntil = time.Time{}
+
+	if err := d.jobRp.UpdateJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// runStep - шаги идемпотентны: повтор после частичного сбоя не должен ломаться на уже удалённых данных
+func (d *DeletionUC) runStep(ctx context.Context, job *entity.DeletionJob, store string) error {
+	switch store {
+	case entity.DeletionStepStats:
+		return d.statPlayerRp.DeleteStats(ctx, job.Entity, job.EntityID)
+	case entity.DeletionStepAwards:
+		if job.Policy == entity.DeletionTombstone {
+			return d.statAwardsRp.TombstoneNode(ctx, job.Entity, job.EntityID)
+		}
+		return d.statAwardsRp.DeleteNode(ctx, job.Entity, job.EntityID)
+	case entity.DeletionStepEvents:
+		if job.Entity == entity.DeletionEntityPlayer {
+			return d.gameEventRp.DeleteEventsByPlayer(ctx, job.EntityID)
+		}
+		return d.gameEventRp.DeleteEventsByGame(ctx, job.EntityID)
+	case entity.DeletionStepCatalog:
+		return d.deleteFromCatalog(ctx, job)
+	default:
+		return fmt.Errorf("unknown deletion step %q", store)
+	}
+}
+
+func (d *DeletionUC) deleteFromCatalog(ctx context.Context, job *entity.DeletionJob) error {
+	var err error
+	switch {
+	case job.Entity == entity.DeletionEntityPlayer && job.Policy == entity.DeletionTombstone:
+		err = d.playerRp.TombstonePlayer(ctx, job.EntityID)
+	case job.Entity == entity.DeletionEntityPlayer:
+		err = d.playerRp.DeletePlayer(ctx, job.EntityID)
+	case job.Entity == entity.DeletionEntityGame && job.Policy == entity.DeletionTombstone:
+		err = d.gameRp.TombstoneGame(ctx, job.EntityID)
+	case job.Entity == entity.DeletionEntityGame:
+		err = d.gameRp.DeleteGame(ctx, job.EntityID)
+	default:
+		return fmt.Errorf("unknown deletion entity %q", job.Entity)
+	}
+
+	// документ уже удалён прошлой попыткой, которая не успела сохранить задачу
+	if errors.Is(err, apperrors.ErrPlayerNotFound) || errors.Is(err, apperrors.ErrGameNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/internal/usecase/repo/memory_rp"
+)
+
+func TestRetryClaimedDeletionJob(t *testing.T) {
+	ctx := context.Background()
+	jobs, players := memory_rp.NewDeletionJobRepo(), memory_rp.NewPlayerRepo()
+	deletion := usecase.NewDeletionUC(jobs, players, memory_rp.NewGameRepo(), memory_rp.NewGameEventRepo(),
+		memory_rp.NewStatPlayerRepo(), memory_rp.NewStatAwardsRepo(), usecase.DeletionPolicies{})
+
+	playerID, err := players.CreatePlayer(ctx, &entity.Player{Name: "retired"})
+	if err != nil {
+		t.Fatalf("CreatePlayer() error = %v", err)
+	}
+	// задача упала и сейчас выполняется другой копией приложения
+	jobID, err := jobs.CreateJob(ctx, &entity.DeletionJob{
+		Entity:      entity.DeletionEntityPlayer,
+		EntityID:    playerID,
+		Policy:      entity.DeletionReject,
+		Status:      entity.DeletionJobFailed,
+		Steps:       []entity.DeletionStep{{Store: entity.DeletionStepCatalog}},
+		Attempts:    1,
+		LockedUntil: time.Now().Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("CreateJob() error = %v", err)
+	}
+
+	if _, err = deletion.RetryJob(ctx, jobID); !errors.Is(err, apperrors.ErrDeletionJobRunning) {
+		t.Fatalf("RetryJob() error = %v, want %v", err, apperrors.ErrDeletionJobRunning)
+	}
+	if err = deletion.RetryFailedJobs(ctx); err != nil {
+		t.Fatalf("RetryFailedJobs() error = %v", err)
+	}
+	if job, _ := jobs.GetJob(ctx, jobID); job.Attempts != 1 {
+		t.Fatalf("claimed job was run %d more times", job.Attempts-1)
+	}
+
+	// срок захвата истёк: копия, которая держала задачу, упала
+	job, err := jobs.GetJob(ctx, jobID)
+	if err != nil {
+		t.Fatalf("GetJob() error = %v", err)
+	}
+	job.LockedUntil = time.Now().Add(-time.Second)
+	if err = jobs.UpdateJob(ctx, job); err != nil {
+		t.Fatalf("UpdateJob() error = %v", err)
+	}
+
+	if job, err = deletion.RetryJob(ctx, jobID); err != nil {
+		t.Fatalf("RetryJob() error = %v", err)
+	}
+	if job.Status != entity.DeletionJobDone || job.Attempts != 2 || !job.LockedUntil.IsZero() {
+		t.Errorf("job = %+v, want done after the second attempt and released", job)
+	}
+	if _, err = players.GetPlayer(ctx, playerID); !errors.Is(err, apperrors.ErrPlayerNotFound) {
+		t.Errorf("GetPlayer() error = %v, want %v", err, apperrors.ErrPlayerNotFound)
+	}
+}
@@ -2,26 +2,85 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	"github.com/romeros69/basket/internal/apperrors"
 	"github.com/romeros69/basket/internal/entity"
 )
 
 type GameUC struct {
-	gameRp GameRp
+	gameRp    GameRp
+	teamRp    TeamRp
+	leagueRp  LeagueRp
+	deletion  Deletion
+	listeners []GameResultListener
 }
 
-func NewGameUC(gameRp GameRp) *GameUC {
+func NewGameUC(gameRp GameRp, teamRp TeamRp, leagueRp LeagueRp, deletion Deletion) *GameUC {
 	return &GameUC{
-		gameRp: gameRp,
+		gameRp:   gameRp,
+		teamRp:   teamRp,
+		leagueRp: leagueRp,
+		deletion: deletion,
 	}
 }
 
 var _ Game = (*GameUC)(nil)
 
+// AddResultListener - подписывает обработчик на запись и исправление результатов матчей
+func (g *GameUC) AddResultListener(listener GameResultListener) {
+	g.listeners = append(g.listeners, listener)
+}
+
+// CreateGame - новый матч может быть только запланирован или перенесён: результат записывается через RecordResult
 func (g *GameUC) CreateGame(ctx context.Context, game *entity.Game) (string, error) {
+	if err := g.validate(ctx, game); err != nil {
+		return "", err
+	}
+
+	if game.Status == "" {
+		game.Status = entity.GameStatusScheduled
+	}
+	if game.Status != entity.GameStatusScheduled && game.Status != entity.GameStatusPostponed {
+		return "", fmt.Errorf("%w: new game must be %s or %s, record its result through /game/{id}/result",
+			apperrors.ErrInvalidGameResult, entity.GameStatusScheduled, entity.GameStatusPostponed)
+	}
+	result, err := normalizeResult(game.Result())
+	if err != nil {
+		return "", err
+	}
+	applyResult(game, result)
+
 	return g.gameRp.CreateGame(ctx, game)
 }
 
+// UpdateGame - меняет расписание и участников матча. Статус, счёт, победитель и место в сетке плей-офф
+// берутся из сохранённого матча: их меняют только RecordResult и CorrectResult, которые оповещают слушателей.
+// Матчи плей-офф создаёт сетка, поэтому у них нельзя менять команды, тип и лигу, а обычный матч нельзя сделать матчем плей-офф
 func (g *GameUC) UpdateGame(ctx context.Context, gameID string, game *entity.Game) (*entity.Game, error) {
+	stored, err := g.gameRp.GetGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if err = g.validate(ctx, game); err != nil {
+		return nil, err
+	}
+	if stored.Status == entity.GameStatusFinal && (game.FirstTeam != stored.FirstTeam || game.SecondTeam != stored.SecondTeam) {
+		return nil, fmt.Errorf("%w: teams of a finished game can't be changed", apperrors.ErrGameAlreadyFinal)
+	}
+	if (stored.Type == entity.GameTypePlayoff || game.Type == entity.GameTypePlayoff) &&
+		(game.FirstTeam != stored.FirstTeam || game.SecondTeam != stored.SecondTeam ||
+			game.Type != stored.Type || game.League != stored.League) {
+		return nil, apperrors.ErrPlayoffGameChange
+	}
+
+	game.Status = stored.Status
+	game.FirstTeamScore, game.SecondTeamScore = stored.FirstTeamScore, stored.SecondTeamScore
+	game.Periods = stored.Periods
+	game.Winner = stored.Winner
+	game.Round, game.Series = stored.Round, stored.Series
+
 	return g.gameRp.UpdateGame(ctx, gameID, game)
 }
 
@@ -29,10 +88,90 @@ func (g *GameUC) GetGame(ctx context.Context, gameID string) (*entity.Game, erro
 	return g.gameRp.GetGame(ctx, gameID)
 }
 
-func (g *GameUC) DeleteGame(ctx context.Context, gameID string) error {
-	return g.gameRp.DeleteGame(ctx, gameID)
+// DeleteGame - удаление по политике для матчей, см. DeletionUC
+func (g *GameUC) DeleteGame(ctx context.Context, gameID string) (*entity.DeletionJob, error) {
+	return g.deletion.DeleteGame(ctx, gameID)
 }
 
 func (g *GameUC) GetGameList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Game, error) {
 	return g.gameRp.GetGameList(ctx, pageSize, pageNumber)
 }
+
+func (g *GameUC) RecordResult(ctx context.Context, gameID string, result *entity.GameResult) (*entity.Game, error) {
+	game, err := g.gameRp.GetGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if game.Status == entity.GameStatusFinal {
+		return nil, apperrors.ErrGameAlreadyFinal
+	}
+
+	return g.saveResult(ctx, gameID, game, result)
+}
+
+func (g *GameUC) CorrectResult(ctx context.Context, gameID string, result *entity.GameResult) (*entity.Game, error) {
+	game, err := g.gameRp.GetGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if game.Status != entity.GameStatusFinal {
+		return nil, apperrors.ErrGameNotFinal
+	}
+	if result.Status != entity.GameStatusFinal {
+		return nil, fmt.Errorf("%w: corrected result must stay final", apperrors.ErrInvalidGameResult)
+	}
+
+	return g.saveResult(ctx, gameID, game, result)
+}
+
+func (g *GameUC) saveResult(ctx context.Context, gameID string, game *entity.Game, result *entity.GameResult) (*entity.Game, error) {
+	normalized, err := normalizeResult(*result)
+	if err != nil {
+		return nil, err
+	}
+
+	previousWinner := game.Winner
+	applyResult(game, normalized)
+	if game.Type == entity.GameTypePlayoff && previousWinner != "" && game.Winner != previousWinner {
+		return nil, apperrors.ErrPlayoffWinnerChange
+	}
+
+	saved, err := g.gameRp.UpdateGame(ctx, gameID, game)
+	if err != nil {
+		return nil, err
+	}
+
+	// ошибка графа карьеры не мешает остальным слушателям, матч возвращается вместе с ней
+	var careerErr error
+	for _, listener := range g.listeners {
+		err = listener.GameResultSaved(ctx, gameID, saved)
+		if errors.Is(err, apperrors.ErrCareerGraphNotUpdated) {
+			careerErr = err
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("game %s result is saved, but its processing failed: %w", gameID, err)
+		}
+	}
+
+	return saved, careerErr
+}
+
+func (g *GameUC) validate(ctx context.Context, game *entity.Game) error {
+	if game.FirstTeam == game.SecondTeam {
+		return apperrors.ErrSameTeams
+	}
+	if err := checkTeamReference(ctx, g.teamRp, "first_team", game.FirstTeam); err != nil {
+		return err
+	}
+	if err := checkTeamReference(ctx, g.teamRp, "second_team", game.SecondTeam); err != nil {
+		return err
+	}
+	if game.League != "" {
+		if err := checkLeagueReference(ctx, g.leagueRp, "league", game.League); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
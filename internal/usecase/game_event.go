@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// eventClock - оставшееся время периода, mm:ss
+var eventClock = regexp.MustCompile(`^\d{1,2}:[0-5]\d$`)
+
+type GameEventUC struct {
+	// mu - номера событий матча выдаются последовательно
+	mu          sync.Mutex
+	gameEventRp GameEventRp
+	gameRp      GameRp
+	playerRp    PlayerRp
+	statPlayer  StatPlayer
+}
+
+func NewGameEventUC(gameEventRp GameEventRp, gameRp GameRp, playerRp PlayerRp, statPlayer StatPlayer) *GameEventUC {
+	return &GameEventUC{
+		gameEventRp: gameEventRp,
+		gameRp:      gameRp,
+		playerRp:    playerRp,
+		statPlayer:  statPlayer,
+	}
+}
+
+var (
+	_ GameEvent          = (*GameEventUC)(nil)
+	_ GameResultListener = (*GameEventUC)(nil)
+)
+
+// AddEvents - дописывает события в конец play-by-play матча; после final события не принимаются
+func (ge *GameEventUC) AddEvents(ctx context.Context, gameID string, events []*entity.GameEvent) ([]*entity.GameEvent, error) {
+	game, err := ge.gameRp.GetGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if game.Status == entity.GameStatusFinal {
+		return nil, apperrors.ErrGameEventsClosed
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: no events", apperrors.ErrInvalidGameEvent)
+	}
+
+	checked := make(map[string]bool)
+	for i, event := range events {
+		if err = validateEvent(event, game); err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		for _, playerID := range []string{event.Player, event.Replaced} {
+			if playerID == "" || checked[playerID] {
+				continue
+			}
+			if err = ge.checkPlayerReference(ctx, playerID); err != nil {
+				return nil, fmt.Errorf("event %d: %w", i, err)
+			}
+			checked[playerID] = true
+		}
+	}
+
+	ge.mu.Lock()
+	defer ge.mu.Unlock()
+
+	existing, err := ge.gameEventRp.GetEventsByGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	for i, event := range events {
+		event.Game = gameID
+		event.Sequence = len(existing) + i + 1
+	}
+
+	if err = ge.gameEventRp.AddEvents(ctx, events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (ge *GameEventUC) GetEvents(ctx context.Context, gameID string) ([]*entity.GameEvent, error) {
+	if _, err := ge.gameRp.GetGame(ctx, gameID); err != nil {
+		return nil, err
+	}
+
+	return ge.gameEventRp.GetEventsByGame(ctx, gameID)
+}
+
+// GetBoxScore - статистика игроков, посчитанная по событиям матча на текущий момент
+func (ge *GameEventUC) GetBoxScore(ctx context.Context, gameID string) ([]entity.PlayerStat, error) {
+	events, err := ge.GetEvents(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return boxScore(gameID, events), nil
+}
+
+// GameResultSaved - когда матч становится final, записывает статистику игроков, посчитанную по событиям.
+// Строки игроков из box score заменяются заново при каждом сохранении результата, поэтому исправление
+// результата и события, дописанные до него, не создают дублей и попадают в статистику.
+// Ошибка графа карьеры не останавливает запись остальных игроков и возвращается в конце
+func (ge *GameEventUC) GameResultSaved(ctx context.Context, gameID string, game *entity.Game) error {
+	if game.Status != entity.GameStatusFinal {
+		return nil
+	}
+
+	events, err := ge.gameEventRp.GetEventsByGame(ctx, gameID)
+	if err != nil {
+		return err
+	}
+
+	var careerErr error
+	for _, stat := range boxScore(gameID, events) {
+		err = ge.statPlayer.ReplacePlayerStat(ctx, stat)
+		if errors.Is(err, apperrors.ErrCareerGraphNotUpdated) {
+			careerErr = err
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("player %s box score: %w", stat.PlayerID, err)
+		}
+	}
+
+	return careerErr
+}
+
+func (ge *GameEventUC) checkPlayerReference(ctx context.Context, playerID string) error {
+	_, err := ge.playerRp.GetPlayer(ctx, playerID)
+	if errors.Is(err, apperrors.ErrPlayerNotFound) || errors.Is(err, apperrors.ErrInvalidPlayerID) {
+		return apperrors.NewReferenceError("player", playerID)
+	}
+	return err
+}
+
+func validateEvent(event *entity.GameEvent, game *entity.Game) error {
+	if event.Period < 1 {
+		return fmt.Errorf("%w: period must be positive", apperrors.ErrInvalidGameEvent)
+	}
+	if !eventClock.MatchString(event.Clock) {
+		return fmt.Errorf("%w: clock %q must be in mm:ss format", apperrors.ErrInvalidGameEvent, event.Clock)
+	}
+	if event.Team != game.FirstTeam && event.Team != game.SecondTeam {
+		return fmt.Errorf("%w: team %q doesn't play in the game", apperrors.ErrInvalidGameEvent, event.Team)
+	}
+	if event.Player == "" {
+		return fmt.Errorf("%w: player is required", apperrors.ErrInvalidGameEvent)
+	}
+
+	switch event.Type {
+	case entity.EventShotMade, entity.EventShotMissed:
+		switch event.ShotType {
+		case entity.ShotTwoPoint, entity.ShotThreePoint, entity.ShotFreeThrow:
+		default:
+			return fmt.Errorf("%w: unknown shot type %q", apperrors.ErrInvalidGameEvent, event.ShotType)
+		}
+	case entity.EventRebound:
+		switch event.ReboundType {
+		case entity.ReboundOffensive, entity.ReboundDefensive:
+		default:
+			return fmt.Errorf("%w: unknown rebound type %q", apperrors.ErrInvalidGameEvent, event.ReboundType)
+		}
+	case entity.EventSubstitution:
+		if event.Replaced == "" || event.Replaced == event.Player {
+			return fmt.Errorf("%w: substitution needs a different replaced player", apperrors.ErrInvalidGameEvent)
+		}
+	case entity.EventAssist, entity.EventSteal, entity.EventBlock, entity.EventTurnover, entity.EventFoul:
+	default:
+		return fmt.Errorf("%w: unknown event type %q", apperrors.ErrInvalidGameEvent, event.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/internal/usecase/repo/memory_rp"
+)
+
+func TestGameResultSavedReplacesBoxScore(t *testing.T) {
+	ctx := context.Background()
+	games, players, teams, stats := memory_rp.NewGameRepo(), memory_rp.NewPlayerRepo(), memory_rp.NewTeamRepo(), memory_rp.NewStatPlayerRepo()
+	home, away := newTeams(t, teams)
+	statPlayer := usecase.NewStatPlayerUC(stats, games, players, teams, memory_rp.NewLeagueRepo(), memory_rp.NewStatAwardsRepo())
+	events := usecase.NewGameEventUC(memory_rp.NewGameEventRepo(), games, players, statPlayer)
+
+	game := &entity.Game{FirstTeam: home, SecondTeam: away, Date: "01.03.24", Status: entity.GameStatusScheduled}
+	gameID, err := games.CreateGame(ctx, game)
+	if err != nil {
+		t.Fatalf("CreateGame() error = %v", err)
+	}
+	shooter, err := players.CreatePlayer(ctx, &entity.Player{Name: "shooter", Team: home})
+	if err != nil {
+		t.Fatalf("CreatePlayer() error = %v", err)
+	}
+	passer, err := players.CreatePlayer(ctx, &entity.Player{Name: "passer", Team: home})
+	if err != nil {
+		t.Fatalf("CreatePlayer() error = %v", err)
+	}
+
+	_, err = events.AddEvents(ctx, gameID, []*entity.GameEvent{
+		{Period: 1, Clock: "11:00", Type: entity.EventShotMade, ShotType: entity.ShotTwoPoint, Team: home, Player: shooter},
+		{Period: 1, Clock: "11:00", Type: entity.EventAssist, Team: home, Player: passer},
+		{Period: 1, Clock: "10:30", Type: entity.EventShotMade, ShotType: entity.ShotThreePoint, Team: home, Player: shooter},
+		{Period: 1, Clock: "10:00", Type: entity.EventRebound, ReboundType: entity.ReboundDefensive, Team: home, Player: passer},
+	})
+	if err != nil {
+		t.Fatalf("AddEvents() error = %v", err)
+	}
+
+	// статистику за матч уже отправили вручную, она расходится с событиями
+	if err = stats.InsertPlayerStat(ctx, entity.PlayerStat{PlayerID: shooter, MatchID: gameID, Goals: 7}); err != nil {
+		t.Fatalf("InsertPlayerStat() error = %v", err)
+	}
+
+	// результат сохраняется дважды: final и его исправление
+	game.Status, game.FirstTeamScore = entity.GameStatusFinal, 5
+	for i := 0; i < 2; i++ {
+		if err = events.GameResultSaved(ctx, gameID, game); err != nil {
+			t.Fatalf("GameResultSaved() error = %v", err)
+		}
+	}
+
+	for _, want := range []entity.PlayerStat{
+		{PlayerID: shooter, MatchID: gameID, TeamID: home, GameDate: "01.03.24", Points: 5, FGM: 2, FGA: 2, ThreePM: 1, ThreePA: 1, Minutes: 12, PlusMinus: 5, Goals: 2},
+		{PlayerID: passer, MatchID: gameID, TeamID: home, GameDate: "01.03.24", DefensiveRebounds: 1, Rebounds: 1, Assists: 1, Minutes: 12, PlusMinus: 5},
+	} {
+		rows, err := stats.GetPlayerStatsByIDAndMatch(ctx, want.PlayerID, gameID)
+		if err != nil {
+			t.Fatalf("GetPlayerStatsByIDAndMatch() error = %v", err)
+		}
+		if len(rows) != 1 || rows[0] != want {
+			t.Errorf("player %s rows = %+v, want only %+v", want.PlayerID, rows, want)
+		}
+	}
+}
+
+func TestGetBoxScoreMinutesAndPlusMinus(t *testing.T) {
+	ctx := context.Background()
+	games, players, teams := memory_rp.NewGameRepo(), memory_rp.NewPlayerRepo(), memory_rp.NewTeamRepo()
+	home, away := newTeams(t, teams)
+	statPlayer := usecase.NewStatPlayerUC(memory_rp.NewStatPlayerRepo(), games, players, teams, memory_rp.NewLeagueRepo(), memory_rp.NewStatAwardsRepo())
+	events := usecase.NewGameEventUC(memory_rp.NewGameEventRepo(), games, players, statPlayer)
+
+	gameID, err := games.CreateGame(ctx, &entity.Game{FirstTeam: home, SecondTeam: away, Date: "01.03.24",
+		Status: entity.GameStatusScheduled})
+	if err != nil {
+		t.Fatalf("CreateGame() error = %v", err)
+	}
+	ids := make(map[string]string)
+	for _, player := range []*entity.Player{{Name: "starter", Team: home}, {Name: "bench", Team: home}, {Name: "guest", Team: away}} {
+		if ids[player.Name], err = players.CreatePlayer(ctx, player); err != nil {
+			t.Fatalf("CreatePlayer() error = %v", err)
+		}
+	}
+	starter, bench, guest := ids["starter"], ids["bench"], ids["guest"]
+
+	_, err = events.AddEvents(ctx, gameID, []*entity.GameEvent{
+		{Period: 1, Clock: "10:00", Type: entity.EventShotMade, ShotType: entity.ShotTwoPoint, Team: home, Player: starter},
+		{Period: 1, Clock: "06:00", Type: entity.EventSubstitution, Team: home, Player: bench, Replaced: starter},
+		{Period: 1, Clock: "03:00", Type: entity.EventShotMade, ShotType: entity.ShotThreePoint, Team: away, Player: guest},
+		{Period: 2, Clock: "06:00", Type: entity.EventShotMade, ShotType: entity.ShotFreeThrow, Team: home, Player: bench},
+	})
+	if err != nil {
+		t.Fatalf("AddEvents() error = %v", err)
+	}
+
+	box, err := events.GetBoxScore(ctx, gameID)
+	if err != nil {
+		t.Fatalf("GetBoxScore() error = %v", err)
+	}
+
+	// стартовые игроки на площадке с начала матча, на скамейке - после замены и до конца второго периода
+	want := map[string]struct {
+		minutes   float64
+		plusMinus int
+	}{
+		starter: {minutes: 6, plusMinus: 2},
+		bench:   {minutes: 18, plusMinus: -2},
+		guest:   {minutes: 24, plusMinus: 0},
+	}
+	if len(box) != len(want) {
+		t.Fatalf("box score has %d rows, want %d", len(box), len(want))
+	}
+	for _, stat := range box {
+		if w := want[stat.PlayerID]; stat.Minutes != w.minutes || stat.PlusMinus != w.plusMinus {
+			t.Errorf("player %s minutes = %v, plus/minus = %d, want %v and %d",
+				stat.PlayerID, stat.Minutes, stat.PlusMinus, w.minutes, w.plusMinus)
+		}
+	}
+}
+
+// newTeams - хозяева и гости матча
+func newTeams(t *testing.T, teams *memory_rp.TeamRepo) (string, string) {
+	t.Helper()
+	home, err := teams.CreateTeam(context.Background(), &entity.Team{Name: "home"})
+	if err != nil {
+		t.Fatalf("CreateTeam() error = %v", err)
+	}
+	away, err := teams.CreateTeam(context.Background(), &entity.Team{Name: "away"})
+	if err != nil {
+		t.Fatalf("CreateTeam() error = %v", err)
+	}
+	return home, away
+}
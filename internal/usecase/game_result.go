@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// normalizeResult - проверяет результат матча и досчитывает итоговый счёт по периодам
+func normalizeResult(result entity.GameResult) (entity.GameResult, error) {
+	switch result.Status {
+	case entity.GameStatusScheduled, entity.GameStatusPostponed:
+		if len(result.Periods) != 0 || result.FirstTeamScore != 0 || result.SecondTeamScore != 0 {
+			return result, fmt.Errorf("%w: %s game can't have a score", apperrors.ErrInvalidGameResult, result.Status)
+		}
+		return result, nil
+	case entity.GameStatusLive, entity.GameStatusFinal:
+	default:
+		return result, fmt.Errorf("%w: unknown status %q", apperrors.ErrInvalidGameResult, result.Status)
+	}
+
+	if result.FirstTeamScore < 0 || result.SecondTeamScore < 0 {
+		return result, fmt.Errorf("%w: score can't be negative", apperrors.ErrInvalidGameResult)
+	}
+
+	periods := append([]entity.PeriodScore(nil), result.Periods...)
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].Period < periods[j].Period
+	})
+
+	var firstTeam, secondTeam int
+	for i, period := range periods {
+		if period.Period != i+1 {
+			return result, fmt.Errorf("%w: periods must go one by one starting from 1", apperrors.ErrInvalidGameResult)
+		}
+		if period.FirstTeam < 0 || period.SecondTeam < 0 {
+			return result, fmt.Errorf("%w: period %d score can't be negative", apperrors.ErrInvalidGameResult, period.Period)
+		}
+		// овертайм играется, только если предыдущий период закончился вничью
+		if period.Period > entity.RegulationPeriods && firstTeam != secondTeam {
+			return result, fmt.Errorf("%w: overtime %d without a tie after period %d",
+				apperrors.ErrInvalidGameResult, period.Period-entity.RegulationPeriods, period.Period-1)
+		}
+		firstTeam += period.FirstTeam
+		secondTeam += period.SecondTeam
+	}
+
+	if len(periods) != 0 {
+		if (result.FirstTeamScore != 0 || result.SecondTeamScore != 0) &&
+			(result.FirstTeamScore != firstTeam || result.SecondTeamScore != secondTeam) {
+			return result, fmt.Errorf("%w: final score %d:%d doesn't match periods sum %d:%d", apperrors.ErrInvalidGameResult,
+				result.FirstTeamScore, result.SecondTeamScore, firstTeam, secondTeam)
+		}
+		result.FirstTeamScore, result.SecondTeamScore = firstTeam, secondTeam
+	}
+	result.Periods = periods
+
+	if result.Status == entity.GameStatusFinal {
+		if len(periods) != 0 && len(periods) < entity.RegulationPeriods {
+			return result, fmt.Errorf("%w: final game must have at least %d periods", apperrors.ErrInvalidGameResult, entity.RegulationPeriods)
+		}
+		if result.FirstTeamScore == result.SecondTeamScore {
+			return result, fmt.Errorf("%w: final game can't end in a tie", apperrors.ErrInvalidGameResult)
+		}
+	}
+
+	return result, nil
+}
+
+// applyResult - переносит проверенный результат в матч и определяет победителя
+func applyResult(game *entity.Game, result entity.GameResult) {
+	game.Status = result.Status
+	game.FirstTeamScore = result.FirstTeamScore
+	game.SecondTeamScore = result.SecondTeamScore
+	game.Periods = result.Periods
+	game.Winner = ""
+
+	if result.Status != entity.GameStatusFinal {
+		return
+	}
+	if result.FirstTeamScore > result.SecondTeamScore {
+		game.Winner = game.FirstTeam
+	} else {
+		game.Winner = game.SecondTeam
+	}
+}
@@ -0,0 +1,78 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/internal/usecase/repo/memory_rp"
+)
+
+func TestUpdatePlayoffGame(t *testing.T) {
+	ctx := context.Background()
+	leagues, teams, games := memory_rp.NewLeagueRepo(), memory_rp.NewTeamRepo(), memory_rp.NewGameRepo()
+
+	var ids []string
+	for _, name := range []string{"A", "B", "C"} {
+		id, err := teams.CreateTeam(ctx, &entity.Team{Name: name})
+		if err != nil {
+			t.Fatalf("CreateTeam() error = %v", err)
+		}
+		ids = append(ids, id)
+	}
+	a, b, c := ids[0], ids[1], ids[2]
+	league, err := leagues.CreateLeague(ctx, &entity.League{Name: "first"})
+	if err != nil {
+		t.Fatalf("CreateLeague() error = %v", err)
+	}
+	otherLeague, err := leagues.CreateLeague(ctx, &entity.League{Name: "second"})
+	if err != nil {
+		t.Fatalf("CreateLeague() error = %v", err)
+	}
+
+	playoff := entity.Game{FirstTeam: a, SecondTeam: b, Date: "20.04.24", Type: entity.GameTypePlayoff, League: league,
+		Status: entity.GameStatusScheduled, Round: 1, Series: "R1S1"}
+	regular := entity.Game{FirstTeam: a, SecondTeam: b, Date: "20.03.24", Type: entity.GameTypeRegular, League: league,
+		Status: entity.GameStatusScheduled}
+
+	tests := []struct {
+		name    string
+		stored  entity.Game
+		change  func(g *entity.Game)
+		wantErr error
+	}{
+		{name: "playoff date", stored: playoff, change: func(g *entity.Game) { g.Date = "21.04.24" }},
+		{name: "playoff team", stored: playoff, change: func(g *entity.Game) { g.SecondTeam = c }, wantErr: apperrors.ErrPlayoffGameChange},
+		{name: "playoff type", stored: playoff, change: func(g *entity.Game) { g.Type = entity.GameTypeRegular }, wantErr: apperrors.ErrPlayoffGameChange},
+		{name: "playoff league", stored: playoff, change: func(g *entity.Game) { g.League = otherLeague }, wantErr: apperrors.ErrPlayoffGameChange},
+		{name: "regular to playoff", stored: regular, change: func(g *entity.Game) { g.Type = entity.GameTypePlayoff }, wantErr: apperrors.ErrPlayoffGameChange},
+		{name: "regular team", stored: regular, change: func(g *entity.Game) { g.SecondTeam = c }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := tt.stored
+			gameID, err := games.CreateGame(ctx, &stored)
+			if err != nil {
+				t.Fatalf("CreateGame() error = %v", err)
+			}
+
+			update := tt.stored
+			update.Round, update.Series = 0, ""
+			tt.change(&update)
+			updated, err := usecase.NewGameUC(games, teams, leagues, nil).UpdateGame(ctx, gameID, &update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateGame() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if updated.Round != tt.stored.Round || updated.Series != tt.stored.Series {
+				t.Errorf("bracket place = %d %q, want %d %q", updated.Round, updated.Series, tt.stored.Round, tt.stored.Series)
+			}
+		})
+	}
+}
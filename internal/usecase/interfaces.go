@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/romeros69/basket/internal/entity"
 )
@@ -12,7 +13,7 @@ type (
 		CreatePlayer(ctx context.Context, player *entity.Player) (string, error)
 		UpdatePlayer(ctx context.Context, playerID string, player *entity.Player) (*entity.Player, error)
 		GetPlayer(ctx context.Context, playerID string) (*entity.Player, error)
-		DeletePlayer(ctx context.Context, playerID string) error
+		DeletePlayer(ctx context.Context, playerID string) (*entity.DeletionJob, error)
 		GetPlayerList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Player, error)
 	}
 
@@ -23,6 +24,32 @@ type (
 		GetPlayer(ctx context.Context, playerID string) (*entity.Player, error)
 		DeletePlayer(ctx context.Context, playerID string) error
 		GetPlayerList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Player, error)
+		GetPlayersByTeam(ctx context.Context, teamID string) ([]*entity.Player, error)
+		TombstonePlayer(ctx context.Context, playerID string) error
+		// GetPlayersByIDs - игроки по id одним запросом; неизвестные и некорректные id пропускаются
+		GetPlayersByIDs(ctx context.Context, playerIDs []string) (map[string]*entity.Player, error)
+		StreamPlayerIDs(ctx context.Context, tombstoned bool, yield func(playerID string) error) error
+	}
+
+	// Team - use case
+	Team interface {
+		CreateTeam(ctx context.Context, team *entity.Team) (string, error)
+		UpdateTeam(ctx context.Context, teamID string, team *entity.Team) (*entity.Team, error)
+		GetTeam(ctx context.Context, teamID string) (*entity.Team, error)
+		DeleteTeam(ctx context.Context, teamID string) error
+		GetTeamList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Team, error)
+		GetRoster(ctx context.Context, teamID string) ([]*entity.Player, error)
+		GetSchedule(ctx context.Context, teamID string) ([]*entity.Game, error)
+	}
+
+	// TeamRp - mongodb
+	TeamRp interface {
+		CreateTeam(ctx context.Context, team *entity.Team) (string, error)
+		UpdateTeam(ctx context.Context, teamID string, team *entity.Team) (*entity.Team, error)
+		GetTeam(ctx context.Context, teamID string) (*entity.Team, error)
+		DeleteTeam(ctx context.Context, teamID string) error
+		GetTeamList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Team, error)
+		GetTeamsByLeague(ctx context.Context, leagueID string) ([]*entity.Team, error)
 	}
 
 	// Award - use case
@@ -32,6 +59,7 @@ type (
 		GetAward(ctx context.Context, awardID string) (*entity.Award, error)
 		DeleteAward(ctx context.Context, awardID string) error
 		GetAwardList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Award, error)
+		GetAwardGrants(ctx context.Context, pageSize, pageNumber int64) ([]*entity.AwardGrants, error)
 	}
 
 	// AwardRp - mongodb
@@ -48,17 +76,24 @@ type (
 		CreateGame(ctx context.Context, game *entity.Game) (string, error)
 		UpdateGame(ctx context.Context, gameID string, game *entity.Game) (*entity.Game, error)
 		GetGame(ctx context.Context, gameID string) (*entity.Game, error)
-		DeleteGame(ctx context.Context, gameID string) error
+		DeleteGame(ctx context.Context, gameID string) (*entity.DeletionJob, error)
 		GetGameList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Game, error)
+		RecordResult(ctx context.Context, gameID string, result *entity.GameResult) (*entity.Game, error)
+		CorrectResult(ctx context.Context, gameID string, result *entity.GameResult) (*entity.Game, error)
 	}
 
 	// GameRp - mongodb
 	GameRp interface {
 		CreateGame(ctx context.Context, game *entity.Game) (string, error)
+		CreateGames(ctx context.Context, games []*entity.Game) ([]string, error)
 		UpdateGame(ctx context.Context, gameID string, game *entity.Game) (*entity.Game, error)
 		GetGame(ctx context.Context, gameID string) (*entity.Game, error)
 		DeleteGame(ctx context.Context, gameID string) error
 		GetGameList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Game, error)
+		GetGamesByTeam(ctx context.Context, teamID string) ([]*entity.Game, error)
+		GetGamesByLeague(ctx context.Context, leagueID string) ([]*entity.Game, error)
+		TombstoneGame(ctx context.Context, gameID string) error
+		StreamGameIDs(ctx context.Context, tombstoned bool, yield func(gameID string) error) error
 	}
 
 	// League - use case
@@ -68,6 +103,88 @@ type (
 		GetLeague(ctx context.Context, leagueID string) (*entity.League, error)
 		DeleteLeague(ctx context.Context, leagueID string) error
 		GetLeagueList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.League, error)
+		GetStandings(ctx context.Context, leagueID, conference string, tieBreakers []string) ([]entity.Standing, error)
+	}
+
+	// Scheduler - use case
+	Scheduler interface {
+		GenerateSchedule(ctx context.Context, leagueID string, req *entity.ScheduleRequest) (*entity.Schedule, error)
+	}
+
+	// Playoff - use case
+	Playoff interface {
+		CreatePlayoffs(ctx context.Context, leagueID string, req *entity.PlayoffRequest) (*entity.PlayoffBracket, error)
+		GetPlayoffs(ctx context.Context, leagueID string) (*entity.PlayoffBracket, error)
+		SyncPlayoffs(ctx context.Context, leagueID string) (*entity.PlayoffBracket, error)
+	}
+
+	// PlayoffRp - mongodb
+	PlayoffRp interface {
+		CreateBracket(ctx context.Context, bracket *entity.PlayoffBracket) error
+		GetBracket(ctx context.Context, leagueID string) (*entity.PlayoffBracket, error)
+		UpdateBracket(ctx context.Context, bracket *entity.PlayoffBracket) error
+	}
+
+	// GameResultListener - вызывается после записи или исправления результата матча
+	GameResultListener interface {
+		GameResultSaved(ctx context.Context, gameID string, game *entity.Game) error
+	}
+
+	// GameEvent - use case
+	GameEvent interface {
+		AddEvents(ctx context.Context, gameID string, events []*entity.GameEvent) ([]*entity.GameEvent, error)
+		GetEvents(ctx context.Context, gameID string) ([]*entity.GameEvent, error)
+		GetBoxScore(ctx context.Context, gameID string) ([]entity.PlayerStat, error)
+	}
+
+	// GameEventRp - mongodb
+	GameEventRp interface {
+		AddEvents(ctx context.Context, events []*entity.GameEvent) error
+		GetEventsByGame(ctx context.Context, gameID string) ([]*entity.GameEvent, error)
+		DeleteEventsByGame(ctx context.Context, gameID string) error
+		HasEventsByPlayer(ctx context.Context, playerID string) (bool, error)
+		DeleteEventsByPlayer(ctx context.Context, playerID string) error
+	}
+
+	// Deletion - use case
+	Deletion interface {
+		DeletePlayer(ctx context.Context, playerID string) (*entity.DeletionJob, error)
+		DeleteGame(ctx context.Context, gameID string) (*entity.DeletionJob, error)
+		GetJob(ctx context.Context, jobID string) (*entity.DeletionJob, error)
+		RetryJob(ctx context.Context, jobID string) (*entity.DeletionJob, error)
+		RetryFailedJobs(ctx context.Context) error
+	}
+
+	// Consistency - use case
+	Consistency interface {
+		Check(ctx context.Context, req entity.ConsistencyRequest) (*entity.ConsistencyReport, error)
+	}
+
+	// DeletionJobRp - mongodb
+	DeletionJobRp interface {
+		CreateJob(ctx context.Context, job *entity.DeletionJob) (string, error)
+		UpdateJob(ctx context.Context, job *entity.DeletionJob) error
+		GetJob(ctx context.Context, jobID string) (*entity.DeletionJob, error)
+		GetJobsByStatus(ctx context.Context, status string) ([]*entity.DeletionJob, error)
+		// ClaimJob - атомарно захватывает незавершённую задачу до until, если её никто не держит на момент now;
+		// иначе ErrDeletionJobRunning
+		ClaimJob(ctx context.Context, jobID string, now, until time.Time) (*entity.DeletionJob, error)
+	}
+
+	// OutboxRelay - use case
+	OutboxRelay interface {
+		RelayPending(ctx context.Context) (int, error)
+	}
+
+	// OutboxRp - mongodb
+	OutboxRp interface {
+		GetPendingEvents(ctx context.Context, limit int64) ([]*entity.OutboxEvent, error)
+		UpdateEventStatus(ctx context.Context, event *entity.OutboxEvent) error
+	}
+
+	// OutboxProjection - neo4j, ClickHouse
+	OutboxProjection interface {
+		ApplyEvent(ctx context.Context, event *entity.OutboxEvent) error
 	}
 
 	// LeagueRp - mongodb
@@ -79,6 +196,32 @@ type (
 		GetLeagueList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.League, error)
 	}
 
+	// Profile - use case
+	Profile interface {
+		GetPlayerProfile(ctx context.Context, playerID string) (*entity.PlayerProfile, error)
+	}
+
+	// Career - use case
+	Career interface {
+		GetTeammates(ctx context.Context, playerID string) ([]entity.Teammate, error)
+		GetSeparation(ctx context.Context, playerID, otherID string) (*entity.Separation, error)
+		GetCommonOpponents(ctx context.Context, playerID, otherID string) ([]entity.CommonOpponent, error)
+	}
+
+	// CareerRp - neo4j
+	CareerRp interface {
+		SetPlayerTeam(ctx context.Context, membership entity.TeamMembership) error
+		// RecordAppearances - связи игроков с матчами одной транзакцией
+		RecordAppearances(ctx context.Context, appearances []entity.Appearance) error
+		GetMemberships(ctx context.Context, playerID string) ([]entity.TeamMembership, error)
+		// ReplaceMemberships - заменяет все членства игрока в командах на memberships
+		ReplaceMemberships(ctx context.Context, playerID string, memberships []entity.TeamMembership) error
+		GetTeammates(ctx context.Context, playerID string) ([]entity.Teammate, error)
+		// GetSeparation - nil, если игроки не связаны цепочкой не длиннее maxDegrees
+		GetSeparation(ctx context.Context, playerID, otherID string, maxDegrees int) (*entity.Separation, error)
+		GetCommonOpponents(ctx context.Context, playerID, otherID string) ([]entity.CommonOpponent, error)
+	}
+
 	// StatAwards - use case
 	StatAwards interface {
 		CreateRecord(context.Context, entity.RewardStat) error
@@ -86,6 +229,10 @@ type (
 		ViewPlayersAndRewardsInMatch(context.Context, string) ([]entity.RewardStat, error)
 		ViewRewardsForPlayer(context.Context, string) ([]entity.RewardStat, error)
 		ViewWhoGotSpecificReward(context.Context, string) ([]entity.RewardStat, error)
+		GetCoAwardedPlayers(ctx context.Context, playerID string, limit int) ([]entity.CoAwardedPlayer, error)
+		GetMostDecoratedPlayers(ctx context.Context, tournamentID string, limit int) ([]entity.DecoratedPlayer, error)
+		GetAwardCoOccurrence(ctx context.Context, awardID string, limit int) ([]entity.AwardCoOccurrence, error)
+		GetDegreeCentrality(ctx context.Context, limit int) ([]entity.PlayerCentrality, error)
 	}
 
 	// StatAwardsRp - neo4j
@@ -95,21 +242,55 @@ type (
 		ViewPlayersAndRewardsInMatch(context.Context, string) ([]entity.RewardStat, error)
 		ViewRewardsForPlayer(context.Context, string) ([]entity.RewardStat, error)
 		ViewWhoGotSpecificReward(context.Context, string) ([]entity.RewardStat, error)
+		// ViewWhoGotRewards - вручения нескольких наград одним запросом, в записях заполнен Reward
+		ViewWhoGotRewards(ctx context.Context, rewardIDs []string) ([]entity.RewardStat, error)
+		// RetireReward - атомарно проверяет, что награду не вручали, и помечает её узел удалённым: после этого
+		// CreateRecord с этой наградой возвращает ErrAwardNotFound. Если вручения есть, узел не меняется и granted = true
+		RetireReward(ctx context.Context, rewardID string) (granted bool, err error)
+		// RestoreReward - снимает отметку RetireReward, если удалить награду из каталога не удалось
+		RestoreReward(ctx context.Context, rewardID string) error
+		GetCoAwardedPlayers(ctx context.Context, playerID string, limit int) ([]entity.CoAwardedPlayer, error)
+		GetMostDecoratedPlayers(ctx context.Context, tournamentID string, limit int) ([]entity.DecoratedPlayer, error)
+		GetAwardCoOccurrence(ctx context.Context, awardID string, limit int) ([]entity.AwardCoOccurrence, error)
+		GetDegreeCentrality(ctx context.Context, limit int) ([]entity.PlayerCentrality, error)
+		HasRecords(ctx context.Context, entityKind, id string) (bool, error)
+		DeleteNode(ctx context.Context, entityKind, id string) error
+		TombstoneNode(ctx context.Context, entityKind, id string) error
+		StreamNodeIDs(ctx context.Context, entityKind string, yield func(id string, deleted bool) error) error
 	}
 
 	// StatPlayer - use case
 	StatPlayer interface {
 		InsertPlayerStat(context.Context, entity.PlayerStat) error
+		// ReplacePlayerStat - заменяет все строки игрока за матч одной строкой stat
+		ReplacePlayerStat(context.Context, entity.PlayerStat) error
+		InsertPlayerStats(ctx context.Context, stats []entity.PlayerStat) (*entity.StatBatchResult, error)
 		GetPlayerStatsByIDAndMatch(ctx context.Context, playerID, matchID string) ([]entity.PlayerStat, error)
 		GetPlayersWithAvgGoalsGreaterThanByMatch(ctx context.Context, minAvgGoals float64, matchID string) ([]entity.PlayerStat, error)
 		GetPlayersWithTotalAvgStatsGreaterThanByMatch(ctx context.Context, minTotalAvg float64, matchID string) ([]entity.PlayerStat, error)
+		GetPlayerMetrics(ctx context.Context, playerID string, scope entity.StatScope) (*entity.PlayerMetrics, error)
+		GetPlayerSeasonStats(ctx context.Context, playerID, season string) ([]entity.PlayerSeasonStat, error)
+		GetTeamSeasonStats(ctx context.Context, teamID, season string) ([]entity.TeamSeasonStat, error)
+		GetLeaders(ctx context.Context, req entity.LeadersRequest) ([]entity.Leader, error)
 	}
 
 	// StatPlayerRp - ClickHouse
 	StatPlayerRp interface {
 		InsertPlayerStat(context.Context, entity.PlayerStat) error
+		// ReplacePlayerStat - заменяет все строки игрока за матч одной строкой stat
+		ReplacePlayerStat(context.Context, entity.PlayerStat) error
+		InsertPlayerStats(ctx context.Context, stats []entity.PlayerStat) []error
 		GetPlayerStatsByIDAndMatch(ctx context.Context, playerID, matchID string) ([]entity.PlayerStat, error)
 		GetPlayersWithAvgGoalsGreaterThanByMatch(ctx context.Context, minAvgGoals float64, matchID string) ([]entity.PlayerStat, error)
 		GetPlayersWithTotalAvgStatsGreaterThanByMatch(ctx context.Context, minTotalAvg float64, matchID string) ([]entity.PlayerStat, error)
+		GetPlayerMetrics(ctx context.Context, playerID string, scope entity.StatScope) (*entity.PlayerMetrics, error)
+		GetPlayerSeasonStats(ctx context.Context, playerID, season string) ([]entity.PlayerSeasonStat, error)
+		GetTeamSeasonStats(ctx context.Context, teamID, season string) ([]entity.TeamSeasonStat, error)
+		GetLeaders(ctx context.Context, req entity.LeadersRequest) ([]entity.Leader, error)
+		HasStats(ctx context.Context, entityKind, id string) (bool, error)
+		DeleteStats(ctx context.Context, entityKind, id string) error
+		StreamStatIDs(ctx context.Context, entityKind string, yield func(id string) error) error
+		// StreamAppearances - по одной связи игрока с матчем на каждую пару игрок-матч в статистике, без соперника
+		StreamAppearances(ctx context.Context, yield func(appearance entity.Appearance) error) error
 	}
 )
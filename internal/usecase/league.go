@@ -2,26 +2,37 @@ package usecase
 
 import (
 	"context"
+
 	"github.com/romeros69/basket/internal/entity"
 )
 
 type LeagueUC struct {
 	leagueRp LeagueRp
+	teamRp   TeamRp
+	gameRp   GameRp
 }
 
-func NewLeagueUC(leagueRp LeagueRp) *LeagueUC {
+func NewLeagueUC(leagueRp LeagueRp, teamRp TeamRp, gameRp GameRp) *LeagueUC {
 	return &LeagueUC{
 		leagueRp: leagueRp,
+		teamRp:   teamRp,
+		gameRp:   gameRp,
 	}
 }
 
 var _ League = (*LeagueUC)(nil)
 
 func (l *LeagueUC) CreateLeague(ctx context.Context, league *entity.League) (string, error) {
+	if err := validateTieBreakers(league.TieBreakers); err != nil {
+		return "", err
+	}
 	return l.leagueRp.CreateLeague(ctx, league)
 }
 
 func (l *LeagueUC) UpdateLeague(ctx context.Context, leagueID string, league *entity.League) (*entity.League, error) {
+	if err := validateTieBreakers(league.TieBreakers); err != nil {
+		return nil, err
+	}
 	return l.leagueRp.UpdateLeague(ctx, leagueID, league)
 }
 
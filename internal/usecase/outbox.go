@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// OutboxRelaySettings - размер пачки событий и число попыток до парковки события
+type OutboxRelaySettings struct {
+	BatchSize   int64
+	MaxAttempts int
+}
+
+// OutboxRelayUC - переносит события каталога из outbox в проекции (узлы графа наград, измерения ClickHouse)
+type OutboxRelayUC struct {
+	outboxRp    OutboxRp
+	projections []OutboxProjection
+	settings    OutboxRelaySettings
+}
+
+func NewOutboxRelayUC(outboxRp OutboxRp, projections []OutboxProjection, settings OutboxRelaySettings) *OutboxRelayUC {
+	return &OutboxRelayUC{
+		outboxRp:    outboxRp,
+		projections: projections,
+		settings:    settings,
+	}
+}
+
+var _ OutboxRelay = (*OutboxRelayUC)(nil)
+
+// RelayPending - применяет необработанные события по порядку и возвращает число применённых.
+// Доставка не реже одного раза: событие отмечается обработанным только после всех проекций, а проекции
+// игнорируют версии не новее уже применённой. На ошибке relay останавливается, чтобы не применить
+// более позднее событие той же сущности раньше; после MaxAttempts попыток событие паркуется
+func (o *OutboxRelayUC) RelayPending(ctx context.Context) (int, error) {
+	events, err := o.outboxRp.GetPendingEvents(ctx, o.settings.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, event := range events {
+		if err = o.apply(ctx, event); err == nil {
+			processedAt := time.Now().UTC()
+			event.Status, event.LastError, event.ProcessedAt = entity.OutboxStatusProcessed, "", &processedAt
+			if err = o.outboxRp.UpdateEventStatus(ctx, event); err != nil {
+				return applied, err
+			}
+			applied++
+			continue
+		}
+
+		event.Attempts++
+		event.LastError = err.Error()
+		parked := event.Attempts >= o.settings.MaxAttempts
+		if parked {
+			event.Status = entity.OutboxStatusParked
+		}
+		if updErr := o.outboxRp.UpdateEventStatus(ctx, event); updErr != nil {
+			return applied, updErr
+		}
+		if !parked {
+			return applied, fmt.Errorf("outbox event %s (%s %s): %w", event.ID, event.Entity, event.EntityID, err)
+		}
+	}
+
+	return applied, nil
+}
+
+func (o *OutboxRelayUC) apply(ctx context.Context, event *entity.OutboxEvent) error {
+	for _, projection := range o.projections {
+		if err := projection.ApplyEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
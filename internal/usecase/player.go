@@ -2,38 +2,84 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/romeros69/basket/internal/apperrors"
 	"github.com/romeros69/basket/internal/entity"
 )
 
 type PlayerUC struct {
 	playerRp PlayerRp
+	teamRp   TeamRp
+	careerRp CareerRp
+	deletion Deletion
 }
 
-func NewPlayerUC(playerRp PlayerRp) *PlayerUC {
+func NewPlayerUC(playerRp PlayerRp, teamRp TeamRp, careerRp CareerRp, deletion Deletion) *PlayerUC {
 	return &PlayerUC{
 		playerRp: playerRp,
+		teamRp:   teamRp,
+		careerRp: careerRp,
+		deletion: deletion,
 	}
 }
 
 var _ Player = (*PlayerUC)(nil)
 
 func (p *PlayerUC) CreatePlayer(ctx context.Context, player *entity.Player) (string, error) {
-	return p.playerRp.CreatePlayer(ctx, player)
+	if err := p.validate(ctx, player); err != nil {
+		return "", err
+	}
+	playerID, err := p.playerRp.CreatePlayer(ctx, player)
+	if err != nil {
+		return "", err
+	}
+
+	if err = recordMembership(ctx, p.careerRp, playerID, player); err != nil {
+		return playerID, fmt.Errorf("%w: player %s is created, but its team is not recorded: %w",
+			apperrors.ErrCareerGraphNotUpdated, playerID, err)
+	}
+
+	return playerID, nil
 }
 
+// UpdatePlayer - игрок в каталоге уже изменён, когда пишется граф карьеры, поэтому при ошибке графа
+// возвращается и изменённый игрок, и ошибка ErrCareerGraphNotUpdated
 func (p *PlayerUC) UpdatePlayer(ctx context.Context, playerID string, player *entity.Player) (*entity.Player, error) {
-	return p.playerRp.UpdatePlayer(ctx, playerID, player)
+	if err := p.validate(ctx, player); err != nil {
+		return nil, err
+	}
+	updated, err := p.playerRp.UpdatePlayer(ctx, playerID, player)
+	if err != nil {
+		return nil, err
+	}
+
+	// смена команды закрывает прежнее членство; повтор запроса с той же командой ничего не меняет
+	if err = recordMembership(ctx, p.careerRp, playerID, player); err != nil {
+		return updated, fmt.Errorf("%w: player %s is updated, but its team is not recorded: %w",
+			apperrors.ErrCareerGraphNotUpdated, playerID, err)
+	}
+
+	return updated, nil
 }
 
 func (p *PlayerUC) GetPlayer(ctx context.Context, playerID string) (*entity.Player, error) {
 	return p.playerRp.GetPlayer(ctx, playerID)
 }
 
-func (p *PlayerUC) DeletePlayer(ctx context.Context, playerID string) error {
-	return p.playerRp.DeletePlayer(ctx, playerID)
+// DeletePlayer - удаление по политике для игроков, см. DeletionUC
+func (p *PlayerUC) DeletePlayer(ctx context.Context, playerID string) (*entity.DeletionJob, error) {
+	return p.deletion.DeletePlayer(ctx, playerID)
 }
 
 func (p *PlayerUC) GetPlayerList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Player, error) {
 	return p.playerRp.GetPlayerList(ctx, pageSize, pageNumber)
 }
+
+// validate - игрок без команды (свободный агент) допустим, иначе команда должна существовать
+func (p *PlayerUC) validate(ctx context.Context, player *entity.Player) error {
+	if player.Team == "" {
+		return nil
+	}
+	return checkTeamReference(ctx, p.teamRp, "team", player.Team)
+}
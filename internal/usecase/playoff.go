@@ -0,0 +1,320 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+const defaultDaysBetweenPlayoffGames = 2
+
+type PlayoffUC struct {
+	// mu - сетка пересчитывается последовательно, чтобы два завершившихся матча не создали дубли следующих игр
+	mu        sync.Mutex
+	playoffRp PlayoffRp
+	gameRp    GameRp
+	league    League
+}
+
+func NewPlayoffUC(playoffRp PlayoffRp, gameRp GameRp, league League) *PlayoffUC {
+	return &PlayoffUC{
+		playoffRp: playoffRp,
+		gameRp:    gameRp,
+		league:    league,
+	}
+}
+
+var (
+	_ Playoff            = (*PlayoffUC)(nil)
+	_ GameResultListener = (*PlayoffUC)(nil)
+)
+
+// CreatePlayoffs - посев лучших команд регулярного сезона по турнирной таблице и создание первых матчей серий
+func (p *PlayoffUC) CreatePlayoffs(ctx context.Context, leagueID string, req *entity.PlayoffRequest) (*entity.PlayoffBracket, error) {
+	if req.Teams < 2 || req.Teams&(req.Teams-1) != 0 {
+		return nil, fmt.Errorf("%w: number of teams must be a power of two, got %d", apperrors.ErrInvalidPlayoffs, req.Teams)
+	}
+	if req.BestOf < 1 || req.BestOf%2 == 0 {
+		return nil, fmt.Errorf("%w: best_of must be a positive odd number, got %d", apperrors.ErrInvalidPlayoffs, req.BestOf)
+	}
+	if _, err := time.Parse(entity.GameDateLayout, req.StartDate); err != nil {
+		return nil, fmt.Errorf("%w: start_date %q must be in %s format", apperrors.ErrInvalidPlayoffs, req.StartDate, entity.GameDateLayout)
+	}
+	days := req.DaysBetweenGames
+	if days == 0 {
+		days = defaultDaysBetweenPlayoffGames
+	}
+	if days < 0 {
+		return nil, fmt.Errorf("%w: days_between_games can't be negative", apperrors.ErrInvalidPlayoffs)
+	}
+
+	standings, err := p.league.GetStandings(ctx, leagueID, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(standings) < req.Teams {
+		return nil, fmt.Errorf("%w: league has only %d teams, %d requested", apperrors.ErrInvalidPlayoffs, len(standings), req.Teams)
+	}
+
+	bracket := &entity.PlayoffBracket{
+		League:           leagueID,
+		BestOf:           req.BestOf,
+		StartDate:        req.StartDate,
+		DaysBetweenGames: days,
+	}
+	for i := 0; i < req.Teams; i++ {
+		bracket.Seeds = append(bracket.Seeds, entity.PlayoffSeed{
+			Seed:     i + 1,
+			Team:     standings[i].Team,
+			TeamName: standings[i].TeamName,
+		})
+	}
+
+	order := seedOrder(req.Teams)
+	for round, series := 1, req.Teams/2; series >= 1; round, series = round+1, series/2 {
+		r := entity.PlayoffRound{Round: round}
+		for i := 0; i < series; i++ {
+			s := entity.PlayoffSeries{
+				ID:    fmt.Sprintf("R%dS%d", round, i+1),
+				Round: round,
+			}
+			if round == 1 {
+				top, bottom := bracket.Seeds[order[2*i]-1], bracket.Seeds[order[2*i+1]-1]
+				s.Top, s.Bottom = &top, &bottom
+			}
+			r.Series = append(r.Series, s)
+		}
+		bracket.Rounds = append(bracket.Rounds, r)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err = p.playoffRp.CreateBracket(ctx, bracket); err != nil {
+		return nil, err
+	}
+	if err = p.advance(ctx, bracket); err != nil {
+		return nil, err
+	}
+
+	return bracket, nil
+}
+
+func (p *PlayoffUC) GetPlayoffs(ctx context.Context, leagueID string) (*entity.PlayoffBracket, error) {
+	return p.playoffRp.GetBracket(ctx, leagueID)
+}
+
+// SyncPlayoffs - пересчёт сетки по матчам, например после сбоя при автоматическом продвижении
+func (p *PlayoffUC) SyncPlayoffs(ctx context.Context, leagueID string) (*entity.PlayoffBracket, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	bracket, err := p.playoffRp.GetBracket(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.advance(ctx, bracket); err != nil {
+		return nil, err
+	}
+
+	return bracket, nil
+}
+
+// GameResultSaved - продвигает сетку, когда матч плей-офф становится final
+func (p *PlayoffUC) GameResultSaved(ctx context.Context, _ string, game *entity.Game) error {
+	if game.Type != entity.GameTypePlayoff || game.Status != entity.GameStatusFinal || game.League == "" {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	bracket, err := p.playoffRp.GetBracket(ctx, game.League)
+	if errors.Is(err, apperrors.ErrPlayoffsNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return p.advance(ctx, bracket)
+}
+
+// advance - подсчитывает победы в сериях, переводит победителей в следующий раунд
+// и создаёт очередной матч каждой незавершённой серии, у которой нет несыгранного матча
+func (p *PlayoffUC) advance(ctx context.Context, bracket *entity.PlayoffBracket) error {
+	lastDates := make(map[string]time.Time)
+
+	for r := range bracket.Rounds {
+		for i := range bracket.Rounds[r].Series {
+			s := &bracket.Rounds[r].Series[i]
+			if s.Top == nil || s.Bottom == nil {
+				continue
+			}
+
+			pending, err := p.tally(ctx, s, lastDates)
+			if err != nil {
+				return err
+			}
+
+			needed := bracket.WinsNeeded()
+			switch {
+			case s.TopWins >= needed:
+				s.Winner = s.Top.Team
+			case s.BottomWins >= needed:
+				s.Winner = s.Bottom.Team
+			}
+
+			if s.Winner != "" {
+				winner := *s.Top
+				if s.Winner == s.Bottom.Team {
+					winner = *s.Bottom
+				}
+				if r+1 == len(bracket.Rounds) {
+					bracket.Champion = winner.Team
+					continue
+				}
+				next := &bracket.Rounds[r+1].Series[i/2]
+				if i%2 == 0 && next.Top == nil {
+					next.Top = &winner
+				} else if i%2 == 1 && next.Bottom == nil {
+					next.Bottom = &winner
+				}
+				continue
+			}
+
+			if !pending {
+				if err = p.createSeriesGame(ctx, bracket, s, r, lastDates); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return p.playoffRp.UpdateBracket(ctx, bracket)
+}
+
+// tally - победы команд в серии по завершённым матчам; возвращает true, если есть несыгранный матч.
+// Удалённые матчи выпадают из серии: их победы не считаются, а вместо несыгранного назначается следующий
+func (p *PlayoffUC) tally(ctx context.Context, s *entity.PlayoffSeries, lastDates map[string]time.Time) (bool, error) {
+	s.TopWins, s.BottomWins = 0, 0
+	pending := false
+
+	games := make([]string, 0, len(s.Games))
+	for _, gameID := range s.Games {
+		game, err := p.gameRp.GetGame(ctx, gameID)
+		if errors.Is(err, apperrors.ErrGameNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("playoff series %s game %s: %w", s.ID, gameID, err)
+		}
+		games = append(games, gameID)
+
+		if date, err := game.ParseDate(); err == nil && date.After(lastDates[s.ID]) {
+			lastDates[s.ID] = date
+		}
+
+		if game.Status != entity.GameStatusFinal {
+			pending = true
+			continue
+		}
+		switch game.Winner {
+		case s.Top.Team:
+			s.TopWins++
+		case s.Bottom.Team:
+			s.BottomWins++
+		}
+	}
+	s.Games = games
+
+	return pending, nil
+}
+
+// createSeriesGame - следующий матч серии; первый матч раунда назначается после последнего матча серий-предшественниц
+func (p *PlayoffUC) createSeriesGame(ctx context.Context, bracket *entity.PlayoffBracket, s *entity.PlayoffSeries, round int, lastDates map[string]time.Time) error {
+	date, err := time.Parse(entity.GameDateLayout, bracket.StartDate)
+	if err != nil {
+		return fmt.Errorf("playoff start date: %w", err)
+	}
+
+	var previous time.Time
+	if len(s.Games) != 0 {
+		previous = lastDates[s.ID]
+	} else if round > 0 {
+		index := seriesIndex(bracket.Rounds[round].Series, s.ID)
+		for _, feeder := range bracket.Rounds[round-1].Series[2*index : 2*index+2] {
+			if lastDates[feeder.ID].After(previous) {
+				previous = lastDates[feeder.ID]
+			}
+		}
+	}
+	if !previous.IsZero() && !previous.AddDate(0, 0, bracket.DaysBetweenGames).Before(date) {
+		date = previous.AddDate(0, 0, bracket.DaysBetweenGames)
+	}
+
+	high, low := s.Top, s.Bottom
+	if low.Seed < high.Seed {
+		high, low = low, high
+	}
+	number := len(s.Games) + 1
+	home, away := high.Team, low.Team
+	if !highSeedHosts(number, bracket.BestOf) {
+		home, away = away, home
+	}
+
+	game := &entity.Game{
+		FirstTeam:  home,
+		SecondTeam: away,
+		Date:       date.Format(entity.GameDateLayout),
+		Type:       entity.GameTypePlayoff,
+		League:     bracket.League,
+		Status:     entity.GameStatusScheduled,
+		Round:      s.Round,
+		Series:     s.ID,
+	}
+	gameID, err := p.gameRp.CreateGame(ctx, game)
+	if err != nil {
+		return fmt.Errorf("playoff series %s game %d: %w", s.ID, number, err)
+	}
+	s.Games = append(s.Games, gameID)
+	lastDates[s.ID] = date
+
+	return nil
+}
+
+// seedOrder - порядок посева в сетке, при котором сильнейшие встречаются как можно позже: 1,8,4,5,2,7,3,6
+func seedOrder(teams int) []int {
+	order := []int{1}
+	for len(order) < teams {
+		sum := 2*len(order) + 1
+		next := make([]int, 0, 2*len(order))
+		for _, seed := range order {
+			next = append(next, seed, sum-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// highSeedHosts - принимает ли матч номер number команда с преимуществом площадки (форматы 2-2-1-1-1 и 1-1-1)
+func highSeedHosts(number, bestOf int) bool {
+	if bestOf >= 5 && number <= 4 {
+		return number <= 2
+	}
+	return number%2 == 1
+}
+
+func seriesIndex(series []entity.PlayoffSeries, id string) int {
+	for i := range series {
+		if series[i].ID == id {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,243 @@
+package usecase_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/internal/usecase/repo/memory_rp"
+)
+
+// playoffFixture - лига, в которой команды заняли места в порядке создания
+type playoffFixture struct {
+	playoffs *usecase.PlayoffUC
+	games    *memory_rp.GameRepo
+	league   string
+	teams    []string
+}
+
+func newPlayoffFixture(t *testing.T, teamCount int) *playoffFixture {
+	t.Helper()
+	ctx := context.Background()
+	leagues, teams, games := memory_rp.NewLeagueRepo(), memory_rp.NewTeamRepo(), memory_rp.NewGameRepo()
+
+	leagueID, err := leagues.CreateLeague(ctx, &entity.League{Name: "test"})
+	if err != nil {
+		t.Fatalf("CreateLeague() error = %v", err)
+	}
+	f := &playoffFixture{
+		playoffs: usecase.NewPlayoffUC(memory_rp.NewPlayoffRepo(), games, usecase.NewLeagueUC(leagues, teams, games)),
+		games:    games,
+		league:   leagueID,
+	}
+	for i := 0; i < teamCount; i++ {
+		teamID, err := teams.CreateTeam(ctx, &entity.Team{Name: string(rune('A' + i)), League: leagueID})
+		if err != nil {
+			t.Fatalf("CreateTeam() error = %v", err)
+		}
+		f.teams = append(f.teams, teamID)
+	}
+
+	// каждая команда обыгрывает все следующие, поэтому место в таблице совпадает с порядком создания
+	for i := range f.teams {
+		for j := i + 1; j < len(f.teams); j++ {
+			_, err := games.CreateGame(ctx, &entity.Game{
+				FirstTeam:       f.teams[j],
+				SecondTeam:      f.teams[i],
+				Date:            "01.03.24",
+				Type:            entity.GameTypeRegular,
+				League:          leagueID,
+				Status:          entity.GameStatusFinal,
+				FirstTeamScore:  90,
+				SecondTeamScore: 100,
+				Winner:          f.teams[i],
+			})
+			if err != nil {
+				t.Fatalf("CreateGame() error = %v", err)
+			}
+		}
+	}
+
+	return f
+}
+
+// win - завершает матч победой team и пересчитывает сетку
+func (f *playoffFixture) win(t *testing.T, gameID, team string) *entity.PlayoffBracket {
+	t.Helper()
+	ctx := context.Background()
+
+	game, err := f.games.GetGame(ctx, gameID)
+	if err != nil {
+		t.Fatalf("GetGame(%s) error = %v", gameID, err)
+	}
+	game.Status, game.Winner = entity.GameStatusFinal, team
+	game.FirstTeamScore, game.SecondTeamScore = 100, 90
+	if game.SecondTeam == team {
+		game.FirstTeamScore, game.SecondTeamScore = 90, 100
+	}
+	if _, err = f.games.UpdateGame(ctx, gameID, game); err != nil {
+		t.Fatalf("UpdateGame(%s) error = %v", gameID, err)
+	}
+
+	bracket, err := f.playoffs.SyncPlayoffs(ctx, f.league)
+	if err != nil {
+		t.Fatalf("SyncPlayoffs() error = %v", err)
+	}
+	return bracket
+}
+
+// lastGame - последний матч серии
+func lastGame(t *testing.T, bracket *entity.PlayoffBracket, round, series int) string {
+	t.Helper()
+	games := bracket.Rounds[round].Series[series].Games
+	if len(games) == 0 {
+		t.Fatalf("series %s has no games", bracket.Rounds[round].Series[series].ID)
+	}
+	return games[len(games)-1]
+}
+
+func TestCreatePlayoffsSeeding(t *testing.T) {
+	tests := []struct {
+		teams int
+		want  [][2]int // пары посевов первого раунда
+	}{
+		{teams: 2, want: [][2]int{{1, 2}}},
+		{teams: 4, want: [][2]int{{1, 4}, {2, 3}}},
+		{teams: 8, want: [][2]int{{1, 8}, {4, 5}, {2, 7}, {3, 6}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(rune('0'+tt.teams))+" teams", func(t *testing.T) {
+			ctx := context.Background()
+			f := newPlayoffFixture(t, tt.teams)
+
+			bracket, err := f.playoffs.CreatePlayoffs(ctx, f.league, &entity.PlayoffRequest{
+				Teams:     tt.teams,
+				BestOf:    7,
+				StartDate: "20.04.24",
+			})
+			if err != nil {
+				t.Fatalf("CreatePlayoffs() error = %v", err)
+			}
+
+			for i, seed := range bracket.Seeds {
+				if seed.Seed != i+1 || seed.Team != f.teams[i] {
+					t.Errorf("seed %d = %+v, want team %s", i+1, seed, f.teams[i])
+				}
+			}
+
+			var got [][2]int
+			for _, s := range bracket.Rounds[0].Series {
+				got = append(got, [2]int{s.Top.Seed, s.Bottom.Seed})
+
+				if len(s.Games) != 1 {
+					t.Fatalf("series %s has %d games, want 1", s.ID, len(s.Games))
+				}
+				game, err := f.games.GetGame(ctx, s.Games[0])
+				if err != nil {
+					t.Fatalf("GetGame() error = %v", err)
+				}
+				if game.FirstTeam != s.Top.Team || game.Type != entity.GameTypePlayoff || game.Date != "20.04.24" {
+					t.Errorf("series %s first game = %+v, want %s at home on 20.04.24", s.ID, game, s.Top.Team)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("first round = %v, want %v", got, tt.want)
+			}
+			if len(bracket.Rounds) == 1 {
+				return
+			}
+			for _, s := range bracket.Rounds[1].Series {
+				if s.Top != nil || s.Bottom != nil || len(s.Games) != 0 {
+					t.Errorf("series %s is filled before the first round is played", s.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestPlayoffsAdvance(t *testing.T) {
+	ctx := context.Background()
+	f := newPlayoffFixture(t, 4)
+	a, b, c, d := f.teams[0], f.teams[1], f.teams[2], f.teams[3]
+
+	bracket, err := f.playoffs.CreatePlayoffs(ctx, f.league, &entity.PlayoffRequest{
+		Teams:            4,
+		BestOf:           3,
+		StartDate:        "20.04.24",
+		DaysBetweenGames: 2,
+	})
+	if err != nil {
+		t.Fatalf("CreatePlayoffs() error = %v", err)
+	}
+
+	// A - D: 2-0, второй матч на площадке D через два дня
+	bracket = f.win(t, lastGame(t, bracket, 0, 0), a)
+	secondID := lastGame(t, bracket, 0, 0)
+	second, err := f.games.GetGame(ctx, secondID)
+	if err != nil {
+		t.Fatalf("GetGame() error = %v", err)
+	}
+	if second.FirstTeam != d || second.Date != "22.04.24" {
+		t.Errorf("second game = %s at home on %s, want %s on 22.04.24", second.FirstTeam, second.Date, d)
+	}
+	bracket = f.win(t, secondID, a)
+
+	s := bracket.Rounds[0].Series[0]
+	if s.Winner != a || s.TopWins != 2 || s.BottomWins != 0 || len(s.Games) != 2 {
+		t.Fatalf("series %s = %+v, want %s winning 2-0", s.ID, s, a)
+	}
+	final := bracket.Rounds[1].Series[0]
+	if final.Top == nil || final.Top.Team != a || final.Bottom != nil || len(final.Games) != 0 {
+		t.Fatalf("final = %+v, want %s waiting for an opponent", final, a)
+	}
+
+	// B - C: 2-1, серия идёт до третьего матча
+	bracket = f.win(t, lastGame(t, bracket, 0, 1), c)
+	bracket = f.win(t, lastGame(t, bracket, 0, 1), b)
+	if bracket.Rounds[0].Series[1].Winner != "" || len(bracket.Rounds[0].Series[1].Games) != 3 {
+		t.Fatalf("series at 1-1 = %+v, want a third game", bracket.Rounds[0].Series[1])
+	}
+	bracket = f.win(t, lastGame(t, bracket, 0, 1), b)
+
+	final = bracket.Rounds[1].Series[0]
+	if final.Bottom == nil || final.Bottom.Team != b || len(final.Games) != 1 {
+		t.Fatalf("final = %+v, want %s against %s with the first game scheduled", final, a, b)
+	}
+	firstID := final.Games[0]
+	first, err := f.games.GetGame(ctx, firstID)
+	if err != nil {
+		t.Fatalf("GetGame() error = %v", err)
+	}
+	// третий матч B - C был 24.04, финал начинается через два дня после него
+	if first.FirstTeam != a || first.Date != "26.04.24" {
+		t.Errorf("first final game = %s at home on %s, want %s on 26.04.24", first.FirstTeam, first.Date, a)
+	}
+
+	// удалённый несыгранный матч выпадает из серии, вместо него назначается новый
+	if err = f.games.DeleteGame(ctx, firstID); err != nil {
+		t.Fatalf("DeleteGame() error = %v", err)
+	}
+	bracket, err = f.playoffs.SyncPlayoffs(ctx, f.league)
+	if err != nil {
+		t.Fatalf("SyncPlayoffs() after a deleted game error = %v", err)
+	}
+	final = bracket.Rounds[1].Series[0]
+	if len(final.Games) != 1 || final.Games[0] == firstID {
+		t.Fatalf("final games = %v, want one new game instead of %s", final.Games, firstID)
+	}
+
+	bracket = f.win(t, lastGame(t, bracket, 1, 0), b)
+	if bracket.Champion != "" {
+		t.Fatalf("champion = %s after one final game", bracket.Champion)
+	}
+	bracket = f.win(t, lastGame(t, bracket, 1, 0), b)
+	if bracket.Champion != b {
+		t.Errorf("champion = %q, want %s", bracket.Champion, b)
+	}
+	if final = bracket.Rounds[1].Series[0]; final.Winner != b || final.BottomWins != 2 || final.TopWins != 0 {
+		t.Errorf("final = %+v, want %s winning 2-0", final, b)
+	}
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// ProfileTimeouts - сколько ждать каждое хранилище при сборке профиля
+type ProfileTimeouts struct {
+	Catalog time.Duration
+	Stats   time.Duration
+	Awards  time.Duration
+}
+
+type ProfileUC struct {
+	playerRp     PlayerRp
+	statPlayerRp StatPlayerRp
+	statAwardsRp StatAwardsRp
+	timeouts     ProfileTimeouts
+}
+
+func NewProfileUC(playerRp PlayerRp, statPlayerRp StatPlayerRp, statAwardsRp StatAwardsRp, timeouts ProfileTimeouts) *ProfileUC {
+	return &ProfileUC{
+		playerRp:     playerRp,
+		statPlayerRp: statPlayerRp,
+		statAwardsRp: statAwardsRp,
+		timeouts:     timeouts,
+	}
+}
+
+var _ Profile = (*ProfileUC)(nil)
+
+// GetPlayerProfile - запрашивает каталог, статистику и награды параллельно.
+// Ошибка или таймаут одного хранилища превращается в предупреждение, профиль возвращается без этой части.
+// Ошибка возвращается, только если игрока нет в каталоге или не ответило ни одно хранилище
+func (p *ProfileUC) GetPlayerProfile(ctx context.Context, playerID string) (*entity.PlayerProfile, error) {
+	profile := &entity.PlayerProfile{PlayerID: playerID}
+
+	var (
+		player                         *entity.Player
+		seasons                        []entity.PlayerSeasonStat
+		awards                         []entity.RewardStat
+		catalogErr, statsErr, awardErr error
+		wg                             sync.WaitGroup
+	)
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		player, catalogErr = withTimeout(ctx, p.timeouts.Catalog, func(ctx context.Context) (*entity.Player, error) {
+			return p.playerRp.GetPlayer(ctx, playerID)
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		seasons, statsErr = withTimeout(ctx, p.timeouts.Stats, func(ctx context.Context) ([]entity.PlayerSeasonStat, error) {
+			return p.statPlayerRp.GetPlayerSeasonStats(ctx, playerID, "")
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		awards, awardErr = withTimeout(ctx, p.timeouts.Awards, func(ctx context.Context) ([]entity.RewardStat, error) {
+			return p.statAwardsRp.ViewRewardsForPlayer(ctx, playerID)
+		})
+	}()
+	wg.Wait()
+
+	if errors.Is(catalogErr, apperrors.ErrPlayerNotFound) || errors.Is(catalogErr, apperrors.ErrInvalidPlayerID) {
+		return nil, catalogErr
+	}
+	if catalogErr != nil && statsErr != nil && awardErr != nil {
+		return nil, fmt.Errorf("player profile: no store responded: %w", catalogErr)
+	}
+
+	profile.Player = player
+	profile.Awards = awards
+	if statsErr == nil && len(seasons) != 0 {
+		career := &entity.CareerStat{}
+		for _, season := range seasons {
+			career.Games += season.Games
+			career.Totals = career.Totals.Add(season.Totals)
+		}
+		career.PerGame = career.Totals.PerGame(career.Games)
+		profile.Career = career
+		profile.Seasons = seasons
+	}
+
+	for _, warning := range []struct {
+		store   string
+		timeout time.Duration
+		err     error
+	}{
+		{"catalog", p.timeouts.Catalog, catalogErr},
+		{"player stats", p.timeouts.Stats, statsErr},
+		{"awards graph", p.timeouts.Awards, awardErr},
+	} {
+		switch {
+		case warning.err == nil:
+		case errors.Is(warning.err, context.DeadlineExceeded):
+			profile.Warnings = append(profile.Warnings, fmt.Sprintf("%s: no response within %s", warning.store, warning.timeout))
+		default:
+			profile.Warnings = append(profile.Warnings, fmt.Sprintf("%s: %s", warning.store, warning.err))
+		}
+	}
+
+	return profile, nil
+}
+
+// withTimeout - выполняет запрос к хранилищу с собственным таймаутом; нулевой таймаут - без ограничения
+func withTimeout[T any](ctx context.Context, timeout time.Duration, query func(context.Context) (T, error)) (T, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := query(ctx)
+		done <- result{value: value, err: err}
+	}()
+
+	// не все драйверы прерывают запрос по контексту, поэтому ответ ждётся не дольше таймаута
+	select {
+	case res := <-done:
+		return res.value, res.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
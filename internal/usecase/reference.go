@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/romeros69/basket/internal/apperrors"
+)
+
+// resolveReference - сущность, на которую ссылается поле field; ошибки notFound превращаются в ReferenceError
+func resolveReference[T any](ctx context.Context, field, id string, get func(context.Context, string) (*T, error), notFound ...error) (*T, error) {
+	found, err := get(ctx, id)
+	for _, target := range notFound {
+		if errors.Is(err, target) {
+			return nil, apperrors.NewReferenceError(field, id)
+		}
+	}
+	return found, err
+}
+
+// cachedGet - get, который читает каждый id один раз, запоминая и найденное, и ошибку. Нужен пакетным
+// операциям, где одни и те же игроки, матчи и лиги повторяются в строках; не для одновременного использования
+func cachedGet[T any](get func(context.Context, string) (*T, error)) func(context.Context, string) (*T, error) {
+	type result struct {
+		found *T
+		err   error
+	}
+	cache := make(map[string]result)
+	return func(ctx context.Context, id string) (*T, error) {
+		if r, ok := cache[id]; ok {
+			return r.found, r.err
+		}
+		found, err := get(ctx, id)
+		cache[id] = result{found: found, err: err}
+		return found, err
+	}
+}
+
+// checkTeamReference - проверяет, что команда, на которую ссылается поле field, существует
+func checkTeamReference(ctx context.Context, teamRp TeamRp, field, teamID string) error {
+	_, err := resolveReference(ctx, field, teamID, teamRp.GetTeam, apperrors.ErrTeamNotFound, apperrors.ErrInvalidTeamID)
+	return err
+}
+
+// checkLeagueReference - проверяет, что лига, на которую ссылается поле field, существует
+func checkLeagueReference(ctx context.Context, leagueRp LeagueRp, field, leagueID string) error {
+	_, err := resolveReference(ctx, field, leagueID, leagueRp.GetLeague, apperrors.ErrLeagueNotFound, apperrors.ErrInvalidLeagueID)
+	return err
+}
+
+// checkPlayerReference - проверяет, что игрок, на которого ссылается поле field, существует
+func checkPlayerReference(ctx context.Context, playerRp PlayerRp, field, playerID string) error {
+	_, err := resolveReference(ctx, field, playerID, playerRp.GetPlayer, apperrors.ErrPlayerNotFound, apperrors.ErrInvalidPlayerID)
+	return err
+}
+
+// checkGameReference - проверяет, что матч, на который ссылается поле field, существует
+func checkGameReference(ctx context.Context, gameRp GameRp, field, gameID string) error {
+	_, err := resolveReference(ctx, field, gameID, gameRp.GetGame, apperrors.ErrGameNotFound, apperrors.ErrInvalidGameID)
+	return err
+}
+
+// checkAwardReference - проверяет, что награда, на которую ссылается поле field, существует
+func checkAwardReference(ctx context.Context, awardRp AwardRp, field, awardID string) error {
+	_, err := resolveReference(ctx, field, awardID, awardRp.GetAward, apperrors.ErrAwardNotFound, apperrors.ErrInvalidAwardID)
+	return err
+}
+
+type skipReferenceCheckKey struct{}
+
+// WithoutReferenceCheck - отключает проверку ссылок на каталог для записей статистики.
+// Нужен для массовой загрузки, когда каталог заполняется позже или заведомо согласован
+func WithoutReferenceCheck(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipReferenceCheckKey{}, true)
+}
+
+// referenceCheckSkipped - отключена ли проверка ссылок для запроса
+func referenceCheckSkipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipReferenceCheckKey{}).(bool)
+	return skip
+}
@@ -0,0 +1,104 @@
+package chouse_rp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// statsColumn - колонка player_stats, по которой ищутся строки удаляемой сущности
+func statsColumn(entityKind string) (string, error) {
+	switch entityKind {
+	case entity.DeletionEntityPlayer:
+		return "player_id", nil
+	case entity.DeletionEntityGame:
+		return "match_id", nil
+	default:
+		return "", fmt.Errorf("player stats are not linked to %q", entityKind)
+	}
+}
+
+// HasStats - есть ли у игрока или матча строки статистики
+func (c *ChouseRepo) HasStats(ctx context.Context, entityKind, id string) (bool, error) {
+	column, err := statsColumn(entityKind)
+	if err != nil {
+		return false, err
+	}
+
+	var count uint64
+	err = c.cHouseDB.DB.QueryRowContext(ctx, "SELECT count() FROM player_stats WHERE "+column+" = ?", id).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("ошибка при подсчёте статистики: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// DeleteStats - удаляет строки статистики игрока или матча вместе с их вкладом в сезонные представления
+func (c *ChouseRepo) DeleteStats(ctx context.Context, entityKind, id string) error {
+	column, err := statsColumn(entityKind)
+	if err != nil {
+		return err
+	}
+
+	return c.deleteStats(ctx, column+" = ?", id)
+}
+
+// StreamStatIDs - различные id игроков или матчей в player_stats
+func (c *ChouseRepo) StreamStatIDs(ctx context.Context, entityKind string, yield func(id string) error) error {
+	column, err := statsColumn(entityKind)
+	if err != nil {
+		return err
+	}
+
+	rows, err := c.cHouseDB.DB.QueryContext(ctx, "SELECT DISTINCT toString("+column+") FROM player_stats")
+	if err != nil {
+		return fmt.Errorf("ошибка при чтении id: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return err
+		}
+		if err = yield(id); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// StreamAppearances - пары игрок-матч из статистики с командой и датой матча; по ним восстанавливается граф карьеры
+func (c *ChouseRepo) StreamAppearances(ctx context.Context, yield func(appearance entity.Appearance) error) error {
+	rows, err := c.cHouseDB.DB.QueryContext(ctx, `
+		SELECT player_id, toString(match_id), any(team_id), max(game_date)
+		FROM player_stats
+		GROUP BY player_id, match_id
+	`)
+	if err != nil {
+		return fmt.Errorf("ошибка при чтении матчей игроков: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			appearance entity.Appearance
+			gameDate   time.Time
+		)
+		if err = rows.Scan(&appearance.Player, &appearance.Match, &appearance.Team, &gameDate); err != nil {
+			return err
+		}
+		if gameDate.After(unknownDate) {
+			appearance.Date = gameDate.Format(entity.CareerDateLayout)
+		}
+		if err = yield(appearance); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,70 @@
+package chouse_rp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+var _ usecase.OutboxProjection = (*ChouseRepo)(nil)
+
+// ApplyEvent - вставляет в таблицу измерения строку с версией события; удаление - строка с deleted = 1.
+// Дубликаты и устаревшие версии схлопывает ReplacingMergeTree
+func (c *ChouseRepo) ApplyEvent(ctx context.Context, event *entity.OutboxEvent) error {
+	deleted := 0
+	if event.Type == entity.OutboxEventDelete {
+		deleted = 1
+	}
+
+	var (
+		query string
+		args  []interface{}
+	)
+	switch event.Entity {
+	case entity.OutboxEntityPlayer:
+		p := event.Player
+		if p == nil {
+			p = new(entity.Player)
+		}
+		query = `INSERT INTO players_dim (id, name, surname, team_id, role, citizenship, version, deleted)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		args = []interface{}{event.EntityID, p.Name, p.Surname, p.Team, p.Role, p.Citizenship, event.Version, deleted}
+	case entity.OutboxEntityGame:
+		g := event.Game
+		if g == nil {
+			g = new(entity.Game)
+		}
+		gameDate, err := toChouseDate(g.Date)
+		if err != nil {
+			return err
+		}
+		query = `INSERT INTO games_dim (id, first_team, second_team, game_date, league_id, status,
+			                           first_team_score, second_team_score, version, deleted)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		args = []interface{}{event.EntityID, g.FirstTeam, g.SecondTeam, gameDate, g.League, g.Status,
+			g.FirstTeamScore, g.SecondTeamScore, event.Version, deleted}
+	case entity.OutboxEntityLeague:
+		l := event.League
+		if l == nil {
+			l = new(entity.League)
+		}
+		query = `INSERT INTO leagues_dim (id, name, season, version, deleted) VALUES (?, ?, ?, ?, ?)`
+		args = []interface{}{event.EntityID, l.Name, l.Season, event.Version, deleted}
+	case entity.OutboxEntityAward:
+		a := event.Award
+		if a == nil {
+			a = new(entity.Award)
+		}
+		query = `INSERT INTO awards_dim (id, title, description, version, deleted) VALUES (?, ?, ?, ?, ?)`
+		args = []interface{}{event.EntityID, a.Tittle, a.Description, event.Version, deleted}
+	default:
+		return fmt.Errorf("unknown outbox entity %q", event.Entity)
+	}
+
+	if _, err := c.cHouseDB.DB.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("apply %s event to dimensions: %w", event.Entity, err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package chouse_rp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// leaderColumns - колонки player_season_stats для показателей entity.LeaderStats
+var leaderColumns = map[string]string{
+	"points":            "points",
+	"rebounds":          "rebounds",
+	"assists":           "assists",
+	"steals":            "steals",
+	"blocks":            "blocks",
+	"turnovers":         "turnovers",
+	"fgm":               "fgm",
+	"fga":               "fga",
+	"threePm":           "three_pm",
+	"threePa":           "three_pa",
+	"ftm":               "ftm",
+	"fta":               "fta",
+	"offensiveRebounds": "offensive_rebounds",
+	"defensiveRebounds": "defensive_rebounds",
+	"personalFouls":     "personal_fouls",
+	"plusMinus":         "plus_minus",
+	"minutes":           "minutes",
+}
+
+// leaderValues - значение показателя в зависимости от per
+var leaderValues = map[string]string{
+	entity.LeaderTotal:    "total",
+	entity.LeaderPerGame:  "if(played = 0, 0, total / played)",
+	entity.LeaderPer36Min: "if(played_minutes = 0, 0, total * 36 / played_minutes)",
+}
+
+// Рейтинг игроков по показателю из сезонного представления; RANK даёт равным значениям одно место
+func (c *ChouseRepo) GetLeaders(ctx context.Context, req entity.LeadersRequest) ([]entity.Leader, error) {
+	column, ok := leaderColumns[req.Stat]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown stat %q", apperrors.ErrInvalidLeaders, req.Stat)
+	}
+	value, ok := leaderValues[req.Per]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown per %q", apperrors.ErrInvalidLeaders, req.Per)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT rank, player_id, played, value
+		FROM
+		(
+			SELECT player_id, played, value, rank() OVER (ORDER BY value DESC) AS rank
+			FROM
+			(
+				SELECT player_id,
+				       uniqExactMerge(matches) AS played,
+				       toFloat64(sumMerge(sum_%s)) AS total,
+				       sumMerge(sum_minutes) AS played_minutes,
+				       %s AS value
+				FROM player_season_stats
+				WHERE (? = '' OR season = ?) AND (? = '' OR league_id = ?) AND (? = '' OR team_id = ?)
+				GROUP BY player_id
+				HAVING played >= ?
+			)
+		)
+		ORDER BY rank, player_id
+		LIMIT ?
+	`, column, value)
+
+	rows, err := c.cHouseDB.DB.QueryContext(ctx, query,
+		req.Season, req.Season, req.League, req.League, req.Team, req.Team, req.MinGames, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var leaders []entity.Leader
+	for rows.Next() {
+		var leader entity.Leader
+		if err := rows.Scan(&leader.Rank, &leader.PlayerID, &leader.Games, &leader.Value); err != nil {
+			return nil, err
+		}
+		leaders = append(leaders, leader)
+	}
+
+	return leaders, rows.Err()
+}
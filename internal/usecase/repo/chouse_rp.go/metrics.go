@@ -0,0 +1,174 @@
+package chouse_rp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// unknownDate - game_date строк, для которых дата матча неизвестна (DEFAULT toDate(0))
+var unknownDate = time.Unix(0, 0).UTC()
+
+func toChouseDate(date string) (time.Time, error) {
+	if date == "" {
+		return unknownDate, nil
+	}
+	parsed, err := time.Parse(entity.GameDateLayout, date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("game date %q: %w", date, err)
+	}
+	return parsed, nil
+}
+
+func fromChouseDate(date time.Time) string {
+	if !date.After(unknownDate) {
+		return ""
+	}
+	return date.Format(entity.GameDateLayout)
+}
+
+// scopeCondition - условие WHERE для выборки матчей
+func scopeCondition(scope entity.StatScope) (string, []interface{}, error) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+
+	if scope.MatchID != "" {
+		conditions = append(conditions, "match_id = ?")
+		args = append(args, scope.MatchID)
+	}
+	if scope.From != "" {
+		from, err := toChouseDate(scope.From)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(conditions, "game_date >= ?")
+		args = append(args, from)
+	}
+	if scope.To != "" {
+		to, err := toChouseDate(scope.To)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(conditions, "game_date <= ?")
+		args = append(args, to)
+	}
+	if scope.Season != "" {
+		conditions = append(conditions, "season = ?")
+		args = append(args, scope.Season)
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// knownAttempts - строка с известными попытками бросков с игры
+const knownAttempts = "NOT (fga = 0 AND fgm > 0)"
+
+// Продвинутые метрики игрока по выборке матчей.
+// p - суммы игрока, t - суммы его команд в тех же матчах, lg - суммы всех игроков выборки для констант PER.
+// Суммы с суффиксом _k - только по строкам с известными попытками бросков: у старых строк fga = 0 при fgm > 0,
+// и их попадания не должны завышать TS%, eFG% и промахи в PER
+const playerMetricsQuery = `
+	SELECT
+		p_games, p_min, p_pts, ts, efg, usg, ast_to, per,
+		p_pts * per36, p_trb * per36, p_ast * per36, p_stl * per36, p_blk * per36, p_tov * per36,
+		p_pts * per100, p_trb * per100, p_ast * per100, p_stl * per100, p_blk * per100, p_tov * per100
+	FROM
+	(
+		SELECT
+			p_games, p_min, p_pts, p_trb, p_ast, p_stl, p_blk, p_tov,
+			if(p_fga + 0.44 * p_fta_k = 0, 0, p_pts_k / (2 * (p_fga + 0.44 * p_fta_k))) AS ts,
+			if(p_fga = 0, 0, (p_fgm_k + 0.5 * p_tpm_k) / p_fga) AS efg,
+			if(p_min = 0 OR t_fga + 0.44 * t_fta + t_tov = 0, 0,
+			   (p_fga + 0.44 * p_fta + p_tov) * (t_min / 5) / (p_min * (t_fga + 0.44 * t_fta + t_tov))) AS usg,
+			if(p_tov = 0, p_ast, p_ast / p_tov) AS ast_to,
+
+			if(p_min = 0, 0, 36 / p_min) AS per36,
+			t_fga + 0.44 * t_fta - t_orb + t_tov AS t_poss,
+			if(p_min = 0 OR t_min = 0 OR t_poss = 0, 0, 100 / (t_poss * p_min / (t_min / 5))) AS per100,
+
+			if(lg_fgm = 0, 0, lg_ast / lg_fgm) AS lg_ratio,
+			if(t_fgm = 0, 0, t_ast / t_fgm) AS t_ratio,
+			if(lg_fgm = 0 OR lg_ftm = 0, 2 / 3, 2 / 3 - (0.5 * lg_ratio) / (2 * (lg_fgm / lg_ftm))) AS factor,
+			if(lg_fga - lg_orb + lg_tov + 0.44 * lg_fta = 0, 0, lg_pts / (lg_fga - lg_orb + lg_tov + 0.44 * lg_fta)) AS vop,
+			if(lg_trb = 0, 0, (lg_trb - lg_orb) / lg_trb) AS drbp,
+			if(lg_pf = 0, 0, lg_ftm / lg_pf - 0.44 * (lg_fta / lg_pf) * vop) AS pf_value,
+			if(p_min = 0, 0, (
+				p_tpm + (2 / 3) * p_ast + (2 - factor * t_ratio) * p_fgm + p_ftm * 0.5 * (1 + (1 - t_ratio) + (2 / 3) * t_ratio)
+				- vop * p_tov - vop * drbp * (p_fga - p_fgm_k) - vop * 0.44 * (0.44 + 0.56 * drbp) * (p_fta - p_ftm)
+				+ vop * (1 - drbp) * (p_trb - p_orb) + vop * drbp * p_orb + vop * p_stl + vop * drbp * p_blk - p_pf * pf_value
+			) / p_min) AS uper,
+			if(lg_min = 0, 0, (
+				lg_tpm + (2 / 3) * lg_ast + (2 - factor * lg_ratio) * lg_fgm + lg_ftm * 0.5 * (1 + (1 - lg_ratio) + (2 / 3) * lg_ratio)
+				- vop * lg_tov - vop * drbp * (lg_fga - lg_fgm_k) - vop * 0.44 * (0.44 + 0.56 * drbp) * (lg_fta - lg_ftm)
+				+ vop * (1 - drbp) * (lg_trb - lg_orb) + vop * drbp * lg_orb + vop * lg_stl + vop * drbp * lg_blk - lg_pf * pf_value
+			) / lg_min) AS lg_uper,
+			if(lg_uper = 0, 0, uper * 15 / lg_uper) AS per
+		FROM
+		(
+			SELECT count(DISTINCT match_id) AS p_games, sum(minutes) AS p_min, sum(points) AS p_pts,
+			       sum(fgm) AS p_fgm, sum(fga) AS p_fga, sum(three_pm) AS p_tpm, sum(ftm) AS p_ftm, sum(fta) AS p_fta,
+			       sum(offensive_rebounds) AS p_orb, sum(rebounds) AS p_trb, sum(assists) AS p_ast, sum(steals) AS p_stl,
+			       sum(blocks) AS p_blk, sum(turnovers) AS p_tov, sum(personal_fouls) AS p_pf,
+			       sumIf(points, %[2]s) AS p_pts_k, sumIf(fgm, %[2]s) AS p_fgm_k, sumIf(three_pm, %[2]s) AS p_tpm_k,
+			       sumIf(fta, %[2]s) AS p_fta_k
+			FROM player_stats
+			WHERE player_id = ? AND %[1]s
+		) AS p
+		CROSS JOIN
+		(
+			SELECT sum(minutes) AS t_min, sum(fgm) AS t_fgm, sum(fga) AS t_fga, sum(fta) AS t_fta,
+			       sum(offensive_rebounds) AS t_orb, sum(assists) AS t_ast, sum(turnovers) AS t_tov
+			FROM player_stats
+			WHERE (team_id, match_id) IN (SELECT team_id, match_id FROM player_stats WHERE player_id = ? AND %[1]s)
+		) AS t
+		CROSS JOIN
+		(
+			SELECT sum(minutes) AS lg_min, sum(points) AS lg_pts,
+			       sum(fgm) AS lg_fgm, sum(fga) AS lg_fga, sum(three_pm) AS lg_tpm, sum(ftm) AS lg_ftm, sum(fta) AS lg_fta,
+			       sum(offensive_rebounds) AS lg_orb, sum(rebounds) AS lg_trb, sum(assists) AS lg_ast, sum(steals) AS lg_stl,
+			       sum(blocks) AS lg_blk, sum(turnovers) AS lg_tov, sum(personal_fouls) AS lg_pf,
+			       sumIf(fgm, %[2]s) AS lg_fgm_k
+			FROM player_stats
+			WHERE %[1]s
+		) AS lg
+	)
+`
+
+// Продвинутые метрики игрока: TS%, eFG%, USG%, PER, AST/TO и показатели на 36 минут и 100 владений
+func (c *ChouseRepo) GetPlayerMetrics(ctx context.Context, playerID string, scope entity.StatScope) (*entity.PlayerMetrics, error) {
+	condition, scopeArgs, err := scopeCondition(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	args := append([]interface{}{playerID}, scopeArgs...)
+	args = append(args, playerID)
+	args = append(args, scopeArgs...)
+	args = append(args, scopeArgs...)
+
+	metrics := &entity.PlayerMetrics{PlayerID: playerID, Scope: scope}
+	var games uint64
+	var points int64
+	err = c.cHouseDB.DB.QueryRowContext(ctx, fmt.Sprintf(playerMetricsQuery, condition, knownAttempts), args...).Scan(
+		&games, &metrics.Minutes, &points,
+		&metrics.TrueShooting, &metrics.EffectiveFG, &metrics.Usage, &metrics.AssistTurnover, &metrics.PER,
+		&metrics.Per36.Points, &metrics.Per36.Rebounds, &metrics.Per36.Assists,
+		&metrics.Per36.Steals, &metrics.Per36.Blocks, &metrics.Per36.Turnovers,
+		&metrics.Per100.Points, &metrics.Per100.Rebounds, &metrics.Per100.Assists,
+		&metrics.Per100.Steals, &metrics.Per100.Blocks, &metrics.Per100.Turnovers,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при расчёте метрик: %w", err)
+	}
+	if games == 0 {
+		return nil, apperrors.ErrPlayerStatsNotFound
+	}
+	metrics.Games = int(games)
+	metrics.Points = int(points)
+
+	return metrics, nil
+}
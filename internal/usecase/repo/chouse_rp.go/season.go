@@ -0,0 +1,141 @@
+package chouse_rp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/pkg/chouse"
+)
+
+// seasonStatsMerges - суммы из состояний сезонных представлений
+const seasonStatsMerges = `
+	uniqExactMerge(matches),
+	sumMerge(sum_minutes),
+	sumMerge(sum_points),
+	sumMerge(sum_fgm),
+	sumMerge(sum_fga),
+	sumMerge(sum_three_pm),
+	sumMerge(sum_three_pa),
+	sumMerge(sum_ftm),
+	sumMerge(sum_fta),
+	sumMerge(sum_offensive_rebounds),
+	sumMerge(sum_defensive_rebounds),
+	sumMerge(sum_rebounds),
+	sumMerge(sum_assists),
+	sumMerge(sum_steals),
+	sumMerge(sum_blocks),
+	sumMerge(sum_turnovers),
+	sumMerge(sum_personal_fouls),
+	sumMerge(sum_plus_minus)
+`
+
+// Сезонная статистика игрока из представления player_season_stats, по сезону и лиге
+func (c *ChouseRepo) GetPlayerSeasonStats(ctx context.Context, playerID, season string) ([]entity.PlayerSeasonStat, error) {
+	query := `
+		SELECT season, league_id, arraySort(groupUniqArray(team_id)),` + seasonStatsMerges + `
+		FROM player_season_stats
+		WHERE player_id = ? AND (? = '' OR season = ?)
+		GROUP BY season, league_id
+		ORDER BY season, league_id
+	`
+	rows, err := c.cHouseDB.DB.QueryContext(ctx, query, playerID, season, season)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []entity.PlayerSeasonStat
+	for rows.Next() {
+		stat := entity.PlayerSeasonStat{PlayerID: playerID}
+		dest := append([]interface{}{&stat.Season, &stat.League, &stat.Teams, &stat.Games}, totalsDest(&stat.Totals)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		stat.PerGame = stat.Totals.PerGame(stat.Games)
+		stats = append(stats, stat)
+	}
+
+	return stats, rows.Err()
+}
+
+// Сезонная статистика команды из представления team_season_stats
+func (c *ChouseRepo) GetTeamSeasonStats(ctx context.Context, teamID, season string) ([]entity.TeamSeasonStat, error) {
+	query := `
+		SELECT season, league_id,` + seasonStatsMerges + `
+		FROM team_season_stats
+		WHERE team_id = ? AND (? = '' OR season = ?)
+		GROUP BY season, league_id
+		ORDER BY season, league_id
+	`
+	rows, err := c.cHouseDB.DB.QueryContext(ctx, query, teamID, season, season)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []entity.TeamSeasonStat
+	for rows.Next() {
+		stat := entity.TeamSeasonStat{TeamID: teamID}
+		dest := append([]interface{}{&stat.Season, &stat.League, &stat.Games}, totalsDest(&stat.Totals)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		stat.PerGame = stat.Totals.PerGame(stat.Games)
+		stats = append(stats, stat)
+	}
+
+	return stats, rows.Err()
+}
+
+// totalsDest - приёмники для sumMerge в порядке seasonStatsMerges
+func totalsDest(t *entity.SeasonTotals) []interface{} {
+	return []interface{}{
+		&t.Minutes, &t.Points, &t.FGM, &t.FGA, &t.ThreePM, &t.ThreePA, &t.FTM, &t.FTA,
+		&t.OffensiveRebounds, &t.DefensiveRebounds, &t.Rebounds, &t.Assists, &t.Steals, &t.Blocks,
+		&t.Turnovers, &t.PersonalFouls, &t.PlusMinus,
+	}
+}
+
+// deleteStats - удаляет строки player_stats по условию вместе с их вкладом в сезонные представления.
+// Представления не видят удалений из player_stats, поэтому затронутые сезонные строки пересобираются
+// из оставшихся данных. Строки player_stats удаляются последними: пока они есть, повтор находит те же
+// затронутые сезоны, и каждый шаг можно выполнить ещё раз. В кластере мутации идут в реплицируемые таблицы всех узлов,
+// а подзапросы и пересборка - через Distributed-таблицы
+func (c *ChouseRepo) deleteStats(ctx context.Context, condition string, args ...interface{}) error {
+	// мутации выполняются асинхронно, без mutations_sync следующий шаг увидит ещё не удалённые строки
+	ctx = clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{
+		"mutations_sync": 2,
+	}))
+
+	for _, view := range []struct {
+		name string
+		keys string
+	}{
+		{"player_season_stats", "season, league_id, team_id, player_id"},
+		{"team_season_stats", "season, league_id, team_id"},
+	} {
+		affected := "(" + view.keys + ") IN (SELECT " + view.keys + " FROM player_stats WHERE " + condition + ")"
+
+		_, err := c.cHouseDB.DB.ExecContext(ctx, "ALTER TABLE "+c.cHouseDB.LocalTable(view.name)+c.cHouseDB.OnCluster()+
+			" DELETE WHERE "+affected, args...)
+		if err != nil {
+			return fmt.Errorf("ошибка при удалении из %s: %w", view.name, err)
+		}
+
+		rebuild := "INSERT INTO " + view.name + " SELECT " + view.keys + "," + chouse.SeasonStatsStates +
+			" FROM player_stats WHERE " + affected + " AND NOT (" + condition + ") GROUP BY " + view.keys
+		if _, err = c.cHouseDB.DB.ExecContext(ctx, rebuild, append(append([]interface{}{}, args...), args...)...); err != nil {
+			return fmt.Errorf("ошибка при пересборке %s: %w", view.name, err)
+		}
+	}
+
+	_, err := c.cHouseDB.DB.ExecContext(ctx, "ALTER TABLE "+c.cHouseDB.LocalTable("player_stats")+c.cHouseDB.OnCluster()+
+		" DELETE WHERE "+condition, args...)
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении статистики: %w", err)
+	}
+
+	return nil
+}
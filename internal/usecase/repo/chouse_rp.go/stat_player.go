@@ -3,6 +3,7 @@ package chouse_rp
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/romeros69/basket/internal/entity"
 	"github.com/romeros69/basket/internal/usecase"
@@ -11,33 +12,76 @@ import (
 
 type ChouseRepo struct {
 	cHouseDB *chouse.Chouse
+	// stats - вставка в player_stats пачками
+	stats *chouse.BatchWriter
 }
 
 func NewChouseRepo(chouse *chouse.Chouse) *ChouseRepo {
 	return &ChouseRepo{
 		cHouseDB: chouse,
+		stats:    chouse.NewBatchWriter(insertStatsQuery),
 	}
 }
 
 var _ usecase.StatPlayerRp = (*ChouseRepo)(nil)
 
+const insertStatsQuery = `
+	INSERT INTO player_stats (player_id, match_id, goals, assists, interceptions, rebounds,
+	                          points, fgm, fga, three_pm, three_pa, ftm, fta, offensive_rebounds, defensive_rebounds,
+	                          steals, blocks, turnovers, personal_fouls, minutes, plus_minus, team_id, game_date, season, league_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
 // Функция для вставки данных в таблицу player_stats
 func (c *ChouseRepo) InsertPlayerStat(ctx context.Context, stat entity.PlayerStat) error {
-	insertQuery := `
-		INSERT INTO player_stats (player_id, match_id, goals, assists, interceptions, rebounds)
-		VALUES (?, ?, ?, ?, ?, ?)
-	`
-	_, err := c.cHouseDB.DB.ExecContext(ctx, insertQuery, stat.PlayerID, stat.MatchID, stat.Goals, stat.Assists, stat.Interceptions, stat.Rebounds)
-	if err != nil {
-		return fmt.Errorf("ошибка при вставке данных: %w", err)
+	return c.InsertPlayerStats(ctx, []entity.PlayerStat{stat})[0]
+}
+
+// InsertPlayerStats - ставит строки в очередь пакетной вставки и ждёт её; ошибки по строкам, в порядке stats
+func (c *ChouseRepo) InsertPlayerStats(ctx context.Context, stats []entity.PlayerStat) []error {
+	errs := make([]error, len(stats))
+	rows := make([][]interface{}, 0, len(stats))
+	index := make([]int, 0, len(stats))
+	for i, stat := range stats {
+		gameDate, err := toChouseDate(stat.GameDate)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		rows = append(rows, []interface{}{stat.PlayerID, stat.MatchID, stat.Goals, stat.Assists, stat.Interceptions, stat.Rebounds,
+			stat.Points, stat.FGM, stat.FGA, stat.ThreePM, stat.ThreePA, stat.FTM, stat.FTA, stat.OffensiveRebounds, stat.DefensiveRebounds,
+			stat.Steals, stat.Blocks, stat.Turnovers, stat.PersonalFouls, stat.Minutes, stat.PlusMinus, stat.TeamID, gameDate, stat.Season, stat.LeagueID})
+		index = append(index, i)
 	}
-	return nil
+
+	for i, err := range c.stats.Write(ctx, rows) {
+		if err != nil {
+			errs[index[i]] = fmt.Errorf("ошибка при вставке данных: %w", err)
+		}
+	}
+	return errs
+}
+
+// Close - дописывает строки, ждущие в очереди пакетной вставки
+func (c *ChouseRepo) Close(ctx context.Context) error {
+	return c.stats.Close(ctx)
+}
+
+// ReplacePlayerStat - удаляет строки игрока за матч вместе с их вкладом в сезонные представления и вставляет stat
+func (c *ChouseRepo) ReplacePlayerStat(ctx context.Context, stat entity.PlayerStat) error {
+	if err := c.deleteStats(ctx, "player_id = ? AND match_id = ?", stat.PlayerID, stat.MatchID); err != nil {
+		return err
+	}
+
+	return c.InsertPlayerStat(ctx, stat)
 }
 
 // Поиск статистики игрока по его идентификатору (player_id) и матчу (match_id)
 func (c *ChouseRepo) GetPlayerStatsByIDAndMatch(ctx context.Context, playerID, matchID string) ([]entity.PlayerStat, error) {
 	query := `
-		SELECT player_id, match_id, goals, assists, interceptions, rebounds
+		SELECT player_id, match_id, goals, assists, interceptions, rebounds,
+		       points, fgm, fga, three_pm, three_pa, ftm, fta, offensive_rebounds, defensive_rebounds,
+		       steals, blocks, turnovers, personal_fouls, minutes, plus_minus, team_id, game_date, season, league_id
 		FROM player_stats
 		WHERE player_id = ? AND match_id = ?
 	`
@@ -50,10 +94,14 @@ func (c *ChouseRepo) GetPlayerStatsByIDAndMatch(ctx context.Context, playerID, m
 	var stats []entity.PlayerStat
 	for rows.Next() {
 		var stat entity.PlayerStat
-		err := rows.Scan(&stat.PlayerID, &stat.MatchID, &stat.Goals, &stat.Assists, &stat.Interceptions, &stat.Rebounds)
+		var gameDate time.Time
+		err := rows.Scan(&stat.PlayerID, &stat.MatchID, &stat.Goals, &stat.Assists, &stat.Interceptions, &stat.Rebounds,
+			&stat.Points, &stat.FGM, &stat.FGA, &stat.ThreePM, &stat.ThreePA, &stat.FTM, &stat.FTA, &stat.OffensiveRebounds, &stat.DefensiveRebounds,
+			&stat.Steals, &stat.Blocks, &stat.Turnovers, &stat.PersonalFouls, &stat.Minutes, &stat.PlusMinus, &stat.TeamID, &gameDate, &stat.Season, &stat.LeagueID)
 		if err != nil {
 			return nil, err
 		}
+		stat.GameDate = fromChouseDate(gameDate)
 		stats = append(stats, stat)
 	}
 
@@ -0,0 +1,218 @@
+package memory_rp
+
+import (
+	"context"
+	"sort"
+
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// GetCoAwardedPlayers - игроки, награждённые в тех же турнирах, что и заданный, и сколько у них общих матчей и турниров
+func (sa *StatAwardsRepo) GetCoAwardedPlayers(_ context.Context, playerID string, limit int) ([]entity.CoAwardedPlayer, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	matches := make(map[string]bool)
+	tournaments := make(map[string]bool)
+	for _, g := range sa.liveGrants() {
+		if g.player != playerID {
+			continue
+		}
+		matches[g.match] = true
+		for _, tournament := range sa.partOfTournament[g.match] {
+			tournaments[tournament] = true
+		}
+	}
+
+	sharedMatches := make(map[string]map[string]bool)
+	sharedTournaments := make(map[string]map[string]bool)
+	for _, g := range sa.liveGrants() {
+		if g.player == playerID {
+			continue
+		}
+		if matches[g.match] {
+			addToSet(sharedMatches, g.player, g.match)
+		}
+		for _, tournament := range sa.partOfTournament[g.match] {
+			if tournaments[tournament] {
+				addToSet(sharedTournaments, g.player, tournament)
+			}
+		}
+	}
+
+	players := make([]entity.CoAwardedPlayer, 0, len(sharedTournaments))
+	for player, shared := range sharedTournaments {
+		props := sa.properties["Player:"+player]
+		players = append(players, entity.CoAwardedPlayer{
+			Player:            player,
+			Name:              props["name"],
+			Surname:           props["surname"],
+			SharedMatches:     len(sharedMatches[player]),
+			SharedTournaments: len(shared),
+		})
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].SharedMatches != players[j].SharedMatches {
+			return players[i].SharedMatches > players[j].SharedMatches
+		}
+		if players[i].SharedTournaments != players[j].SharedTournaments {
+			return players[i].SharedTournaments > players[j].SharedTournaments
+		}
+		return players[i].Player < players[j].Player
+	})
+
+	return firstN(players, limit), nil
+}
+
+// GetMostDecoratedPlayers - игроки турнира по убыванию числа вручений, затем разных наград
+func (sa *StatAwardsRepo) GetMostDecoratedPlayers(_ context.Context, tournamentID string, limit int) ([]entity.DecoratedPlayer, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	awards := make(map[string]int)
+	distinct := make(map[string]map[string]bool)
+	for _, g := range sa.liveGrants() {
+		if !hasNode(sa.partOfTournament[g.match], tournamentID) {
+			continue
+		}
+		awards[g.player]++
+		addToSet(distinct, g.player, g.reward)
+	}
+
+	players := make([]entity.DecoratedPlayer, 0, len(awards))
+	for player, count := range awards {
+		props := sa.properties["Player:"+player]
+		players = append(players, entity.DecoratedPlayer{
+			Player:         player,
+			Name:           props["name"],
+			Surname:        props["surname"],
+			Awards:         count,
+			DistinctAwards: len(distinct[player]),
+		})
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Awards != players[j].Awards {
+			return players[i].Awards > players[j].Awards
+		}
+		if players[i].DistinctAwards != players[j].DistinctAwards {
+			return players[i].DistinctAwards > players[j].DistinctAwards
+		}
+		return players[i].Player < players[j].Player
+	})
+
+	return firstN(players, limit), nil
+}
+
+// GetAwardCoOccurrence - пары наград, которые получали одни и те же игроки; пустой awardID - все пары
+func (sa *StatAwardsRepo) GetAwardCoOccurrence(_ context.Context, awardID string, limit int) ([]entity.AwardCoOccurrence, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	var pairs []entity.AwardCoOccurrence
+	for _, award := range sa.rewards {
+		for _, other := range sa.rewards {
+			if awardID == "" && award >= other || awardID != "" && (award != awardID || other == award) {
+				continue
+			}
+
+			players := 0
+			for _, player := range sa.awardedTo[award] {
+				if !sa.deleted[entity.DeletionEntityPlayer+":"+player] && hasNode(sa.awardedTo[other], player) {
+					players++
+				}
+			}
+			if players == 0 {
+				continue
+			}
+
+			pairs = append(pairs, entity.AwardCoOccurrence{
+				Award:           award,
+				AwardTitle:      sa.properties["Reward:"+award]["title"],
+				OtherAward:      other,
+				OtherAwardTitle: sa.properties["Reward:"+other]["title"],
+				Players:         players,
+			})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Players != pairs[j].Players {
+			return pairs[i].Players > pairs[j].Players
+		}
+		if pairs[i].Award != pairs[j].Award {
+			return pairs[i].Award < pairs[j].Award
+		}
+		return pairs[i].OtherAward < pairs[j].OtherAward
+	})
+
+	return firstN(pairs, limit), nil
+}
+
+// GetDegreeCentrality - игроки по убыванию степени в сети совместных награждений в одних матчах
+func (sa *StatAwardsRepo) GetDegreeCentrality(_ context.Context, limit int) ([]entity.PlayerCentrality, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	awarded := make(map[string]bool)
+	byMatch := make(map[string]map[string]bool)
+	for _, g := range sa.liveGrants() {
+		awarded[g.player] = true
+		addToSet(byMatch, g.match, g.player)
+	}
+
+	neighbours := make(map[string]map[string]bool)
+	for _, players := range byMatch {
+		for player := range players {
+			for other := range players {
+				if other != player {
+					addToSet(neighbours, player, other)
+				}
+			}
+		}
+	}
+
+	players := make([]entity.PlayerCentrality, 0, len(neighbours))
+	for player, linked := range neighbours {
+		props := sa.properties["Player:"+player]
+		players = append(players, entity.PlayerCentrality{
+			Player:     player,
+			Name:       props["name"],
+			Surname:    props["surname"],
+			Degree:     len(linked),
+			Centrality: float64(len(linked)) / float64(len(awarded)-1),
+		})
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Degree != players[j].Degree {
+			return players[i].Degree > players[j].Degree
+		}
+		return players[i].Player < players[j].Player
+	})
+
+	return firstN(players, limit), nil
+}
+
+// liveGrants - вручения без удалённых с сохранением истории игроков и матчей: аналитика их не учитывает
+func (sa *StatAwardsRepo) liveGrants() []grant {
+	grants := make([]grant, 0, len(sa.grants))
+	for _, g := range sa.grants {
+		if sa.deleted[entity.DeletionEntityPlayer+":"+g.player] || sa.deleted[entity.DeletionEntityGame+":"+g.match] {
+			continue
+		}
+		grants = append(grants, g)
+	}
+	return grants
+}
+
+func addToSet(sets map[string]map[string]bool, key, value string) {
+	if sets[key] == nil {
+		sets[key] = make(map[string]bool)
+	}
+	sets[key][value] = true
+}
+
+func firstN[T any](items []T, n int) []T {
+	if len(items) > n {
+		return items[:n]
+	}
+	return items
+}
@@ -0,0 +1,50 @@
+package memory_rp
+
+import (
+	"context"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+type AwardRepo struct {
+	awards *collection[entity.Award]
+}
+
+func NewAwardRepo() *AwardRepo {
+	return &AwardRepo{
+		awards: newCollection[entity.Award](apperrors.ErrInvalidAwardID, apperrors.ErrAwardNotFound).withID(setAwardID),
+	}
+}
+
+var _ usecase.AwardRp = (*AwardRepo)(nil)
+
+func (a *AwardRepo) CreateAward(_ context.Context, award *entity.Award) (string, error) {
+	return a.awards.create(award), nil
+}
+
+func (a *AwardRepo) UpdateAward(_ context.Context, awardID string, award *entity.Award) (*entity.Award, error) {
+	if err := a.awards.replace(awardID, award); err != nil {
+		return nil, err
+	}
+	award.ID = awardID
+
+	return award, nil
+}
+
+func (a *AwardRepo) GetAward(_ context.Context, awardID string) (*entity.Award, error) {
+	return a.awards.get(awardID)
+}
+
+func (a *AwardRepo) DeleteAward(_ context.Context, awardID string) error {
+	return a.awards.delete(awardID)
+}
+
+func (a *AwardRepo) GetAwardList(_ context.Context, pageSize, pageNumber int64) ([]*entity.Award, error) {
+	return a.awards.list(pageSize, pageNumber)
+}
+
+func setAwardID(award *entity.Award, id string) {
+	award.ID = id
+}
@@ -0,0 +1,285 @@
+package memory_rp
+
+import (
+	"context"
+	"sort"
+
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+var _ usecase.CareerRp = (*StatAwardsRepo)(nil)
+
+// openEnd - конец текущего членства при сравнении периодов, как coalesce(to, '9999-12-31') в neo4j
+const openEnd = "9999-12-31"
+
+// membership - связь (Player)-[:PLAYED_FOR {from, to}]->(Team), пустой to - текущая команда
+type membership struct {
+	player string
+	team   string
+	from   string
+	to     string
+}
+
+// SetPlayerTeam - закрывает текущие членства в других командах и открывает членство в новой, если его нет
+func (sa *StatAwardsRepo) SetPlayerTeam(_ context.Context, m entity.TeamMembership) error {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	sa.setProperties("Player", m.Player, "name", m.Name, "surname", m.Surname)
+
+	open := false
+	for i := range sa.memberships {
+		current := &sa.memberships[i]
+		if current.player != m.Player || current.to != "" {
+			continue
+		}
+		if current.team == m.Team {
+			open = true
+			continue
+		}
+		current.to = m.Since
+	}
+	if m.Team != "" && !open {
+		sa.memberships = append(sa.memberships, membership{player: m.Player, team: m.Team, from: m.Since})
+	}
+
+	return nil
+}
+
+// RecordAppearances - связи игроков с матчами; повторная запись обновляет команду, соперника и дату
+func (sa *StatAwardsRepo) RecordAppearances(_ context.Context, appearances []entity.Appearance) error {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	for _, appearance := range appearances {
+		sa.recordAppearance(appearance)
+	}
+
+	return nil
+}
+
+func (sa *StatAwardsRepo) recordAppearance(appearance entity.Appearance) {
+	for i, existing := range sa.appearances {
+		if existing.Player == appearance.Player && existing.Match == appearance.Match {
+			sa.appearances[i] = appearance
+			return
+		}
+	}
+	sa.appearances = append(sa.appearances, appearance)
+}
+
+// GetMemberships - членства игрока в командах по дате начала
+func (sa *StatAwardsRepo) GetMemberships(_ context.Context, playerID string) ([]entity.TeamMembership, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	var memberships []entity.TeamMembership
+	for _, m := range sa.memberships {
+		if m.player == playerID {
+			memberships = append(memberships, entity.TeamMembership{Player: m.player, Team: m.team, Since: m.from, Until: m.to})
+		}
+	}
+	sort.Slice(memberships, func(i, j int) bool { return memberships[i].Since < memberships[j].Since })
+
+	return memberships, nil
+}
+
+// ReplaceMemberships - заменяет все членства игрока в командах на memberships
+func (sa *StatAwardsRepo) ReplaceMemberships(_ context.Context, playerID string, memberships []entity.TeamMembership) error {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	sa.memberships = removeMemberships(sa.memberships, playerID)
+	for _, m := range memberships {
+		sa.setProperties("Player", playerID, "name", m.Name, "surname", m.Surname)
+		sa.memberships = append(sa.memberships, membership{player: playerID, team: m.Team, from: m.Since, to: m.Until})
+	}
+
+	return nil
+}
+
+// GetTeammates - игроки с пересекающимся по времени членством в команде или сыгравшие за одну команду в одном матче
+func (sa *StatAwardsRepo) GetTeammates(_ context.Context, playerID string) ([]entity.Teammate, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	teams := make(map[string]map[string]bool)
+	current := make(map[string]bool)
+	for _, a := range sa.memberships {
+		if a.player != playerID {
+			continue
+		}
+		for _, b := range sa.memberships {
+			if b.player == playerID || b.team != a.team || a.from > orOpenEnd(b.to) || b.from > orOpenEnd(a.to) {
+				continue
+			}
+			addToSet(teams, b.player, a.team)
+			if a.to == "" && b.to == "" {
+				current[b.player] = true
+			}
+		}
+	}
+	for _, a := range sa.appearances {
+		if a.Player != playerID || a.Team == "" {
+			continue
+		}
+		for _, b := range sa.appearances {
+			if b.Player != playerID && b.Match == a.Match && b.Team == a.Team {
+				addToSet(teams, b.Player, a.Team)
+			}
+		}
+	}
+
+	teammates := make([]entity.Teammate, 0, len(teams))
+	for player, shared := range teams {
+		props := sa.properties["Player:"+player]
+		teammate := entity.Teammate{
+			Player:  player,
+			Name:    props["name"],
+			Surname: props["surname"],
+			Teams:   []string{},
+			Current: current[player],
+		}
+		for team := range shared {
+			teammate.Teams = append(teammate.Teams, team)
+		}
+		sort.Strings(teammate.Teams)
+		teammates = append(teammates, teammate)
+	}
+	sort.Slice(teammates, func(i, j int) bool {
+		return teammates[i].Player < teammates[j].Player
+	})
+
+	return teammates, nil
+}
+
+func orOpenEnd(date string) string {
+	if date == "" {
+		return openEnd
+	}
+	return date
+}
+
+// GetSeparation - поиск в ширину по связям игрок - матч - игрок, как shortestPath по PLAYED_IN в neo4j
+func (sa *StatAwardsRepo) GetSeparation(_ context.Context, playerID, otherID string, maxDegrees int) (*entity.Separation, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	matchesOf := make(map[string][]string)
+	playersIn := make(map[string][]string)
+	for _, a := range sa.appearances {
+		matchesOf[a.Player] = append(matchesOf[a.Player], a.Match)
+		playersIn[a.Match] = append(playersIn[a.Match], a.Player)
+	}
+
+	// via - игрок и матч, через которые до игрока дошли впервые
+	type via struct {
+		player string
+		match  string
+	}
+	reached := map[string]via{playerID: {}}
+	frontier := []string{playerID}
+	for degree := 0; degree < maxDegrees && len(frontier) != 0; degree++ {
+		var next []string
+		for _, player := range frontier {
+			for _, match := range matchesOf[player] {
+				for _, other := range playersIn[match] {
+					if _, ok := reached[other]; ok {
+						continue
+					}
+					reached[other] = via{player: player, match: match}
+					next = append(next, other)
+				}
+			}
+		}
+		frontier = next
+
+		if _, ok := reached[otherID]; ok {
+			break
+		}
+	}
+
+	if _, ok := reached[otherID]; !ok {
+		return nil, nil
+	}
+
+	var path []entity.SeparationStep
+	for player, match := otherID, ""; ; {
+		props := sa.properties["Player:"+player]
+		path = append([]entity.SeparationStep{{
+			Player:  player,
+			Name:    props["name"],
+			Surname: props["surname"],
+			Match:   match,
+		}}, path...)
+		if player == playerID {
+			break
+		}
+		player, match = reached[player].player, reached[player].match
+	}
+
+	return &entity.Separation{Degrees: len(path) - 1, Path: path}, nil
+}
+
+// GetCommonOpponents - команды-соперники из связей PLAYED_IN обоих игроков
+func (sa *StatAwardsRepo) GetCommonOpponents(_ context.Context, playerID, otherID string) ([]entity.CommonOpponent, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	games := make(map[string]int)
+	otherGames := make(map[string]int)
+	for _, a := range sa.appearances {
+		if a.Opponent == "" {
+			continue
+		}
+		switch a.Player {
+		case playerID:
+			games[a.Opponent]++
+		case otherID:
+			otherGames[a.Opponent]++
+		}
+	}
+
+	opponents := make([]entity.CommonOpponent, 0)
+	for team, count := range games {
+		if otherGames[team] == 0 {
+			continue
+		}
+		opponents = append(opponents, entity.CommonOpponent{
+			Team:       team,
+			Games:      count,
+			OtherGames: otherGames[team],
+		})
+	}
+	sort.Slice(opponents, func(i, j int) bool {
+		ti := opponents[i].Games + opponents[i].OtherGames
+		tj := opponents[j].Games + opponents[j].OtherGames
+		if ti != tj {
+			return ti > tj
+		}
+		return opponents[i].Team < opponents[j].Team
+	})
+
+	return opponents, nil
+}
+
+func removeMemberships(memberships []membership, playerID string) []membership {
+	kept := memberships[:0]
+	for _, m := range memberships {
+		if m.player != playerID {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+func removeAppearances(appearances []entity.Appearance, match func(entity.Appearance) bool) []entity.Appearance {
+	kept := appearances[:0]
+	for _, a := range appearances {
+		if !match(a) {
+			kept = append(kept, a)
+		}
+	}
+	return kept
+}
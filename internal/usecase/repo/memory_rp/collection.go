@@ -0,0 +1,239 @@
+package memory_rp
+
+import (
+	"fmt"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// collection - потокобезопасное хранилище документов, повторяющее поведение коллекции mongodb:
+// идентификаторы в формате ObjectID, порядок вставки и пагинация через limit/skip
+type collection[T any] struct {
+	mu   sync.RWMutex
+	ids  []string
+	docs map[string]T
+	// tombstones - документы, убранные из коллекции с сохранением, аналог коллекции <name>_tombstones
+	tombstones map[string]T
+
+	errInvalidID error
+	errNotFound  error
+
+	// clone - глубокое копирование документа, чтобы вызывающий код не делил с хранилищем срезы
+	clone func(T) T
+	// setID - проставляет id в документ при чтении, если сущность его хранит
+	setID func(doc *T, id string)
+}
+
+func newCollection[T any](errInvalidID, errNotFound error) *collection[T] {
+	return &collection[T]{
+		docs:         make(map[string]T),
+		tombstones:   make(map[string]T),
+		errInvalidID: errInvalidID,
+		errNotFound:  errNotFound,
+		clone:        func(doc T) T { return doc },
+		setID:        func(*T, string) {},
+	}
+}
+
+// withID - задаёт заполнение id при чтении документа
+func (c *collection[T]) withID(setID func(doc *T, id string)) *collection[T] {
+	c.setID = setID
+	return c
+}
+
+// withClone - задаёт глубокое копирование для документов со срезами
+func (c *collection[T]) withClone(clone func(T) T) *collection[T] {
+	c.clone = clone
+	return c
+}
+
+func (c *collection[T]) checkID(id string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return c.errInvalidID
+	}
+	return nil
+}
+
+func (c *collection[T]) create(doc *T) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := primitive.NewObjectID().Hex()
+	c.ids = append(c.ids, id)
+	c.docs[id] = c.clone(*doc)
+
+	return id
+}
+
+func (c *collection[T]) replace(id string, doc *T) error {
+	if err := c.checkID(id); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.docs[id]; !ok {
+		return c.errNotFound
+	}
+	c.docs[id] = c.clone(*doc)
+
+	return nil
+}
+
+// update - аналог FindOneAndUpdate: change меняет документ на месте или возвращает ошибку, если он не подходит под фильтр
+func (c *collection[T]) update(id string, change func(doc *T) error) (*T, error) {
+	if err := c.checkID(id); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	doc, ok := c.docs[id]
+	if !ok {
+		return nil, c.errNotFound
+	}
+	doc = c.clone(doc)
+	if err := change(&doc); err != nil {
+		return nil, err
+	}
+	c.docs[id] = c.clone(doc)
+	c.setID(&doc, id)
+
+	return &doc, nil
+}
+
+func (c *collection[T]) get(id string) (*T, error) {
+	if err := c.checkID(id); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	doc, ok := c.docs[id]
+	if !ok {
+		return nil, c.errNotFound
+	}
+	doc = c.clone(doc)
+	c.setID(&doc, id)
+
+	return &doc, nil
+}
+
+func (c *collection[T]) delete(id string) error {
+	if err := c.checkID(id); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.docs[id]; !ok {
+		return c.errNotFound
+	}
+	c.remove(id)
+
+	return nil
+}
+
+// remove - удаляет документ, вызывается под c.mu
+func (c *collection[T]) remove(id string) {
+	delete(c.docs, id)
+	for i := range c.ids {
+		if c.ids[i] == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+}
+
+// tombstone - переносит документ из коллекции в tombstones; повторный вызов для перенесённого документа не ошибка
+func (c *collection[T]) tombstone(id string) error {
+	if err := c.checkID(id); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	doc, ok := c.docs[id]
+	if !ok {
+		if _, buried := c.tombstones[id]; buried {
+			return nil
+		}
+		return c.errNotFound
+	}
+	c.tombstones[id] = doc
+	c.remove(id)
+
+	return nil
+}
+
+// streamIDs - передаёт в yield id документов коллекции или tombstones; yield вызывается без блокировки
+func (c *collection[T]) streamIDs(tombstoned bool, yield func(id string) error) error {
+	c.mu.RLock()
+	var ids []string
+	if tombstoned {
+		for id := range c.tombstones {
+			ids = append(ids, id)
+		}
+	} else {
+		ids = append(ids, c.ids...)
+	}
+	c.mu.RUnlock()
+
+	for _, id := range ids {
+		if err := yield(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// list - аналог Find с SetLimit(pageSize) и SetSkip((pageNumber-1)*pageSize)
+func (c *collection[T]) list(pageSize, pageNumber int64) ([]*T, error) {
+	skip := (pageNumber - 1) * pageSize
+	if skip < 0 {
+		return nil, fmt.Errorf("memory error: skip must be non-negative, got %d", skip)
+	}
+	// отрицательный limit в mongodb означает один батч из |limit| документов
+	if pageSize < 0 {
+		pageSize = -pageSize
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var docs []*T
+	for i := skip; i < int64(len(c.ids)); i++ {
+		if pageSize != 0 && int64(len(docs)) >= pageSize {
+			break
+		}
+		doc := c.clone(c.docs[c.ids[i]])
+		c.setID(&doc, c.ids[i])
+		docs = append(docs, &doc)
+	}
+
+	return docs, nil
+}
+
+// find - аналог Find с фильтром без пагинации, в порядке вставки
+func (c *collection[T]) find(match func(*T) bool) []*T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var docs []*T
+	for _, id := range c.ids {
+		doc := c.clone(c.docs[id])
+		c.setID(&doc, id)
+		if match(&doc) {
+			docs = append(docs, &doc)
+		}
+	}
+
+	return docs
+}
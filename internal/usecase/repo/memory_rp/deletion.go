@@ -0,0 +1,252 @@
+package memory_rp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// statOwner - поле строки статистики, по которому ищутся строки удаляемой сущности
+func statOwner(entityKind string) (func(entity.PlayerStat) string, error) {
+	switch entityKind {
+	case entity.DeletionEntityPlayer:
+		return func(stat entity.PlayerStat) string { return stat.PlayerID }, nil
+	case entity.DeletionEntityGame:
+		return func(stat entity.PlayerStat) string { return stat.MatchID }, nil
+	default:
+		return nil, fmt.Errorf("player stats are not linked to %q", entityKind)
+	}
+}
+
+// HasStats - есть ли у игрока или матча строки статистики
+func (s *StatPlayerRepo) HasStats(_ context.Context, entityKind, id string) (bool, error) {
+	owner, err := statOwner(entityKind)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, stat := range s.stats {
+		if owner(stat) == id {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// DeleteStats - удаляет строки статистики игрока или матча; сезонные суммы считаются по строкам и пересборки не требуют
+func (s *StatPlayerRepo) DeleteStats(_ context.Context, entityKind, id string) error {
+	owner, err := statOwner(entityKind)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.stats[:0]
+	for _, stat := range s.stats {
+		if owner(stat) != id {
+			kept = append(kept, stat)
+		}
+	}
+	s.stats = kept
+
+	return nil
+}
+
+// HasRecords - есть ли у узла игрока или матча связи с наградами
+func (sa *StatAwardsRepo) HasRecords(_ context.Context, entityKind, id string) (bool, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	switch entityKind {
+	case entity.DeletionEntityPlayer:
+		for _, players := range sa.awardedTo {
+			if hasNode(players, id) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case entity.DeletionEntityGame:
+		if len(sa.partOfTournament[id]) != 0 {
+			return true, nil
+		}
+		for _, matches := range sa.awardedForMatch {
+			if hasNode(matches, id) {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("awards graph has no nodes for %q", entityKind)
+	}
+}
+
+// DeleteNode - удаляет узел игрока или матча со всеми связями; узлы наград общие для всех награждённых и остаются
+func (sa *StatAwardsRepo) DeleteNode(_ context.Context, entityKind, id string) error {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	switch entityKind {
+	case entity.DeletionEntityPlayer:
+		for reward, players := range sa.awardedTo {
+			sa.awardedTo[reward] = removeNode(players, id)
+		}
+		delete(sa.properties, "Player:"+id)
+		sa.grants = removeGrants(sa.grants, func(g grant) bool { return g.player == id })
+		sa.memberships = removeMemberships(sa.memberships, id)
+		sa.appearances = removeAppearances(sa.appearances, func(a entity.Appearance) bool { return a.Player == id })
+	case entity.DeletionEntityGame:
+		sa.matches = removeNode(sa.matches, id)
+		for reward, matches := range sa.awardedForMatch {
+			sa.awardedForMatch[reward] = removeNode(matches, id)
+		}
+		delete(sa.partOfTournament, id)
+		delete(sa.properties, "Match:"+id)
+		sa.grants = removeGrants(sa.grants, func(g grant) bool { return g.match == id })
+		sa.appearances = removeAppearances(sa.appearances, func(a entity.Appearance) bool { return a.Match == id })
+	default:
+		return fmt.Errorf("awards graph has no nodes for %q", entityKind)
+	}
+	delete(sa.deleted, entityKind+":"+id)
+
+	return nil
+}
+
+// TombstoneNode - помечает узел удалённым, связи и история наград сохраняются
+func (sa *StatAwardsRepo) TombstoneNode(_ context.Context, entityKind, id string) error {
+	if entityKind != entity.DeletionEntityPlayer && entityKind != entity.DeletionEntityGame {
+		return fmt.Errorf("awards graph has no nodes for %q", entityKind)
+	}
+
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	sa.deleted[entityKind+":"+id] = true
+
+	return nil
+}
+
+func removeNode(nodes []string, id string) []string {
+	kept := nodes[:0]
+	for _, node := range nodes {
+		if node != id {
+			kept = append(kept, node)
+		}
+	}
+	return kept
+}
+
+func removeGrants(grants []grant, match func(grant) bool) []grant {
+	kept := grants[:0]
+	for _, g := range grants {
+		if !match(g) {
+			kept = append(kept, g)
+		}
+	}
+	return kept
+}
+
+// StreamStatIDs - различные id игроков или матчей в строках статистики
+func (s *StatPlayerRepo) StreamStatIDs(_ context.Context, entityKind string, yield func(id string) error) error {
+	owner, err := statOwner(entityKind)
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	seen := make(map[string]struct{})
+	var ids []string
+	for _, stat := range s.stats {
+		id := owner(stat)
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, id := range ids {
+		if err = yield(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StreamAppearances - пары игрок-матч из статистики с командой и датой матча; по ним восстанавливается граф карьеры
+func (s *StatPlayerRepo) StreamAppearances(_ context.Context, yield func(appearance entity.Appearance) error) error {
+	s.mu.RLock()
+	seen := make(map[string]struct{})
+	var appearances []entity.Appearance
+	for _, stat := range s.stats {
+		key := stat.PlayerID + ":" + stat.MatchID
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		appearance := entity.Appearance{Player: stat.PlayerID, Match: stat.MatchID, Team: stat.TeamID}
+		if date, err := time.Parse(entity.GameDateLayout, stat.GameDate); err == nil {
+			appearance.Date = date.Format(entity.CareerDateLayout)
+		}
+		appearances = append(appearances, appearance)
+	}
+	s.mu.RUnlock()
+
+	for _, appearance := range appearances {
+		if err := yield(appearance); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StreamNodeIDs - id узлов игроков или матчей и отметка удаления
+func (sa *StatAwardsRepo) StreamNodeIDs(_ context.Context, entityKind string, yield func(id string, deleted bool) error) error {
+	sa.mu.RLock()
+	var ids []string
+	switch entityKind {
+	case entity.DeletionEntityPlayer:
+		for _, reward := range sa.rewards {
+			for _, player := range sa.awardedTo[reward] {
+				ids = mergeNode(ids, player)
+			}
+		}
+		for _, m := range sa.memberships {
+			ids = mergeNode(ids, m.player)
+		}
+		for _, a := range sa.appearances {
+			ids = mergeNode(ids, a.Player)
+		}
+	case entity.DeletionEntityGame:
+		ids = append(ids, sa.matches...)
+		for _, a := range sa.appearances {
+			ids = mergeNode(ids, a.Match)
+		}
+	default:
+		sa.mu.RUnlock()
+		return fmt.Errorf("awards graph has no nodes for %q", entityKind)
+	}
+	deleted := make([]bool, len(ids))
+	for i, id := range ids {
+		deleted[i] = sa.deleted[entityKind+":"+id]
+	}
+	sa.mu.RUnlock()
+
+	for i, id := range ids {
+		if err := yield(id, deleted[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
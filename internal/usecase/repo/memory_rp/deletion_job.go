@@ -0,0 +1,61 @@
+package memory_rp
+
+import (
+	"context"
+	"time"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+type DeletionJobRepo struct {
+	jobs *collection[entity.DeletionJob]
+}
+
+func NewDeletionJobRepo() *DeletionJobRepo {
+	return &DeletionJobRepo{
+		jobs: newCollection[entity.DeletionJob](apperrors.ErrInvalidDeletionJobID, apperrors.ErrDeletionJobNotFound).
+			withID(setDeletionJobID).
+			withClone(cloneDeletionJob),
+	}
+}
+
+var _ usecase.DeletionJobRp = (*DeletionJobRepo)(nil)
+
+func (d *DeletionJobRepo) CreateJob(_ context.Context, job *entity.DeletionJob) (string, error) {
+	return d.jobs.create(job), nil
+}
+
+func (d *DeletionJobRepo) UpdateJob(_ context.Context, job *entity.DeletionJob) error {
+	return d.jobs.replace(job.ID, job)
+}
+
+func (d *DeletionJobRepo) GetJob(_ context.Context, jobID string) (*entity.DeletionJob, error) {
+	return d.jobs.get(jobID)
+}
+
+func (d *DeletionJobRepo) GetJobsByStatus(_ context.Context, status string) ([]*entity.DeletionJob, error) {
+	return d.jobs.find(func(job *entity.DeletionJob) bool {
+		return job.Status == status
+	}), nil
+}
+
+func (d *DeletionJobRepo) ClaimJob(_ context.Context, jobID string, now, until time.Time) (*entity.DeletionJob, error) {
+	return d.jobs.update(jobID, func(job *entity.DeletionJob) error {
+		if job.Status == entity.DeletionJobDone || job.LockedUntil.After(now) {
+			return apperrors.ErrDeletionJobRunning
+		}
+		job.LockedUntil = until
+		return nil
+	})
+}
+
+func setDeletionJobID(job *entity.DeletionJob, id string) {
+	job.ID = id
+}
+
+func cloneDeletionJob(job entity.DeletionJob) entity.DeletionJob {
+	job.Steps = append([]entity.DeletionStep(nil), job.Steps...)
+	return job
+}
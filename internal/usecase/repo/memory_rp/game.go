@@ -0,0 +1,78 @@
+package memory_rp
+
+import (
+	"context"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+type GameRepo struct {
+	games *collection[entity.Game]
+}
+
+func NewGameRepo() *GameRepo {
+	return &GameRepo{
+		games: newCollection[entity.Game](apperrors.ErrInvalidGameID, apperrors.ErrGameNotFound).withClone(cloneGame),
+	}
+}
+
+var _ usecase.GameRp = (*GameRepo)(nil)
+
+func (g *GameRepo) CreateGame(_ context.Context, game *entity.Game) (string, error) {
+	return g.games.create(game), nil
+}
+
+func (g *GameRepo) CreateGames(_ context.Context, games []*entity.Game) ([]string, error) {
+	gameIDs := make([]string, 0, len(games))
+	for _, game := range games {
+		gameIDs = append(gameIDs, g.games.create(game))
+	}
+	return gameIDs, nil
+}
+
+func (g *GameRepo) UpdateGame(_ context.Context, gameID string, game *entity.Game) (*entity.Game, error) {
+	if err := g.games.replace(gameID, game); err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
+func (g *GameRepo) GetGame(_ context.Context, gameID string) (*entity.Game, error) {
+	return g.games.get(gameID)
+}
+
+func (g *GameRepo) DeleteGame(_ context.Context, gameID string) error {
+	return g.games.delete(gameID)
+}
+
+func (g *GameRepo) GetGameList(_ context.Context, pageSize, pageNumber int64) ([]*entity.Game, error) {
+	return g.games.list(pageSize, pageNumber)
+}
+
+func (g *GameRepo) GetGamesByTeam(_ context.Context, teamID string) ([]*entity.Game, error) {
+	return g.games.find(func(game *entity.Game) bool {
+		return game.FirstTeam == teamID || game.SecondTeam == teamID
+	}), nil
+}
+
+func cloneGame(game entity.Game) entity.Game {
+	game.Periods = append([]entity.PeriodScore(nil), game.Periods...)
+	return game
+}
+
+func (g *GameRepo) GetGamesByLeague(_ context.Context, leagueID string) ([]*entity.Game, error) {
+	return g.games.find(func(game *entity.Game) bool {
+		return game.League == leagueID
+	}), nil
+}
+
+func (g *GameRepo) TombstoneGame(_ context.Context, gameID string) error {
+	return g.games.tombstone(gameID)
+}
+
+func (g *GameRepo) StreamGameIDs(_ context.Context, tombstoned bool, yield func(gameID string) error) error {
+	return g.games.streamIDs(tombstoned, yield)
+}
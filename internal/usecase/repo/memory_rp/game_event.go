@@ -0,0 +1,87 @@
+package memory_rp
+
+import (
+	"context"
+	"sync"
+
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+type GameEventRepo struct {
+	mu     sync.RWMutex
+	events map[string][]entity.GameEvent
+}
+
+func NewGameEventRepo() *GameEventRepo {
+	return &GameEventRepo{
+		events: make(map[string][]entity.GameEvent),
+	}
+}
+
+var _ usecase.GameEventRp = (*GameEventRepo)(nil)
+
+func (g *GameEventRepo) AddEvents(_ context.Context, events []*entity.GameEvent) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, event := range events {
+		g.events[event.Game] = append(g.events[event.Game], *event)
+	}
+
+	return nil
+}
+
+func (g *GameEventRepo) GetEventsByGame(_ context.Context, gameID string) ([]*entity.GameEvent, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var events []*entity.GameEvent
+	for _, event := range g.events[gameID] {
+		event := event
+		events = append(events, &event)
+	}
+
+	return events, nil
+}
+
+func (g *GameEventRepo) DeleteEventsByGame(_ context.Context, gameID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.events, gameID)
+
+	return nil
+}
+
+func (g *GameEventRepo) HasEventsByPlayer(_ context.Context, playerID string) (bool, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	for _, events := range g.events {
+		for _, event := range events {
+			if event.Player == playerID || event.Replaced == playerID {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (g *GameEventRepo) DeleteEventsByPlayer(_ context.Context, playerID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for gameID, events := range g.events {
+		kept := events[:0]
+		for _, event := range events {
+			if event.Player != playerID && event.Replaced != playerID {
+				kept = append(kept, event)
+			}
+		}
+		g.events[gameID] = kept
+	}
+
+	return nil
+}
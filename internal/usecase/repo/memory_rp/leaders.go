@@ -0,0 +1,102 @@
+package memory_rp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// leaderFields - значения показателей entity.LeaderStats в строке статистики
+var leaderFields = map[string]func(entity.PlayerStat) float64{
+	"points":            func(s entity.PlayerStat) float64 { return float64(s.Points) },
+	"rebounds":          func(s entity.PlayerStat) float64 { return float64(s.Rebounds) },
+	"assists":           func(s entity.PlayerStat) float64 { return float64(s.Assists) },
+	"steals":            func(s entity.PlayerStat) float64 { return float64(s.Steals) },
+	"blocks":            func(s entity.PlayerStat) float64 { return float64(s.Blocks) },
+	"turnovers":         func(s entity.PlayerStat) float64 { return float64(s.Turnovers) },
+	"fgm":               func(s entity.PlayerStat) float64 { return float64(s.FGM) },
+	"fga":               func(s entity.PlayerStat) float64 { return float64(s.FGA) },
+	"threePm":           func(s entity.PlayerStat) float64 { return float64(s.ThreePM) },
+	"threePa":           func(s entity.PlayerStat) float64 { return float64(s.ThreePA) },
+	"ftm":               func(s entity.PlayerStat) float64 { return float64(s.FTM) },
+	"fta":               func(s entity.PlayerStat) float64 { return float64(s.FTA) },
+	"offensiveRebounds": func(s entity.PlayerStat) float64 { return float64(s.OffensiveRebounds) },
+	"defensiveRebounds": func(s entity.PlayerStat) float64 { return float64(s.DefensiveRebounds) },
+	"personalFouls":     func(s entity.PlayerStat) float64 { return float64(s.PersonalFouls) },
+	"plusMinus":         func(s entity.PlayerStat) float64 { return float64(s.PlusMinus) },
+	"minutes":           func(s entity.PlayerStat) float64 { return s.Minutes },
+}
+
+// Рейтинг игроков по показателю; равные значения делят место, как RANK в ClickHouse
+func (s *StatPlayerRepo) GetLeaders(_ context.Context, req entity.LeadersRequest) ([]entity.Leader, error) {
+	field, ok := leaderFields[req.Stat]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown stat %q", apperrors.ErrInvalidLeaders, req.Stat)
+	}
+
+	type aggregate struct {
+		matches        map[string]bool
+		total, minutes float64
+	}
+
+	s.mu.RLock()
+	players := make(map[string]*aggregate)
+	for _, stat := range s.stats {
+		if (req.Season != "" && stat.Season != req.Season) ||
+			(req.League != "" && stat.LeagueID != req.League) ||
+			(req.Team != "" && stat.TeamID != req.Team) {
+			continue
+		}
+		agg, ok := players[stat.PlayerID]
+		if !ok {
+			agg = &aggregate{matches: make(map[string]bool)}
+			players[stat.PlayerID] = agg
+		}
+		agg.matches[stat.MatchID] = true
+		agg.total += field(stat)
+		agg.minutes += stat.Minutes
+	}
+	s.mu.RUnlock()
+
+	var leaders []entity.Leader
+	for playerID, agg := range players {
+		games := len(agg.matches)
+		if games < req.MinGames {
+			continue
+		}
+
+		leader := entity.Leader{PlayerID: playerID, Games: games}
+		switch req.Per {
+		case entity.LeaderTotal:
+			leader.Value = agg.total
+		case entity.LeaderPerGame:
+			leader.Value = div(agg.total, float64(games))
+		case entity.LeaderPer36Min:
+			leader.Value = div(agg.total*36, agg.minutes)
+		default:
+			return nil, fmt.Errorf("%w: unknown per %q", apperrors.ErrInvalidLeaders, req.Per)
+		}
+		leaders = append(leaders, leader)
+	}
+
+	sort.Slice(leaders, func(i, j int) bool {
+		if leaders[i].Value != leaders[j].Value {
+			return leaders[i].Value > leaders[j].Value
+		}
+		return leaders[i].PlayerID < leaders[j].PlayerID
+	})
+	for i := range leaders {
+		leaders[i].Rank = i + 1
+		if i > 0 && leaders[i].Value == leaders[i-1].Value {
+			leaders[i].Rank = leaders[i-1].Rank
+		}
+	}
+	if len(leaders) > req.Limit {
+		leaders = leaders[:req.Limit]
+	}
+
+	return leaders, nil
+}
@@ -0,0 +1,50 @@
+package memory_rp
+
+import (
+	"context"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+type LeagueRepo struct {
+	leagues *collection[entity.League]
+}
+
+func NewLeagueRepo() *LeagueRepo {
+	return &LeagueRepo{
+		leagues: newCollection[entity.League](apperrors.ErrInvalidLeagueID, apperrors.ErrLeagueNotFound).withClone(cloneLeague),
+	}
+}
+
+var _ usecase.LeagueRp = (*LeagueRepo)(nil)
+
+func (l *LeagueRepo) CreateLeague(_ context.Context, league *entity.League) (string, error) {
+	return l.leagues.create(league), nil
+}
+
+func (l *LeagueRepo) UpdateLeague(_ context.Context, leagueID string, league *entity.League) (*entity.League, error) {
+	if err := l.leagues.replace(leagueID, league); err != nil {
+		return nil, err
+	}
+
+	return league, nil
+}
+
+func (l *LeagueRepo) GetLeague(_ context.Context, leagueID string) (*entity.League, error) {
+	return l.leagues.get(leagueID)
+}
+
+func (l *LeagueRepo) DeleteLeague(_ context.Context, leagueID string) error {
+	return l.leagues.delete(leagueID)
+}
+
+func (l *LeagueRepo) GetLeagueList(_ context.Context, pageSize, pageNumber int64) ([]*entity.League, error) {
+	return l.leagues.list(pageSize, pageNumber)
+}
+
+func cloneLeague(league entity.League) entity.League {
+	league.TieBreakers = append([]string(nil), league.TieBreakers...)
+	return league
+}
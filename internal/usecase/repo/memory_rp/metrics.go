@@ -0,0 +1,179 @@
+package memory_rp
+
+import (
+	"context"
+	"time"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// totals - суммы box score по набору строк player_stats.
+// Поля с суффиксом k - только по строкам с известными попытками бросков, как _k в запросе ChouseRepo
+type totals struct {
+	min, pts, fgm, fga, tpm, ftm, fta, orb, trb, ast, stl, blk, tov, pf float64
+	ptsk, fgmk, tpmk, ftak                                              float64
+}
+
+func (t *totals) add(stat entity.PlayerStat) {
+	t.min += stat.Minutes
+	t.pts += float64(stat.Points)
+	t.fgm += float64(stat.FGM)
+	t.fga += float64(stat.FGA)
+	t.tpm += float64(stat.ThreePM)
+	t.ftm += float64(stat.FTM)
+	t.fta += float64(stat.FTA)
+	t.orb += float64(stat.OffensiveRebounds)
+	t.trb += float64(stat.Rebounds)
+	t.ast += float64(stat.Assists)
+	t.stl += float64(stat.Steals)
+	t.blk += float64(stat.Blocks)
+	t.tov += float64(stat.Turnovers)
+	t.pf += float64(stat.PersonalFouls)
+	if stat.FGA == 0 && stat.FGM > 0 {
+		return
+	}
+	t.ptsk += float64(stat.Points)
+	t.fgmk += float64(stat.FGM)
+	t.tpmk += float64(stat.ThreePM)
+	t.ftak += float64(stat.FTA)
+}
+
+// Продвинутые метрики игрока: те же формулы, что и в запросе ChouseRepo.GetPlayerMetrics
+func (s *StatPlayerRepo) GetPlayerMetrics(_ context.Context, playerID string, scope entity.StatScope) (*entity.PlayerMetrics, error) {
+	inScope, err := scopeFilter(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var p, t, lg totals
+	matches := make(map[string]bool)
+	teamMatches := make(map[[2]string]bool)
+	for _, stat := range s.stats {
+		if !inScope(stat) {
+			continue
+		}
+		lg.add(stat)
+		if stat.PlayerID == playerID {
+			p.add(stat)
+			matches[stat.MatchID] = true
+			teamMatches[[2]string{stat.TeamID, stat.MatchID}] = true
+		}
+	}
+	if len(matches) == 0 {
+		return nil, apperrors.ErrPlayerStatsNotFound
+	}
+	// команда считается по всем строкам её матчей, как (team_id, match_id) IN (...) в ClickHouse
+	for _, stat := range s.stats {
+		if teamMatches[[2]string{stat.TeamID, stat.MatchID}] {
+			t.add(stat)
+		}
+	}
+
+	metrics := &entity.PlayerMetrics{
+		PlayerID:     playerID,
+		Scope:        scope,
+		Games:        len(matches),
+		Minutes:      p.min,
+		Points:       int(p.pts),
+		TrueShooting: div(p.ptsk, 2*(p.fga+0.44*p.ftak)),
+		EffectiveFG:  div(p.fgmk+0.5*p.tpmk, p.fga),
+		PER:          div(uper(p, t, lg)*15, uper(lg, lg, lg)),
+	}
+	if p.min != 0 && t.fga+0.44*t.fta+t.tov != 0 {
+		metrics.Usage = (p.fga + 0.44*p.fta + p.tov) * (t.min / 5) / (p.min * (t.fga + 0.44*t.fta + t.tov))
+	}
+	metrics.AssistTurnover = p.ast
+	if p.tov != 0 {
+		metrics.AssistTurnover = p.ast / p.tov
+	}
+
+	per36 := div(36, p.min)
+	tPoss := t.fga + 0.44*t.fta - t.orb + t.tov
+	var per100 float64
+	if p.min != 0 && t.min != 0 && tPoss != 0 {
+		per100 = 100 / (tPoss * p.min / (t.min / 5))
+	}
+	metrics.Per36 = statLine(p, per36)
+	metrics.Per100 = statLine(p, per100)
+
+	return metrics, nil
+}
+
+// uper - PER Холлинджера без поправки на темп; team - суммы команды игрока, lg - суммы всей выборки
+func uper(p, team, lg totals) float64 {
+	if p.min == 0 {
+		return 0
+	}
+
+	lgRatio := div(lg.ast, lg.fgm)
+	tRatio := div(team.ast, team.fgm)
+	factor := 2.0 / 3
+	if lg.fgm != 0 && lg.ftm != 0 {
+		factor = 2.0/3 - (0.5*lgRatio)/(2*(lg.fgm/lg.ftm))
+	}
+	vop := div(lg.pts, lg.fga-lg.orb+lg.tov+0.44*lg.fta)
+	drbp := div(lg.trb-lg.orb, lg.trb)
+	var pfValue float64
+	if lg.pf != 0 {
+		pfValue = lg.ftm/lg.pf - 0.44*(lg.fta/lg.pf)*vop
+	}
+
+	return (p.tpm + (2.0/3)*p.ast + (2-factor*tRatio)*p.fgm + p.ftm*0.5*(1+(1-tRatio)+(2.0/3)*tRatio) -
+		vop*p.tov - vop*drbp*(p.fga-p.fgmk) - vop*0.44*(0.44+0.56*drbp)*(p.fta-p.ftm) +
+		vop*(1-drbp)*(p.trb-p.orb) + vop*drbp*p.orb + vop*p.stl + vop*drbp*p.blk - p.pf*pfValue) / p.min
+}
+
+func statLine(p totals, factor float64) entity.StatLine {
+	return entity.StatLine{
+		Points:    p.pts * factor,
+		Rebounds:  p.trb * factor,
+		Assists:   p.ast * factor,
+		Steals:    p.stl * factor,
+		Blocks:    p.blk * factor,
+		Turnovers: p.tov * factor,
+	}
+}
+
+func div(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
+
+// scopeFilter - аналог WHERE по выборке матчей; строки без даты матча в интервал дат не попадают
+func scopeFilter(scope entity.StatScope) (func(entity.PlayerStat) bool, error) {
+	var from, to time.Time
+	var err error
+	if scope.From != "" {
+		if from, err = time.Parse(entity.GameDateLayout, scope.From); err != nil {
+			return nil, err
+		}
+	}
+	if scope.To != "" {
+		if to, err = time.Parse(entity.GameDateLayout, scope.To); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(stat entity.PlayerStat) bool {
+		if scope.MatchID != "" && stat.MatchID != scope.MatchID {
+			return false
+		}
+		if scope.Season != "" && stat.Season != scope.Season {
+			return false
+		}
+		if scope.From == "" && scope.To == "" {
+			return true
+		}
+		date, err := time.Parse(entity.GameDateLayout, stat.GameDate)
+		if err != nil {
+			return false
+		}
+		return (from.IsZero() || !date.Before(from)) && (to.IsZero() || !date.After(to))
+	}, nil
+}
@@ -0,0 +1,70 @@
+package memory_rp
+
+import (
+	"context"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+type PlayerRepo struct {
+	players *collection[entity.Player]
+}
+
+func NewPlayerRepo() *PlayerRepo {
+	return &PlayerRepo{
+		players: newCollection[entity.Player](apperrors.ErrInvalidPlayerID, apperrors.ErrPlayerNotFound),
+	}
+}
+
+var _ usecase.PlayerRp = (*PlayerRepo)(nil)
+
+func (p *PlayerRepo) CreatePlayer(_ context.Context, player *entity.Player) (string, error) {
+	return p.players.create(player), nil
+}
+
+func (p *PlayerRepo) UpdatePlayer(_ context.Context, playerID string, player *entity.Player) (*entity.Player, error) {
+	if err := p.players.replace(playerID, player); err != nil {
+		return nil, err
+	}
+
+	return player, nil
+}
+
+func (p *PlayerRepo) GetPlayer(_ context.Context, playerID string) (*entity.Player, error) {
+	return p.players.get(playerID)
+}
+
+func (p *PlayerRepo) DeletePlayer(_ context.Context, playerID string) error {
+	return p.players.delete(playerID)
+}
+
+func (p *PlayerRepo) GetPlayerList(_ context.Context, pageSize, pageNumber int64) ([]*entity.Player, error) {
+	return p.players.list(pageSize, pageNumber)
+}
+
+func (p *PlayerRepo) GetPlayersByTeam(_ context.Context, teamID string) ([]*entity.Player, error) {
+	return p.players.find(func(player *entity.Player) bool {
+		return player.Team == teamID
+	}), nil
+}
+
+func (p *PlayerRepo) GetPlayersByIDs(_ context.Context, playerIDs []string) (map[string]*entity.Player, error) {
+	players := make(map[string]*entity.Player, len(playerIDs))
+	for _, playerID := range playerIDs {
+		if player, err := p.players.get(playerID); err == nil {
+			players[playerID] = player
+		}
+	}
+
+	return players, nil
+}
+
+func (p *PlayerRepo) TombstonePlayer(_ context.Context, playerID string) error {
+	return p.players.tombstone(playerID)
+}
+
+func (p *PlayerRepo) StreamPlayerIDs(_ context.Context, tombstoned bool, yield func(playerID string) error) error {
+	return p.players.streamIDs(tombstoned, yield)
+}
@@ -0,0 +1,86 @@
+package memory_rp
+
+import (
+	"context"
+	"sync"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+type PlayoffRepo struct {
+	mu       sync.RWMutex
+	brackets map[string]entity.PlayoffBracket
+}
+
+func NewPlayoffRepo() *PlayoffRepo {
+	return &PlayoffRepo{
+		brackets: make(map[string]entity.PlayoffBracket),
+	}
+}
+
+var _ usecase.PlayoffRp = (*PlayoffRepo)(nil)
+
+func (p *PlayoffRepo) CreateBracket(_ context.Context, bracket *entity.PlayoffBracket) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.brackets[bracket.League]; ok {
+		return apperrors.ErrPlayoffsAlreadyExist
+	}
+	p.brackets[bracket.League] = cloneBracket(*bracket)
+
+	return nil
+}
+
+func (p *PlayoffRepo) GetBracket(_ context.Context, leagueID string) (*entity.PlayoffBracket, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	bracket, ok := p.brackets[leagueID]
+	if !ok {
+		return nil, apperrors.ErrPlayoffsNotFound
+	}
+	bracket = cloneBracket(bracket)
+
+	return &bracket, nil
+}
+
+func (p *PlayoffRepo) UpdateBracket(_ context.Context, bracket *entity.PlayoffBracket) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.brackets[bracket.League]; !ok {
+		return apperrors.ErrPlayoffsNotFound
+	}
+	p.brackets[bracket.League] = cloneBracket(*bracket)
+
+	return nil
+}
+
+func cloneBracket(bracket entity.PlayoffBracket) entity.PlayoffBracket {
+	bracket.Seeds = append([]entity.PlayoffSeed(nil), bracket.Seeds...)
+
+	rounds := make([]entity.PlayoffRound, len(bracket.Rounds))
+	for i, round := range bracket.Rounds {
+		series := make([]entity.PlayoffSeries, len(round.Series))
+		for j, s := range round.Series {
+			if s.Top != nil {
+				top := *s.Top
+				s.Top = &top
+			}
+			if s.Bottom != nil {
+				bottom := *s.Bottom
+				s.Bottom = &bottom
+			}
+			s.Games = append([]string(nil), s.Games...)
+			series[j] = s
+		}
+		round.Series = series
+		rounds[i] = round
+	}
+	bracket.Rounds = rounds
+
+	return bracket
+}
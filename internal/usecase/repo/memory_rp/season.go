@@ -0,0 +1,115 @@
+package memory_rp
+
+import (
+	"context"
+	"sort"
+
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// seasonGroup - аналог строки сезонного представления: суммы и множество матчей
+type seasonGroup struct {
+	season, league string
+	teams          map[string]bool
+	matches        map[string]bool
+	totals         entity.SeasonTotals
+}
+
+func (g *seasonGroup) add(stat entity.PlayerStat) {
+	g.teams[stat.TeamID] = true
+	g.matches[stat.MatchID] = true
+	g.totals.Minutes += stat.Minutes
+	g.totals.Points += stat.Points
+	g.totals.FGM += stat.FGM
+	g.totals.FGA += stat.FGA
+	g.totals.ThreePM += stat.ThreePM
+	g.totals.ThreePA += stat.ThreePA
+	g.totals.FTM += stat.FTM
+	g.totals.FTA += stat.FTA
+	g.totals.OffensiveRebounds += stat.OffensiveRebounds
+	g.totals.DefensiveRebounds += stat.DefensiveRebounds
+	g.totals.Rebounds += stat.Rebounds
+	g.totals.Assists += stat.Assists
+	g.totals.Steals += stat.Steals
+	g.totals.Blocks += stat.Blocks
+	g.totals.Turnovers += stat.Turnovers
+	g.totals.PersonalFouls += stat.PersonalFouls
+	g.totals.PlusMinus += stat.PlusMinus
+}
+
+// groupBySeason - аналог GROUP BY season, league_id по строкам, подходящим под match, в порядке сезона и лиги
+func (s *StatPlayerRepo) groupBySeason(season string, match func(entity.PlayerStat) bool) []*seasonGroup {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	groups := make(map[[2]string]*seasonGroup)
+	for _, stat := range s.stats {
+		if !match(stat) || (season != "" && stat.Season != season) {
+			continue
+		}
+		key := [2]string{stat.Season, stat.LeagueID}
+		group, ok := groups[key]
+		if !ok {
+			group = &seasonGroup{
+				season:  stat.Season,
+				league:  stat.LeagueID,
+				teams:   make(map[string]bool),
+				matches: make(map[string]bool),
+			}
+			groups[key] = group
+		}
+		group.add(stat)
+	}
+
+	result := make([]*seasonGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].season != result[j].season {
+			return result[i].season < result[j].season
+		}
+		return result[i].league < result[j].league
+	})
+
+	return result
+}
+
+func (s *StatPlayerRepo) GetPlayerSeasonStats(_ context.Context, playerID, season string) ([]entity.PlayerSeasonStat, error) {
+	var stats []entity.PlayerSeasonStat
+	for _, group := range s.groupBySeason(season, func(stat entity.PlayerStat) bool { return stat.PlayerID == playerID }) {
+		teams := make([]string, 0, len(group.teams))
+		for team := range group.teams {
+			teams = append(teams, team)
+		}
+		sort.Strings(teams)
+
+		stats = append(stats, entity.PlayerSeasonStat{
+			PlayerID: playerID,
+			Season:   group.season,
+			League:   group.league,
+			Teams:    teams,
+			Games:    len(group.matches),
+			Totals:   group.totals,
+			PerGame:  group.totals.PerGame(len(group.matches)),
+		})
+	}
+
+	return stats, nil
+}
+
+func (s *StatPlayerRepo) GetTeamSeasonStats(_ context.Context, teamID, season string) ([]entity.TeamSeasonStat, error) {
+	var stats []entity.TeamSeasonStat
+	for _, group := range s.groupBySeason(season, func(stat entity.PlayerStat) bool { return stat.TeamID == teamID }) {
+		stats = append(stats, entity.TeamSeasonStat{
+			TeamID:  teamID,
+			Season:  group.season,
+			League:  group.league,
+			Games:   len(group.matches),
+			Totals:  group.totals,
+			PerGame: group.totals.PerGame(len(group.matches)),
+		})
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,254 @@
+package memory_rp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+// StatAwardsRepo - in-memory граф наград с теми же узлами и связями, что и в neo4j:
+// (Reward)-[:AWARDED_TO]->(Player), (Reward)-[:AWARDED_FOR_MATCH]->(Match), (Match)-[:PART_OF_TOURNAMENT]->(Tournament)
+type StatAwardsRepo struct {
+	mu sync.RWMutex
+
+	rewards []string
+	matches []string
+
+	awardedTo        map[string][]string
+	awardedForMatch  map[string][]string
+	partOfTournament map[string][]string
+
+	// deleted - узлы, помеченные удалёнными (свойство deleted в neo4j), ключ - метка и id
+	deleted map[string]bool
+
+	// retired - награды, помеченные RetireReward
+	retired map[string]bool
+
+	// properties - свойства узлов из каталога, ключ - метка и id
+	properties map[string]map[string]string
+
+	// grants - вручения, связи (Player)-[:AWARDED_IN {reward}]->(Match)
+	grants []grant
+
+	// memberships и appearances - граф карьеры, связи PLAYED_FOR и PLAYED_IN
+	memberships []membership
+	appearances []entity.Appearance
+}
+
+// grant - кто, какую награду и в каком матче получил
+type grant struct {
+	player string
+	reward string
+	match  string
+}
+
+func NewStatAwardsRepo() *StatAwardsRepo {
+	return &StatAwardsRepo{
+		awardedTo:        make(map[string][]string),
+		awardedForMatch:  make(map[string][]string),
+		partOfTournament: make(map[string][]string),
+		deleted:          make(map[string]bool),
+		retired:          make(map[string]bool),
+		properties:       make(map[string]map[string]string),
+	}
+}
+
+var _ usecase.StatAwardsRp = (*StatAwardsRepo)(nil)
+
+// CreateRecord - Функция для создания записи (награждение игрока в рамках матча и турнира), семантика MERGE
+func (sa *StatAwardsRepo) CreateRecord(_ context.Context, rewardStat entity.RewardStat) error {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	if sa.retired[rewardStat.Reward] {
+		return fmt.Errorf("%w: award %s is deleted", apperrors.ErrAwardNotFound, rewardStat.Reward)
+	}
+
+	sa.rewards = mergeNode(sa.rewards, rewardStat.Reward)
+	sa.matches = mergeNode(sa.matches, rewardStat.Match)
+	sa.awardedTo[rewardStat.Reward] = mergeNode(sa.awardedTo[rewardStat.Reward], rewardStat.Player)
+	sa.awardedForMatch[rewardStat.Reward] = mergeNode(sa.awardedForMatch[rewardStat.Reward], rewardStat.Match)
+	sa.partOfTournament[rewardStat.Match] = mergeNode(sa.partOfTournament[rewardStat.Match], rewardStat.Tournament)
+	if g := (grant{player: rewardStat.Player, reward: rewardStat.Reward, match: rewardStat.Match}); !hasGrant(sa.grants, g) {
+		sa.grants = append(sa.grants, g)
+	}
+
+	sa.setProperties("Player", rewardStat.Player, "name", rewardStat.PlayerName, "surname", rewardStat.PlayerSurname,
+		"team", rewardStat.PlayerTeam)
+	sa.setProperties("Match", rewardStat.Match, "first_team", rewardStat.MatchFirstTeam,
+		"second_team", rewardStat.MatchSecondTeam, "date", rewardStat.MatchDate)
+	sa.setProperties("Reward", rewardStat.Reward, "title", rewardStat.RewardTitle)
+	sa.setProperties("Tournament", rewardStat.Tournament, "name", rewardStat.TournamentName,
+		"season", rewardStat.TournamentSeason)
+
+	return nil
+}
+
+// setProperties - записывает непустые свойства узла, как SET n += $props
+func (sa *StatAwardsRepo) setProperties(label, id string, kv ...string) {
+	props := sa.properties[label+":"+id]
+	if props == nil {
+		props = make(map[string]string)
+		sa.properties[label+":"+id] = props
+	}
+	for i := 0; i < len(kv); i += 2 {
+		if kv[i+1] != "" {
+			props[kv[i]] = kv[i+1]
+		}
+	}
+}
+
+// withProperties - дополняет запись свойствами её узлов
+func (sa *StatAwardsRepo) withProperties(rewardStat entity.RewardStat) entity.RewardStat {
+	player := sa.properties["Player:"+rewardStat.Player]
+	rewardStat.PlayerName = player["name"]
+	rewardStat.PlayerSurname = player["surname"]
+	rewardStat.PlayerTeam = player["team"]
+
+	match := sa.properties["Match:"+rewardStat.Match]
+	rewardStat.MatchFirstTeam = match["first_team"]
+	rewardStat.MatchSecondTeam = match["second_team"]
+	rewardStat.MatchDate = match["date"]
+
+	rewardStat.RewardTitle = sa.properties["Reward:"+rewardStat.Reward]["title"]
+
+	if rewardStat.Tournament != "" {
+		tournament := sa.properties["Tournament:"+rewardStat.Tournament]
+		rewardStat.TournamentName = tournament["name"]
+		rewardStat.TournamentSeason = tournament["season"]
+	}
+
+	return rewardStat
+}
+
+// ViewPlayersAndRewardsInTournament - Функция для просмотра какие игроки получили награды в рамках турнира
+func (sa *StatAwardsRepo) ViewPlayersAndRewardsInTournament(_ context.Context, tournamentId string) ([]entity.RewardStat, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	return sa.viewGrants(func(g grant) bool { return true }, func(tournament string) bool {
+		return tournament == tournamentId
+	}), nil
+}
+
+// ViewPlayersAndRewardsInMatch - Функция для просмотра какие игроки получили награды в рамках матча
+func (sa *StatAwardsRepo) ViewPlayersAndRewardsInMatch(_ context.Context, matchId string) ([]entity.RewardStat, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	var rewards []entity.RewardStat
+	for _, g := range sa.grants {
+		if g.match != matchId {
+			continue
+		}
+		rewards = append(rewards, sa.withProperties(entity.RewardStat{
+			Player: g.player,
+			Reward: g.reward,
+			Match:  g.match,
+		}))
+	}
+
+	return rewards, nil
+}
+
+// ViewRewardsForPlayer - Функция для просмотра наград игрока и информации о матче и турнире, где была получена награда
+func (sa *StatAwardsRepo) ViewRewardsForPlayer(_ context.Context, playerId string) ([]entity.RewardStat, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	return sa.viewGrants(func(g grant) bool { return g.player == playerId }, nil), nil
+}
+
+// ViewWhoGotSpecificReward - Функция для просмотра кто получил конкретную награду (с информацией о матче и турнире)
+func (sa *StatAwardsRepo) ViewWhoGotSpecificReward(_ context.Context, rewardId string) ([]entity.RewardStat, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	return sa.viewGrants(func(g grant) bool { return g.reward == rewardId }, nil), nil
+}
+
+// ViewWhoGotRewards - Функция для просмотра кто получил награды из списка
+func (sa *StatAwardsRepo) ViewWhoGotRewards(_ context.Context, rewardIDs []string) ([]entity.RewardStat, error) {
+	sa.mu.RLock()
+	defer sa.mu.RUnlock()
+
+	return sa.viewGrants(func(g grant) bool { return hasNode(rewardIDs, g.reward) }, nil), nil
+}
+
+// RetireReward - помечает награду удалённой, если её ни разу не вручали
+func (sa *StatAwardsRepo) RetireReward(_ context.Context, rewardID string) (bool, error) {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	if len(sa.awardedTo[rewardID]) != 0 {
+		return true, nil
+	}
+	for _, g := range sa.grants {
+		if g.reward == rewardID {
+			return true, nil
+		}
+	}
+	sa.retired[rewardID] = true
+	return false, nil
+}
+
+// RestoreReward - снимает отметку RetireReward
+func (sa *StatAwardsRepo) RestoreReward(_ context.Context, rewardID string) error {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	delete(sa.retired, rewardID)
+	return nil
+}
+
+// viewGrants - записи по вручениям, подходящим под filter, с турниром матча: одна строка на вручение,
+// как (Player)-[:AWARDED_IN]->(Match)-[:PART_OF_TOURNAMENT]->(Tournament). inTournament, если задан, отбирает турниры
+func (sa *StatAwardsRepo) viewGrants(filter func(g grant) bool, inTournament func(tournament string) bool) []entity.RewardStat {
+	var rewards []entity.RewardStat
+	for _, g := range sa.grants {
+		if !filter(g) {
+			continue
+		}
+		for _, tournament := range sa.partOfTournament[g.match] {
+			if inTournament != nil && !inTournament(tournament) {
+				continue
+			}
+			rewards = append(rewards, sa.withProperties(entity.RewardStat{
+				Player:     g.player,
+				Reward:     g.reward,
+				Match:      g.match,
+				Tournament: tournament,
+			}))
+		}
+	}
+	return rewards
+}
+
+func hasGrant(grants []grant, g grant) bool {
+	for _, existing := range grants {
+		if existing == g {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeNode(nodes []string, id string) []string {
+	if hasNode(nodes, id) {
+		return nodes
+	}
+	return append(nodes, id)
+}
+
+func hasNode(nodes []string, id string) bool {
+	for _, node := range nodes {
+		if node == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package memory_rp
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+// StatPlayerRepo - in-memory аналог таблицы player_stats из ClickHouse
+type StatPlayerRepo struct {
+	mu    sync.RWMutex
+	stats []entity.PlayerStat
+}
+
+func NewStatPlayerRepo() *StatPlayerRepo {
+	return &StatPlayerRepo{}
+}
+
+var _ usecase.StatPlayerRp = (*StatPlayerRepo)(nil)
+
+// Функция для вставки данных в таблицу player_stats
+func (s *StatPlayerRepo) InsertPlayerStat(_ context.Context, stat entity.PlayerStat) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// агрегаты в таблицу не пишутся
+	stat.AVGGoals, stat.TotalAVGStats = 0, 0
+	s.stats = append(s.stats, stat)
+
+	return nil
+}
+
+// ReplacePlayerStat - удаляет строки игрока за матч и вставляет stat
+func (s *StatPlayerRepo) ReplacePlayerStat(ctx context.Context, stat entity.PlayerStat) error {
+	s.mu.Lock()
+	kept := s.stats[:0]
+	for _, row := range s.stats {
+		if row.PlayerID != stat.PlayerID || row.MatchID != stat.MatchID {
+			kept = append(kept, row)
+		}
+	}
+	s.stats = kept
+	s.mu.Unlock()
+
+	return s.InsertPlayerStat(ctx, stat)
+}
+
+// InsertPlayerStats - в памяти пачка не нужна, строки добавляются сразу
+func (s *StatPlayerRepo) InsertPlayerStats(ctx context.Context, stats []entity.PlayerStat) []error {
+	errs := make([]error, len(stats))
+	for i, stat := range stats {
+		errs[i] = s.InsertPlayerStat(ctx, stat)
+	}
+	return errs
+}
+
+// Поиск статистики игрока по его идентификатору (player_id) и матчу (match_id)
+func (s *StatPlayerRepo) GetPlayerStatsByIDAndMatch(_ context.Context, playerID, matchID string) ([]entity.PlayerStat, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var stats []entity.PlayerStat
+	for _, stat := range s.stats {
+		if stat.PlayerID == playerID && stat.MatchID == matchID {
+			stats = append(stats, stat)
+		}
+	}
+
+	return stats, nil
+}
+
+// Поиск игроков с средним количеством голов больше определённого значения по id матча
+func (s *StatPlayerRepo) GetPlayersWithAvgGoalsGreaterThanByMatch(_ context.Context, minAvgGoals float64, matchID string) ([]entity.PlayerStat, error) {
+	var stats []entity.PlayerStat
+	for _, group := range s.groupByPlayer(matchID) {
+		avgGoals := avg(group.rows, func(stat entity.PlayerStat) int { return stat.Goals })
+		if avgGoals > minAvgGoals {
+			stats = append(stats, entity.PlayerStat{PlayerID: group.playerID, AVGGoals: avgGoals})
+		}
+	}
+
+	return stats, nil
+}
+
+// Поиск игроков, у которых сумма средних значений голов, перехватов, подборов и передач больше определённого значения по id матча
+func (s *StatPlayerRepo) GetPlayersWithTotalAvgStatsGreaterThanByMatch(_ context.Context, minTotalAvg float64, matchID string) ([]entity.PlayerStat, error) {
+	var stats []entity.PlayerStat
+	for _, group := range s.groupByPlayer(matchID) {
+		totalAvgStats := avg(group.rows, func(stat entity.PlayerStat) int { return stat.Goals }) +
+			avg(group.rows, func(stat entity.PlayerStat) int { return stat.Assists }) +
+			avg(group.rows, func(stat entity.PlayerStat) int { return stat.Interceptions }) +
+			avg(group.rows, func(stat entity.PlayerStat) int { return stat.Rebounds })
+		if totalAvgStats > minTotalAvg {
+			stats = append(stats, entity.PlayerStat{PlayerID: group.playerID, TotalAVGStats: totalAvgStats})
+		}
+	}
+
+	return stats, nil
+}
+
+type playerGroup struct {
+	playerID string
+	rows     []entity.PlayerStat
+}
+
+// groupByPlayer - аналог WHERE match_id = ? GROUP BY player_id
+func (s *StatPlayerRepo) groupByPlayer(matchID string) []playerGroup {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	index := make(map[string]int)
+	var groups []playerGroup
+	for _, stat := range s.stats {
+		if stat.MatchID != matchID {
+			continue
+		}
+		i, ok := index[stat.PlayerID]
+		if !ok {
+			i = len(groups)
+			index[stat.PlayerID] = i
+			groups = append(groups, playerGroup{playerID: stat.PlayerID})
+		}
+		groups[i].rows = append(groups[i].rows, stat)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].playerID < groups[j].playerID
+	})
+
+	return groups
+}
+
+func avg(rows []entity.PlayerStat, field func(entity.PlayerStat) int) float64 {
+	if len(rows) == 0 {
+		return 0
+	}
+
+	var sum int
+	for _, row := range rows {
+		sum += field(row)
+	}
+
+	return float64(sum) / float64(len(rows))
+}
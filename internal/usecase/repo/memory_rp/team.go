@@ -0,0 +1,56 @@
+package memory_rp
+
+import (
+	"context"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+type TeamRepo struct {
+	teams *collection[entity.Team]
+}
+
+func NewTeamRepo() *TeamRepo {
+	return &TeamRepo{
+		teams: newCollection[entity.Team](apperrors.ErrInvalidTeamID, apperrors.ErrTeamNotFound).withID(setTeamID),
+	}
+}
+
+var _ usecase.TeamRp = (*TeamRepo)(nil)
+
+func (t *TeamRepo) CreateTeam(_ context.Context, team *entity.Team) (string, error) {
+	return t.teams.create(team), nil
+}
+
+func (t *TeamRepo) UpdateTeam(_ context.Context, teamID string, team *entity.Team) (*entity.Team, error) {
+	if err := t.teams.replace(teamID, team); err != nil {
+		return nil, err
+	}
+	team.ID = teamID
+
+	return team, nil
+}
+
+func (t *TeamRepo) GetTeam(_ context.Context, teamID string) (*entity.Team, error) {
+	return t.teams.get(teamID)
+}
+
+func (t *TeamRepo) DeleteTeam(_ context.Context, teamID string) error {
+	return t.teams.delete(teamID)
+}
+
+func (t *TeamRepo) GetTeamList(_ context.Context, pageSize, pageNumber int64) ([]*entity.Team, error) {
+	return t.teams.list(pageSize, pageNumber)
+}
+
+func (t *TeamRepo) GetTeamsByLeague(_ context.Context, leagueID string) ([]*entity.Team, error) {
+	return t.teams.find(func(team *entity.Team) bool {
+		return team.League == leagueID
+	}), nil
+}
+
+func setTeamID(team *entity.Team, id string) {
+	team.ID = id
+}
@@ -17,23 +17,45 @@ import (
 
 type AwardRepo struct {
 	mngCollection *mongo.Collection
+	outbox        *outbox
 }
 
 func NewAwardRepo(mng *mongodb.Mongo, collectionName string) *AwardRepo {
 	return &AwardRepo{
 		mngCollection: mng.DB.Collection(collectionName),
+		outbox:        newOutbox(mng),
 	}
 }
 
 var _ usecase.AwardRp = (*AwardRepo)(nil)
 
+// awardDocument - документ награды вместе с _id, чтобы вернуть id в entity.Award
+type awardDocument struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	entity.Award `bson:",inline"`
+}
+
+func (d *awardDocument) award() *entity.Award {
+	award := d.Award
+	award.ID = d.ID.Hex()
+	return &award
+}
+
 func (a *AwardRepo) CreateAward(ctx context.Context, award *entity.Award) (string, error) {
-	res, err := a.mngCollection.InsertOne(ctx, award)
+	var awardID string
+	err := a.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		res, err := a.mngCollection.InsertOne(sc, award)
+		if err != nil {
+			return nil, fmt.Errorf("create award: %w", err)
+		}
+		awardID = res.InsertedID.(primitive.ObjectID).Hex()
+		return upsertEvent(awardID, award), nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("create award: %w", err)
+		return "", err
 	}
 
-	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+	return awardID, nil
 }
 
 func (a *AwardRepo) UpdateAward(ctx context.Context, awardID string, award *entity.Award) (*entity.Award, error) {
@@ -46,12 +68,19 @@ func (a *AwardRepo) UpdateAward(ctx context.Context, awardID string, award *enti
 		"_id": objID,
 	}
 
-	if err = a.mngCollection.FindOneAndReplace(ctx, filter, award).Err(); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, apperrors.ErrAwardNotFound
+	err = a.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		if err := a.mngCollection.FindOneAndReplace(sc, filter, award).Err(); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, apperrors.ErrAwardNotFound
+			}
+			return nil, fmt.Errorf("mongo error: %w", err)
 		}
-		return nil, fmt.Errorf("mongo error: %w", err)
+		return upsertEvent(awardID, award), nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	award.ID = awardID
 
 	return award, nil
 }
@@ -66,15 +95,15 @@ func (a *AwardRepo) GetAward(ctx context.Context, awardID string) (*entity.Award
 		"_id": objID,
 	}
 
-	award := new(entity.Award)
-	if err := a.mngCollection.FindOne(ctx, filter).Decode(award); err != nil {
+	doc := new(awardDocument)
+	if err := a.mngCollection.FindOne(ctx, filter).Decode(doc); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, apperrors.ErrAwardNotFound
 		}
 		return nil, fmt.Errorf("mongo error: %w", err)
 	}
 
-	return award, nil
+	return doc.award(), nil
 }
 
 func (a *AwardRepo) DeleteAward(ctx context.Context, awardID string) error {
@@ -87,14 +116,15 @@ func (a *AwardRepo) DeleteAward(ctx context.Context, awardID string) error {
 		"_id": objID,
 	}
 
-	if err = a.mngCollection.FindOneAndDelete(ctx, filter).Err(); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return apperrors.ErrAwardNotFound
+	return a.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		if err := a.mngCollection.FindOneAndDelete(sc, filter).Err(); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, apperrors.ErrAwardNotFound
+			}
+			return nil, fmt.Errorf("mongo error: %w", err)
 		}
-		return fmt.Errorf("mongo error: %w", err)
-	}
-
-	return nil
+		return deleteEvent(entity.OutboxEntityAward, awardID), nil
+	})
 }
 
 func (a *AwardRepo) GetAwardList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Award, error) {
@@ -106,12 +136,12 @@ func (a *AwardRepo) GetAwardList(ctx context.Context, pageSize, pageNumber int64
 
 	var awards []*entity.Award
 	for cursor.Next(ctx) {
-		var award *entity.Award
-		err := cursor.Decode(&award)
+		var doc awardDocument
+		err := cursor.Decode(&doc)
 		if err != nil {
 			return nil, fmt.Errorf("mongo error: %w", err)
 		}
-		awards = append(awards, award)
+		awards = append(awards, doc.award())
 	}
 
 	if err := cursor.Err(); err != nil {
@@ -0,0 +1,155 @@
+package mongo_rp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	mongodb "github.com/romeros69/basket/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DeletionJobRepo - задачи удаления сущностей каталога из всех хранилищ
+type DeletionJobRepo struct {
+	mngCollection *mongo.Collection
+}
+
+func NewDeletionJobRepo(mng *mongodb.Mongo, collectionName string) *DeletionJobRepo {
+	return &DeletionJobRepo{
+		mngCollection: mng.DB.Collection(collectionName),
+	}
+}
+
+var _ usecase.DeletionJobRp = (*DeletionJobRepo)(nil)
+
+// deletionJobDocument - документ задачи вместе с _id, чтобы вернуть id в entity.DeletionJob
+type deletionJobDocument struct {
+	ID                 primitive.ObjectID `bson:"_id"`
+	entity.DeletionJob `bson:",inline"`
+}
+
+func (d *deletionJobDocument) job() *entity.DeletionJob {
+	job := d.DeletionJob
+	job.ID = d.ID.Hex()
+	return &job
+}
+
+func (d *DeletionJobRepo) CreateJob(ctx context.Context, job *entity.DeletionJob) (string, error) {
+	res, err := d.mngCollection.InsertOne(ctx, job)
+	if err != nil {
+		return "", fmt.Errorf("create deletion job: %w", err)
+	}
+
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (d *DeletionJobRepo) UpdateJob(ctx context.Context, job *entity.DeletionJob) error {
+	objID, err := primitive.ObjectIDFromHex(job.ID)
+	if err != nil {
+		return apperrors.ErrInvalidDeletionJobID
+	}
+
+	filter := bson.M{
+		"_id": objID,
+	}
+
+	res, err := d.mngCollection.ReplaceOne(ctx, filter, job)
+	if err != nil {
+		return fmt.Errorf("mongo error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return apperrors.ErrDeletionJobNotFound
+	}
+
+	return nil
+}
+
+func (d *DeletionJobRepo) GetJob(ctx context.Context, jobID string) (*entity.DeletionJob, error) {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidDeletionJobID
+	}
+
+	filter := bson.M{
+		"_id": objID,
+	}
+
+	doc := new(deletionJobDocument)
+	if err := d.mngCollection.FindOne(ctx, filter).Decode(doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperrors.ErrDeletionJobNotFound
+		}
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return doc.job(), nil
+}
+
+func (d *DeletionJobRepo) GetJobsByStatus(ctx context.Context, status string) ([]*entity.DeletionJob, error) {
+	filter := bson.M{
+		"status": status,
+	}
+
+	cursor, err := d.mngCollection.Find(ctx, filter, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []*entity.DeletionJob
+	for cursor.Next(ctx) {
+		doc := new(deletionJobDocument)
+		if err := cursor.Decode(doc); err != nil {
+			return nil, fmt.Errorf("mongo error: %w", err)
+		}
+		jobs = append(jobs, doc.job())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// ClaimJob - условный FindOneAndUpdate: задача захватывается, только если она не завершена и срок прошлого захвата истёк
+func (d *DeletionJobRepo) ClaimJob(ctx context.Context, jobID string, now, until time.Time) (*entity.DeletionJob, error) {
+	objID, err := primitive.ObjectIDFromHex(jobID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidDeletionJobID
+	}
+
+	filter := bson.M{
+		"_id":    objID,
+		"status": bson.M{"$ne": entity.DeletionJobDone},
+		"$or": bson.A{
+			bson.M{"locked_until": bson.M{"$exists": false}},
+			bson.M{"locked_until": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{"locked_until": until},
+	}
+
+	doc := new(deletionJobDocument)
+	err = d.mngCollection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// задачи нет совсем или её держит другая копия приложения
+		if _, err = d.GetJob(ctx, jobID); err != nil {
+			return nil, err
+		}
+		return nil, apperrors.ErrDeletionJobRunning
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return doc.job(), nil
+}
@@ -16,23 +16,68 @@ import (
 
 type GameRepo struct {
 	mngCollection *mongo.Collection
+	outbox        *outbox
+	tombstones    *mongo.Collection
 }
 
 func NewGameRepo(mng *mongodb.Mongo, collectionName string) *GameRepo {
 	return &GameRepo{
 		mngCollection: mng.DB.Collection(collectionName),
+		outbox:        newOutbox(mng),
+		tombstones:    mng.DB.Collection(collectionName + tombstonesSuffix),
 	}
 }
 
 var _ usecase.GameRp = (*GameRepo)(nil)
 
 func (g *GameRepo) CreateGame(ctx context.Context, game *entity.Game) (string, error) {
-	res, err := g.mngCollection.InsertOne(ctx, game)
+	var gameID string
+	err := g.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		res, err := g.mngCollection.InsertOne(sc, game)
+		if err != nil {
+			return nil, fmt.Errorf("create game")
+		}
+		gameID = res.InsertedID.(primitive.ObjectID).Hex()
+		return upsertEvent(gameID, game), nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("create game")
+		return "", err
 	}
 
-	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+	return gameID, nil
+}
+
+// CreateGames - вставляет все матчи одной транзакцией: при ошибке не остаётся ни одного
+func (g *GameRepo) CreateGames(ctx context.Context, games []*entity.Game) ([]string, error) {
+	if len(games) == 0 {
+		return nil, nil
+	}
+
+	var gameIDs []string
+	err := g.outbox.writeAll(ctx, func(sc mongo.SessionContext) ([]*entity.OutboxEvent, error) {
+		docs := make([]interface{}, 0, len(games))
+		for _, game := range games {
+			docs = append(docs, game)
+		}
+		res, err := g.mngCollection.InsertMany(sc, docs)
+		if err != nil {
+			return nil, fmt.Errorf("create games: %w", err)
+		}
+
+		gameIDs = make([]string, 0, len(games))
+		events := make([]*entity.OutboxEvent, 0, len(games))
+		for i, id := range res.InsertedIDs {
+			gameID := id.(primitive.ObjectID).Hex()
+			gameIDs = append(gameIDs, gameID)
+			events = append(events, upsertEvent(gameID, games[i]))
+		}
+		return events, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return gameIDs, nil
 }
 
 func (g *GameRepo) UpdateGame(ctx context.Context, gameID string, game *entity.Game) (*entity.Game, error) {
@@ -45,11 +90,17 @@ func (g *GameRepo) UpdateGame(ctx context.Context, gameID string, game *entity.G
 		"_id": objID,
 	}
 
-	if err = g.mngCollection.FindOneAndReplace(ctx, filter, game).Err(); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, apperrors.ErrGameNotFound
+	err = g.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		if err := g.mngCollection.FindOneAndReplace(sc, filter, game).Err(); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, apperrors.ErrGameNotFound
+			}
+			return nil, fmt.Errorf("mongo error: %w", err)
 		}
-		return nil, fmt.Errorf("mongo error: %w", err)
+		return upsertEvent(gameID, game), nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return game, nil
@@ -86,14 +137,15 @@ func (g *GameRepo) DeleteGame(ctx context.Context, gameID string) error {
 		"_id": objID,
 	}
 
-	if err = g.mngCollection.FindOneAndDelete(ctx, filter).Err(); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return apperrors.ErrGameNotFound
+	return g.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		if err := g.mngCollection.FindOneAndDelete(sc, filter).Err(); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, apperrors.ErrGameNotFound
+			}
+			return nil, fmt.Errorf("mongo error: %w", err)
 		}
-		return fmt.Errorf("mongo error: %w", err)
-	}
-
-	return nil
+		return deleteEvent(entity.OutboxEntityGame, gameID), nil
+	})
 }
 
 func (g *GameRepo) GetGameList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Game, error) {
@@ -119,3 +171,85 @@ func (g *GameRepo) GetGameList(ctx context.Context, pageSize, pageNumber int64)
 
 	return games, nil
 }
+
+func (g *GameRepo) GetGamesByTeam(ctx context.Context, teamID string) ([]*entity.Game, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"firstteam": teamID},
+			bson.M{"secondteam": teamID},
+		},
+	}
+
+	cursor, err := g.mngCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var games []*entity.Game
+	for cursor.Next(ctx) {
+		var game *entity.Game
+		err := cursor.Decode(&game)
+		if err != nil {
+			return nil, fmt.Errorf("mongo error: %w", err)
+		}
+		games = append(games, game)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return games, nil
+}
+
+func (g *GameRepo) GetGamesByLeague(ctx context.Context, leagueID string) ([]*entity.Game, error) {
+	filter := bson.M{
+		"league": leagueID,
+	}
+
+	cursor, err := g.mngCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var games []*entity.Game
+	for cursor.Next(ctx) {
+		var game *entity.Game
+		err := cursor.Decode(&game)
+		if err != nil {
+			return nil, fmt.Errorf("mongo error: %w", err)
+		}
+		games = append(games, game)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return games, nil
+}
+
+// TombstoneGame - убирает матч из каталога, сохраняя документ в коллекции tombstones
+func (g *GameRepo) TombstoneGame(ctx context.Context, gameID string) error {
+	objID, err := primitive.ObjectIDFromHex(gameID)
+	if err != nil {
+		return apperrors.ErrInvalidGameID
+	}
+
+	return g.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		if err := tombstone(sc, g.mngCollection, g.tombstones, objID, apperrors.ErrGameNotFound); err != nil {
+			return nil, err
+		}
+		return deleteEvent(entity.OutboxEntityGame, gameID), nil
+	})
+}
+
+// StreamGameIDs - id матчей из каталога или, если tombstoned, из коллекции tombstones
+func (g *GameRepo) StreamGameIDs(ctx context.Context, tombstoned bool, yield func(gameID string) error) error {
+	if tombstoned {
+		return streamIDs(ctx, g.tombstones, yield)
+	}
+	return streamIDs(ctx, g.mngCollection, yield)
+}
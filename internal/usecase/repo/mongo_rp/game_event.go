@@ -0,0 +1,109 @@
+package mongo_rp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	mongodb "github.com/romeros69/basket/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type GameEventRepo struct {
+	mngCollection *mongo.Collection
+}
+
+func NewGameEventRepo(mng *mongodb.Mongo, collectionName string) *GameEventRepo {
+	return &GameEventRepo{
+		mngCollection: mng.DB.Collection(collectionName),
+	}
+}
+
+var _ usecase.GameEventRp = (*GameEventRepo)(nil)
+
+func (g *GameEventRepo) AddEvents(ctx context.Context, events []*entity.GameEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		docs = append(docs, event)
+	}
+
+	if _, err := g.mngCollection.InsertMany(ctx, docs); err != nil {
+		return fmt.Errorf("create game events: %w", err)
+	}
+
+	return nil
+}
+
+func (g *GameEventRepo) GetEventsByGame(ctx context.Context, gameID string) ([]*entity.GameEvent, error) {
+	filter := bson.M{
+		"game": gameID,
+	}
+
+	cursor, err := g.mngCollection.Find(ctx, filter, options.Find().SetSort(bson.M{"sequence": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []*entity.GameEvent
+	for cursor.Next(ctx) {
+		var event *entity.GameEvent
+		err := cursor.Decode(&event)
+		if err != nil {
+			return nil, fmt.Errorf("mongo error: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return events, nil
+}
+
+func (g *GameEventRepo) DeleteEventsByGame(ctx context.Context, gameID string) error {
+	filter := bson.M{
+		"game": gameID,
+	}
+
+	if _, err := g.mngCollection.DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("mongo error: %w", err)
+	}
+
+	return nil
+}
+
+// playerEventsFilter - события, где игрок участвует сам или уходит с площадки при замене
+func playerEventsFilter(playerID string) bson.M {
+	return bson.M{
+		"$or": bson.A{
+			bson.M{"player": playerID},
+			bson.M{"replaced": playerID},
+		},
+	}
+}
+
+func (g *GameEventRepo) HasEventsByPlayer(ctx context.Context, playerID string) (bool, error) {
+	count, err := g.mngCollection.CountDocuments(ctx, playerEventsFilter(playerID), options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return count != 0, nil
+}
+
+func (g *GameEventRepo) DeleteEventsByPlayer(ctx context.Context, playerID string) error {
+	if _, err := g.mngCollection.DeleteMany(ctx, playerEventsFilter(playerID)); err != nil {
+		return fmt.Errorf("mongo error: %w", err)
+	}
+
+	return nil
+}
@@ -16,23 +16,33 @@ import (
 
 type LeagueRepo struct {
 	mngCollection *mongo.Collection
+	outbox        *outbox
 }
 
 func NewLeagueRepo(mng *mongodb.Mongo, collectionName string) *LeagueRepo {
 	return &LeagueRepo{
 		mngCollection: mng.DB.Collection(collectionName),
+		outbox:        newOutbox(mng),
 	}
 }
 
 var _ usecase.LeagueRp = (*LeagueRepo)(nil)
 
 func (l *LeagueRepo) CreateLeague(ctx context.Context, league *entity.League) (string, error) {
-	res, err := l.mngCollection.InsertOne(ctx, league)
+	var leagueID string
+	err := l.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		res, err := l.mngCollection.InsertOne(sc, league)
+		if err != nil {
+			return nil, fmt.Errorf("create league")
+		}
+		leagueID = res.InsertedID.(primitive.ObjectID).Hex()
+		return upsertEvent(leagueID, league), nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("create league")
+		return "", err
 	}
 
-	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+	return leagueID, nil
 }
 
 func (l *LeagueRepo) UpdateLeague(ctx context.Context, leagueID string, league *entity.League) (*entity.League, error) {
@@ -45,11 +55,17 @@ func (l *LeagueRepo) UpdateLeague(ctx context.Context, leagueID string, league *
 		"_id": objID,
 	}
 
-	if err = l.mngCollection.FindOneAndReplace(ctx, filter, league).Err(); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, apperrors.ErrLeagueNotFound
+	err = l.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		if err := l.mngCollection.FindOneAndReplace(sc, filter, league).Err(); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, apperrors.ErrLeagueNotFound
+			}
+			return nil, fmt.Errorf("mongo error: %w", err)
 		}
-		return nil, fmt.Errorf("mongo error: %w", err)
+		return upsertEvent(leagueID, league), nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return league, nil
@@ -86,14 +102,15 @@ func (l *LeagueRepo) DeleteLeague(ctx context.Context, leagueID string) error {
 		"_id": objID,
 	}
 
-	if err = l.mngCollection.FindOneAndDelete(ctx, filter).Err(); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return apperrors.ErrLeagueNotFound
+	return l.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		if err := l.mngCollection.FindOneAndDelete(sc, filter).Err(); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, apperrors.ErrLeagueNotFound
+			}
+			return nil, fmt.Errorf("mongo error: %w", err)
 		}
-		return fmt.Errorf("mongo error: %w", err)
-	}
-
-	return nil
+		return deleteEvent(entity.OutboxEntityLeague, leagueID), nil
+	})
 }
 
 func (l *LeagueRepo) GetLeagueList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.League, error) {
@@ -0,0 +1,249 @@
+package mongo_rp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	mongodb "github.com/romeros69/basket/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// outboxCollection - общая для всех репозиториев каталога коллекция доменных событий
+	outboxCollection = "outbox"
+	// outboxVersionsCollection - счётчик версий по каждой сущности: {_id: "player:<id>", version}
+	outboxVersionsCollection = "outbox_versions"
+)
+
+// outbox - запись изменения каталога и его события в одной транзакции (нужен replica set)
+type outbox struct {
+	client   *mongo.Client
+	events   *mongo.Collection
+	versions *mongo.Collection
+}
+
+func newOutbox(mng *mongodb.Mongo) *outbox {
+	return &outbox{
+		client:   mng.DB.Client(),
+		events:   mng.DB.Collection(outboxCollection),
+		versions: mng.DB.Collection(outboxVersionsCollection),
+	}
+}
+
+// write - выполняет change в транзакции и записывает в outbox возвращённое им событие.
+// При временной ошибке транзакции драйвер повторяет change целиком
+func (o *outbox) write(ctx context.Context, change func(sc mongo.SessionContext) (*entity.OutboxEvent, error)) error {
+	return o.writeAll(ctx, func(sc mongo.SessionContext) ([]*entity.OutboxEvent, error) {
+		event, err := change(sc)
+		if err != nil {
+			return nil, err
+		}
+		return []*entity.OutboxEvent{event}, nil
+	})
+}
+
+// writeAll - как write, но change меняет несколько документов и возвращает событие для каждого
+func (o *outbox) writeAll(ctx context.Context, change func(sc mongo.SessionContext) ([]*entity.OutboxEvent, error)) error {
+	session, err := o.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("mongo error: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		events, err := change(sc)
+		if err != nil {
+			return nil, err
+		}
+
+		docs := make([]interface{}, 0, len(events))
+		for _, event := range events {
+			if event.Version, err = o.nextVersion(sc, event); err != nil {
+				return nil, err
+			}
+			event.OccurredAt = time.Now().UTC()
+			event.Status = entity.OutboxStatusPending
+			docs = append(docs, event)
+		}
+		if _, err = o.events.InsertMany(sc, docs); err != nil {
+			return nil, fmt.Errorf("write outbox events: %w", err)
+		}
+		return nil, nil
+	})
+
+	return err
+}
+
+// nextVersion - следующая версия сущности. Счётчик меняется в той же транзакции, что и документ,
+// поэтому две транзакции по одной сущности конфликтуют, и версии идут в порядке фиксации, а не по часам узлов.
+// Новый счётчик начинается с текущего времени в наносекундах: так версии не меньше тех, что раньше
+// выдавались по часам и уже лежат в проекциях
+func (o *outbox) nextVersion(sc mongo.SessionContext, event *entity.OutboxEvent) (int64, error) {
+	filter := bson.M{
+		"_id": event.Entity + ":" + event.EntityID,
+	}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"version": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$version", time.Now().UnixNano()}}, 1}},
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var counter struct {
+		Version int64 `bson:"version"`
+	}
+	if err := o.versions.FindOneAndUpdate(sc, filter, update, opts).Decode(&counter); err != nil {
+		return 0, fmt.Errorf("next outbox version for %s %s: %w", event.Entity, event.EntityID, err)
+	}
+	return counter.Version, nil
+}
+
+// upsertEvent - событие создания или изменения с документом сущности
+func upsertEvent(entityID string, doc interface{}) *entity.OutboxEvent {
+	event := &entity.OutboxEvent{
+		EntityID: entityID,
+		Type:     entity.OutboxEventUpsert,
+	}
+	switch doc := doc.(type) {
+	case *entity.Player:
+		event.Entity, event.Player = entity.OutboxEntityPlayer, doc
+	case *entity.Game:
+		event.Entity, event.Game = entity.OutboxEntityGame, doc
+	case *entity.League:
+		event.Entity, event.League = entity.OutboxEntityLeague, doc
+	case *entity.Award:
+		event.Entity, event.Award = entity.OutboxEntityAward, doc
+	default:
+		panic(fmt.Sprintf("outbox: unexpected document %T", doc))
+	}
+	return event
+}
+
+// deleteEvent - событие удаления сущности
+func deleteEvent(entityKind, entityID string) *entity.OutboxEvent {
+	return &entity.OutboxEvent{
+		Entity:   entityKind,
+		EntityID: entityID,
+		Type:     entity.OutboxEventDelete,
+	}
+}
+
+// OutboxRepo - чтение и отметка событий outbox для relay
+type OutboxRepo struct {
+	mngCollection *mongo.Collection
+}
+
+func NewOutboxRepo(mng *mongodb.Mongo) *OutboxRepo {
+	return &OutboxRepo{
+		mngCollection: mng.DB.Collection(outboxCollection),
+	}
+}
+
+var _ usecase.OutboxRp = (*OutboxRepo)(nil)
+
+// outboxTTLIndex - имя TTL-индекса: по нему меняется срок хранения, если retention в конфиге изменился
+const outboxTTLIndex = "processed_at_ttl"
+
+// EnsureIndexes - индекс для выборки необработанных событий по порядку и TTL-индекс, по которому mongo удаляет
+// обработанные события через retention. У необработанных и запаркованных событий нет processed_at, и они не удаляются
+func (o *OutboxRepo) EnsureIndexes(ctx context.Context, retention time.Duration) error {
+	_, err := o.mngCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "_id", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("create outbox status index: %w", err)
+	}
+
+	expireAfter := int32(retention / time.Second)
+	_, err = o.mngCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "processed_at", Value: 1}},
+		Options: options.Index().SetName(outboxTTLIndex).SetExpireAfterSeconds(expireAfter),
+	})
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Name == "IndexOptionsConflict" {
+		// индекс уже есть с другим сроком хранения
+		err = o.mngCollection.Database().RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: outboxCollection},
+			{Key: "index", Value: bson.M{"name": outboxTTLIndex, "expireAfterSeconds": expireAfter}},
+		}).Err()
+	}
+	if err != nil {
+		return fmt.Errorf("create outbox ttl index: %w", err)
+	}
+
+	return nil
+}
+
+// outboxEventDocument - документ события вместе с _id, чтобы вернуть id в entity.OutboxEvent
+type outboxEventDocument struct {
+	ID                 primitive.ObjectID `bson:"_id"`
+	entity.OutboxEvent `bson:",inline"`
+}
+
+// GetPendingEvents - необработанные события в порядке записи
+func (o *OutboxRepo) GetPendingEvents(ctx context.Context, limit int64) ([]*entity.OutboxEvent, error) {
+	filter := bson.M{
+		"status": entity.OutboxStatusPending,
+	}
+
+	cursor, err := o.mngCollection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []*entity.OutboxEvent
+	for cursor.Next(ctx) {
+		doc := new(outboxEventDocument)
+		if err := cursor.Decode(doc); err != nil {
+			return nil, fmt.Errorf("mongo error: %w", err)
+		}
+		event := doc.OutboxEvent
+		event.ID = doc.ID.Hex()
+		events = append(events, &event)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return events, nil
+}
+
+// UpdateEventStatus - сохраняет статус, число попыток и последнюю ошибку события
+func (o *OutboxRepo) UpdateEventStatus(ctx context.Context, event *entity.OutboxEvent) error {
+	objID, err := primitive.ObjectIDFromHex(event.ID)
+	if err != nil {
+		return fmt.Errorf("invalid outbox event id %q", event.ID)
+	}
+
+	filter := bson.M{
+		"_id": objID,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     event.Status,
+			"attempts":   event.Attempts,
+			"last_error": event.LastError,
+		},
+	}
+	if event.ProcessedAt != nil {
+		update["$set"].(bson.M)["processed_at"] = *event.ProcessedAt
+	}
+
+	if err = o.mngCollection.FindOneAndUpdate(ctx, filter, update).Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("outbox event %s not found", event.ID)
+		}
+		return fmt.Errorf("mongo error: %w", err)
+	}
+
+	return nil
+}
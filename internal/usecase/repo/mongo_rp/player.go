@@ -17,23 +17,35 @@ import (
 
 type PlayerRepo struct {
 	mngCollection *mongo.Collection
+	outbox        *outbox
+	tombstones    *mongo.Collection
 }
 
 func NewPlayerRepo(mng *mongodb.Mongo, collectionName string) *PlayerRepo {
 	return &PlayerRepo{
 		mngCollection: mng.DB.Collection(collectionName),
+		outbox:        newOutbox(mng),
+		tombstones:    mng.DB.Collection(collectionName + tombstonesSuffix),
 	}
 }
 
 var _ usecase.PlayerRp = (*PlayerRepo)(nil)
 
 func (p *PlayerRepo) CreatePlayer(ctx context.Context, player *entity.Player) (string, error) {
-	res, err := p.mngCollection.InsertOne(ctx, player)
+	var playerID string
+	err := p.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		res, err := p.mngCollection.InsertOne(sc, player)
+		if err != nil {
+			return nil, fmt.Errorf("create player: %w", err)
+		}
+		playerID = res.InsertedID.(primitive.ObjectID).Hex()
+		return upsertEvent(playerID, player), nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("create player: %w", err)
+		return "", err
 	}
 
-	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+	return playerID, nil
 }
 
 func (p *PlayerRepo) UpdatePlayer(ctx context.Context, playerID string, player *entity.Player) (*entity.Player, error) {
@@ -46,11 +58,17 @@ func (p *PlayerRepo) UpdatePlayer(ctx context.Context, playerID string, player *
 		"_id": objID,
 	}
 
-	if err = p.mngCollection.FindOneAndReplace(ctx, filter, player).Err(); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, apperrors.ErrPlayerNotFound
+	err = p.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		if err := p.mngCollection.FindOneAndReplace(sc, filter, player).Err(); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, apperrors.ErrPlayerNotFound
+			}
+			return nil, fmt.Errorf("mongo error: %w", err)
 		}
-		return nil, fmt.Errorf("mongo error: %w", err)
+		return upsertEvent(playerID, player), nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return player, nil
@@ -87,14 +105,15 @@ func (p *PlayerRepo) DeletePlayer(ctx context.Context, playerID string) error {
 		"_id": objID,
 	}
 
-	if err = p.mngCollection.FindOneAndDelete(ctx, filter).Err(); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return apperrors.ErrPlayerNotFound
+	return p.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		if err := p.mngCollection.FindOneAndDelete(sc, filter).Err(); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, apperrors.ErrPlayerNotFound
+			}
+			return nil, fmt.Errorf("mongo error: %w", err)
 		}
-		return fmt.Errorf("mongo error: %w", err)
-	}
-
-	return nil
+		return deleteEvent(entity.OutboxEntityPlayer, playerID), nil
+	})
 }
 
 func (p *PlayerRepo) GetPlayerList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Player, error) {
@@ -120,3 +139,91 @@ func (p *PlayerRepo) GetPlayerList(ctx context.Context, pageSize, pageNumber int
 
 	return players, nil
 }
+
+func (p *PlayerRepo) GetPlayersByTeam(ctx context.Context, teamID string) ([]*entity.Player, error) {
+	filter := bson.M{
+		"team": teamID,
+	}
+
+	cursor, err := p.mngCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var players []*entity.Player
+	for cursor.Next(ctx) {
+		var player *entity.Player
+		err := cursor.Decode(&player)
+		if err != nil {
+			return nil, fmt.Errorf("mongo error: %w", err)
+		}
+		players = append(players, player)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return players, nil
+}
+
+func (p *PlayerRepo) GetPlayersByIDs(ctx context.Context, playerIDs []string) (map[string]*entity.Player, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(playerIDs))
+	for _, playerID := range playerIDs {
+		if objID, err := primitive.ObjectIDFromHex(playerID); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	players := make(map[string]*entity.Player, len(objIDs))
+	if len(objIDs) == 0 {
+		return players, nil
+	}
+
+	cursor, err := p.mngCollection.Find(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+	if err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID            primitive.ObjectID `bson:"_id"`
+			entity.Player `bson:",inline"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("mongo error: %w", err)
+		}
+		player := doc.Player
+		players[doc.ID.Hex()] = &player
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return players, nil
+}
+
+// TombstonePlayer - убирает игрока из каталога, сохраняя документ в коллекции tombstones
+func (p *PlayerRepo) TombstonePlayer(ctx context.Context, playerID string) error {
+	objID, err := primitive.ObjectIDFromHex(playerID)
+	if err != nil {
+		return apperrors.ErrInvalidPlayerID
+	}
+
+	return p.outbox.write(ctx, func(sc mongo.SessionContext) (*entity.OutboxEvent, error) {
+		if err := tombstone(sc, p.mngCollection, p.tombstones, objID, apperrors.ErrPlayerNotFound); err != nil {
+			return nil, err
+		}
+		return deleteEvent(entity.OutboxEntityPlayer, playerID), nil
+	})
+}
+
+// StreamPlayerIDs - id игроков из каталога или, если tombstoned, из коллекции tombstones
+func (p *PlayerRepo) StreamPlayerIDs(ctx context.Context, tombstoned bool, yield func(playerID string) error) error {
+	if tombstoned {
+		return streamIDs(ctx, p.tombstones, yield)
+	}
+	return streamIDs(ctx, p.mngCollection, yield)
+}
@@ -0,0 +1,76 @@
+package mongo_rp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	mongodb "github.com/romeros69/basket/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PlayoffRepo - сетки плей-офф, по одной на лигу
+type PlayoffRepo struct {
+	mngCollection *mongo.Collection
+}
+
+func NewPlayoffRepo(mng *mongodb.Mongo, collectionName string) *PlayoffRepo {
+	return &PlayoffRepo{
+		mngCollection: mng.DB.Collection(collectionName),
+	}
+}
+
+var _ usecase.PlayoffRp = (*PlayoffRepo)(nil)
+
+func (p *PlayoffRepo) CreateBracket(ctx context.Context, bracket *entity.PlayoffBracket) error {
+	filter := bson.M{
+		"league": bracket.League,
+	}
+
+	res, err := p.mngCollection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": bracket}, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("create playoffs: %w", err)
+	}
+	if res.UpsertedCount == 0 {
+		return apperrors.ErrPlayoffsAlreadyExist
+	}
+
+	return nil
+}
+
+func (p *PlayoffRepo) GetBracket(ctx context.Context, leagueID string) (*entity.PlayoffBracket, error) {
+	filter := bson.M{
+		"league": leagueID,
+	}
+
+	bracket := new(entity.PlayoffBracket)
+	if err := p.mngCollection.FindOne(ctx, filter).Decode(bracket); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperrors.ErrPlayoffsNotFound
+		}
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return bracket, nil
+}
+
+func (p *PlayoffRepo) UpdateBracket(ctx context.Context, bracket *entity.PlayoffBracket) error {
+	filter := bson.M{
+		"league": bracket.League,
+	}
+
+	res, err := p.mngCollection.ReplaceOne(ctx, filter, bracket)
+	if err != nil {
+		return fmt.Errorf("mongo error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return apperrors.ErrPlayoffsNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package mongo_rp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	mongodb "github.com/romeros69/basket/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TeamRepo struct {
+	mngCollection *mongo.Collection
+}
+
+func NewTeamRepo(mng *mongodb.Mongo, collectionName string) *TeamRepo {
+	return &TeamRepo{
+		mngCollection: mng.DB.Collection(collectionName),
+	}
+}
+
+var _ usecase.TeamRp = (*TeamRepo)(nil)
+
+// teamDocument - документ команды вместе с _id, чтобы вернуть id в entity.Team
+type teamDocument struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	entity.Team `bson:",inline"`
+}
+
+func (d *teamDocument) team() *entity.Team {
+	team := d.Team
+	team.ID = d.ID.Hex()
+	return &team
+}
+
+func (t *TeamRepo) CreateTeam(ctx context.Context, team *entity.Team) (string, error) {
+	res, err := t.mngCollection.InsertOne(ctx, team)
+	if err != nil {
+		return "", fmt.Errorf("create team: %w", err)
+	}
+
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (t *TeamRepo) UpdateTeam(ctx context.Context, teamID string, team *entity.Team) (*entity.Team, error) {
+	objID, err := primitive.ObjectIDFromHex(teamID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidTeamID
+	}
+
+	filter := bson.M{
+		"_id": objID,
+	}
+
+	if err = t.mngCollection.FindOneAndReplace(ctx, filter, team).Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperrors.ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+	team.ID = teamID
+
+	return team, nil
+}
+
+func (t *TeamRepo) GetTeam(ctx context.Context, teamID string) (*entity.Team, error) {
+	objID, err := primitive.ObjectIDFromHex(teamID)
+	if err != nil {
+		return nil, apperrors.ErrInvalidTeamID
+	}
+
+	filter := bson.M{
+		"_id": objID,
+	}
+
+	doc := new(teamDocument)
+	if err := t.mngCollection.FindOne(ctx, filter).Decode(doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperrors.ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return doc.team(), nil
+}
+
+func (t *TeamRepo) DeleteTeam(ctx context.Context, teamID string) error {
+	objID, err := primitive.ObjectIDFromHex(teamID)
+	if err != nil {
+		return apperrors.ErrInvalidTeamID
+	}
+
+	filter := bson.M{
+		"_id": objID,
+	}
+
+	if err = t.mngCollection.FindOneAndDelete(ctx, filter).Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return apperrors.ErrTeamNotFound
+		}
+		return fmt.Errorf("mongo error: %w", err)
+	}
+
+	return nil
+}
+
+func (t *TeamRepo) GetTeamList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Team, error) {
+	return t.find(ctx, bson.M{}, options.Find().SetLimit(pageSize).SetSkip((pageNumber-1)*pageSize))
+}
+
+func (t *TeamRepo) GetTeamsByLeague(ctx context.Context, leagueID string) ([]*entity.Team, error) {
+	filter := bson.M{
+		"league": leagueID,
+	}
+
+	return t.find(ctx, filter)
+}
+
+func (t *TeamRepo) find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) ([]*entity.Team, error) {
+	cursor, err := t.mngCollection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var teams []*entity.Team
+	for cursor.Next(ctx) {
+		var doc teamDocument
+		err := cursor.Decode(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("mongo error: %w", err)
+		}
+		teams = append(teams, doc.team())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("mongo error: %w", err)
+	}
+
+	return teams, nil
+}
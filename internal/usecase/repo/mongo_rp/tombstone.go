@@ -0,0 +1,76 @@
+package mongo_rp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tombstonesSuffix - документы, удалённые с сохранением истории, переносятся в коллекцию <name>_tombstones,
+// поэтому чтение из основной коллекции не нужно фильтровать
+const tombstonesSuffix = "_tombstones"
+
+// tombstone - переносит документ в коллекцию tombstones с отметкой deleted_at.
+// Сначала документ копируется, потом удаляется, так что повтор после сбоя между шагами безопасен
+func tombstone(ctx context.Context, from, to *mongo.Collection, objID primitive.ObjectID, errNotFound error) error {
+	filter := bson.M{
+		"_id": objID,
+	}
+
+	var doc bson.M
+	if err := from.FindOne(ctx, filter).Decode(&doc); err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("mongo error: %w", err)
+		}
+		// документ уже перенесён прошлой попыткой
+		if err = to.FindOne(ctx, filter).Err(); err == nil {
+			return nil
+		} else if errors.Is(err, mongo.ErrNoDocuments) {
+			return errNotFound
+		}
+		return fmt.Errorf("mongo error: %w", err)
+	}
+
+	doc["deleted_at"] = time.Now().UTC()
+	if _, err := to.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true)); err != nil {
+		return fmt.Errorf("mongo error: %w", err)
+	}
+	if _, err := from.DeleteOne(ctx, filter); err != nil {
+		return fmt.Errorf("mongo error: %w", err)
+	}
+
+	return nil
+}
+
+// streamIDs - передаёт в yield id всех документов коллекции, не загружая сами документы
+func streamIDs(ctx context.Context, coll *mongo.Collection, yield func(id string) error) error {
+	cursor, err := coll.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return fmt.Errorf("mongo error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return fmt.Errorf("mongo error: %w", err)
+		}
+		if err := yield(doc.ID.Hex()); err != nil {
+			return err
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("mongo error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package neo4j_rp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// readRows - выполняет запрос чтения и передаёт каждую строку в scan
+func (sa *StatAwardsRepo) readRows(ctx context.Context, query string, params map[string]interface{}, reset func(), scan func(record *neo4j.Record)) error {
+	_, err := sa.neoDB.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		// при повторе транзакции строки читаются заново
+		reset()
+
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for result.Next(ctx) {
+			scan(result.Record())
+		}
+		return nil, result.Err()
+	})
+
+	return err
+}
+
+// notDeleted - условие WHERE: ни один из узлов vars не помечен удалённым. Аналитика не учитывает
+// удалённые с сохранением истории сущности и узлы, оставленные событиями удаления outbox
+func notDeleted(vars ...string) string {
+	conditions := make([]string, 0, len(vars))
+	for _, v := range vars {
+		conditions = append(conditions, "coalesce("+v+".deleted, false) = false")
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+func recordString(record *neo4j.Record, key string) string {
+	value, _ := record.Get(key)
+	s, _ := value.(string)
+	return s
+}
+
+func recordInt(record *neo4j.Record, key string) int {
+	value, _ := record.Get(key)
+	i, _ := value.(int64)
+	return int(i)
+}
+
+func recordFloat(record *neo4j.Record, key string) float64 {
+	value, _ := record.Get(key)
+	f, _ := value.(float64)
+	return f
+}
+
+// GetCoAwardedPlayers - игроки, награждённые в тех же турнирах, что и заданный, и сколько у них общих матчей и турниров
+func (sa *StatAwardsRepo) GetCoAwardedPlayers(ctx context.Context, playerID string, limit int) ([]entity.CoAwardedPlayer, error) {
+	var players []entity.CoAwardedPlayer
+
+	// общий матч всегда означает и общий турнир, поэтому достаточно начать с турниров
+	query := `
+		MATCH (p:Player {id: $playerId})-[:AWARDED_IN]->(pm:Match)-[:PART_OF_TOURNAMENT]->(t:Tournament)
+		      <-[:PART_OF_TOURNAMENT]-(om:Match)<-[:AWARDED_IN]-(o:Player)
+		WHERE o <> p AND ` + notDeleted("pm", "om", "o", "t") + `
+		WITH p, o, count(DISTINCT t) AS shared_tournaments
+		OPTIONAL MATCH (p)-[:AWARDED_IN]->(m:Match)<-[:AWARDED_IN]-(o)
+		WHERE ` + notDeleted("m") + `
+		WITH o, shared_tournaments, count(DISTINCT m) AS shared_matches
+		RETURN o.id AS player, o.name AS name, o.surname AS surname, shared_matches, shared_tournaments
+		ORDER BY shared_matches DESC, shared_tournaments DESC, player
+		LIMIT $limit`
+	err := sa.readRows(ctx, query, map[string]interface{}{
+		"playerId": playerID,
+		"limit":    limit,
+	}, func() { players = nil }, func(record *neo4j.Record) {
+		players = append(players, entity.CoAwardedPlayer{
+			Player:            recordString(record, "player"),
+			Name:              recordString(record, "name"),
+			Surname:           recordString(record, "surname"),
+			SharedMatches:     recordInt(record, "shared_matches"),
+			SharedTournaments: recordInt(record, "shared_tournaments"),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return players, nil
+}
+
+// GetMostDecoratedPlayers - игроки турнира по убыванию числа вручений, затем разных наград
+func (sa *StatAwardsRepo) GetMostDecoratedPlayers(ctx context.Context, tournamentID string, limit int) ([]entity.DecoratedPlayer, error) {
+	var players []entity.DecoratedPlayer
+
+	query := `
+		MATCH (:Tournament {id: $tournamentId})<-[:PART_OF_TOURNAMENT]-(m:Match)<-[g:AWARDED_IN]-(p:Player)
+		WHERE ` + notDeleted("m", "p") + `
+		WITH p, count(g) AS awards, count(DISTINCT g.reward) AS distinct_awards
+		RETURN p.id AS player, p.name AS name, p.surname AS surname, awards, distinct_awards
+		ORDER BY awards DESC, distinct_awards DESC, player
+		LIMIT $limit`
+	err := sa.readRows(ctx, query, map[string]interface{}{
+		"tournamentId": tournamentID,
+		"limit":        limit,
+	}, func() { players = nil }, func(record *neo4j.Record) {
+		players = append(players, entity.DecoratedPlayer{
+			Player:         recordString(record, "player"),
+			Name:           recordString(record, "name"),
+			Surname:        recordString(record, "surname"),
+			Awards:         recordInt(record, "awards"),
+			DistinctAwards: recordInt(record, "distinct_awards"),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return players, nil
+}
+
+// GetAwardCoOccurrence - пары наград, которые получали одни и те же игроки; пустой awardID - все пары
+func (sa *StatAwardsRepo) GetAwardCoOccurrence(ctx context.Context, awardID string, limit int) ([]entity.AwardCoOccurrence, error) {
+	var pairs []entity.AwardCoOccurrence
+
+	query := `
+		MATCH (r1:Reward)-[:AWARDED_TO]->(p:Player)<-[:AWARDED_TO]-(r2:Reward)
+		WHERE (($awardId = '' AND r1.id < r2.id) OR (r1.id = $awardId AND r2 <> r1)) AND ` + notDeleted("r1", "r2", "p") + `
+		WITH r1, r2, count(DISTINCT p) AS players
+		RETURN r1.id AS award, r1.title AS award_title, r2.id AS other_award, r2.title AS other_award_title, players
+		ORDER BY players DESC, award, other_award
+		LIMIT $limit`
+	err := sa.readRows(ctx, query, map[string]interface{}{
+		"awardId": awardID,
+		"limit":   limit,
+	}, func() { pairs = nil }, func(record *neo4j.Record) {
+		pairs = append(pairs, entity.AwardCoOccurrence{
+			Award:           recordString(record, "award"),
+			AwardTitle:      recordString(record, "award_title"),
+			OtherAward:      recordString(record, "other_award"),
+			OtherAwardTitle: recordString(record, "other_award_title"),
+			Players:         recordInt(record, "players"),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+// GetDegreeCentrality - игроки по убыванию степени в сети совместных награждений в одних матчах
+func (sa *StatAwardsRepo) GetDegreeCentrality(ctx context.Context, limit int) ([]entity.PlayerCentrality, error) {
+	var players []entity.PlayerCentrality
+
+	query := `
+		MATCH (n:Player)-[:AWARDED_IN]->(nm:Match)
+		WHERE ` + notDeleted("n", "nm") + `
+		WITH count(DISTINCT n) AS total
+		MATCH (p:Player)-[:AWARDED_IN]->(m:Match)<-[:AWARDED_IN]-(o:Player)
+		WHERE o <> p AND ` + notDeleted("p", "m", "o") + `
+		WITH total, p, count(DISTINCT o) AS degree
+		RETURN p.id AS player, p.name AS name, p.surname AS surname, degree,
+		       CASE WHEN total > 1 THEN toFloat(degree) / (total - 1) ELSE 0.0 END AS centrality
+		ORDER BY degree DESC, player
+		LIMIT $limit`
+	err := sa.readRows(ctx, query, map[string]interface{}{
+		"limit": limit,
+	}, func() { players = nil }, func(record *neo4j.Record) {
+		players = append(players, entity.PlayerCentrality{
+			Player:     recordString(record, "player"),
+			Name:       recordString(record, "name"),
+			Surname:    recordString(record, "surname"),
+			Degree:     recordInt(record, "degree"),
+			Centrality: recordFloat(record, "centrality"),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return players, nil
+}
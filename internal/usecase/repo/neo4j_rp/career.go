@@ -0,0 +1,252 @@
+package neo4j_rp
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+// Граф карьеры живёт в той же базе, что и граф наград, и делит с ним узлы игроков и матчей:
+// (Player)-[:PLAYED_FOR {from, to}]->(Team) - состав, to пустой у текущей команды,
+// (Player)-[:PLAYED_IN {team, opponent, date}]->(Match) - сыгранный матч
+var _ usecase.CareerRp = (*StatAwardsRepo)(nil)
+
+// SetPlayerTeam - закрывает текущие членства в других командах и открывает членство в новой, если его нет
+func (sa *StatAwardsRepo) SetPlayerTeam(ctx context.Context, membership entity.TeamMembership) error {
+	_, err := sa.neoDB.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MERGE (p:Player {id: $playerId})
+			SET p += $playerProps
+			WITH p
+			OPTIONAL MATCH (p)-[open:PLAYED_FOR]->(other:Team)
+			WHERE open.to IS NULL AND other.id <> $teamId
+			SET open.to = $since
+			WITH DISTINCT p
+			WHERE $teamId <> ''
+			MERGE (t:Team {id: $teamId})
+			WITH p, t
+			OPTIONAL MATCH (p)-[current:PLAYED_FOR]->(t)
+			WHERE current.to IS NULL
+			WITH p, t, current
+			WHERE current IS NULL
+			CREATE (p)-[:PLAYED_FOR {from: $since}]->(t)`
+		_, err := tx.Run(ctx, query, map[string]interface{}{
+			"playerId":    membership.Player,
+			"playerProps": nonEmptyProperties("name", membership.Name, "surname", membership.Surname),
+			"teamId":      membership.Team,
+			"since":       membership.Since,
+		})
+		return nil, err
+	})
+
+	return err
+}
+
+// RecordAppearances - связи игроков с матчами одним запросом; повторная запись обновляет команду, соперника и дату
+func (sa *StatAwardsRepo) RecordAppearances(ctx context.Context, appearances []entity.Appearance) error {
+	if len(appearances) == 0 {
+		return nil
+	}
+
+	rows := make([]map[string]interface{}, 0, len(appearances))
+	for _, a := range appearances {
+		rows = append(rows, map[string]interface{}{
+			"player":   a.Player,
+			"match":    a.Match,
+			"team":     a.Team,
+			"opponent": a.Opponent,
+			"date":     a.Date,
+		})
+	}
+
+	_, err := sa.neoDB.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			UNWIND $appearances AS a
+			MERGE (p:Player {id: a.player})
+			MERGE (m:Match {id: a.match})
+			MERGE (p)-[r:PLAYED_IN]->(m)
+			SET r.team = a.team, r.opponent = a.opponent, r.date = a.date`
+		_, err := tx.Run(ctx, query, map[string]interface{}{
+			"appearances": rows,
+		})
+		return nil, err
+	})
+
+	return err
+}
+
+// GetMemberships - членства игрока в командах по дате начала
+func (sa *StatAwardsRepo) GetMemberships(ctx context.Context, playerID string) ([]entity.TeamMembership, error) {
+	var memberships []entity.TeamMembership
+
+	query := `
+		MATCH (:Player {id: $playerId})-[r:PLAYED_FOR]->(t:Team)
+		RETURN t.id AS team, r.from AS since, r.to AS until
+		ORDER BY since`
+	err := sa.readRows(ctx, query, map[string]interface{}{
+		"playerId": playerID,
+	}, func() { memberships = nil }, func(record *neo4j.Record) {
+		memberships = append(memberships, entity.TeamMembership{
+			Player: playerID,
+			Team:   recordString(record, "team"),
+			Since:  recordString(record, "since"),
+			Until:  recordString(record, "until"),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+// ReplaceMemberships - заменяет все членства игрока в командах на memberships одной транзакцией
+func (sa *StatAwardsRepo) ReplaceMemberships(ctx context.Context, playerID string, memberships []entity.TeamMembership) error {
+	rows := make([]map[string]interface{}, 0, len(memberships))
+	var name, surname string
+	for _, m := range memberships {
+		var until interface{}
+		if m.Until != "" {
+			until = m.Until
+		}
+		rows = append(rows, map[string]interface{}{"team": m.Team, "from": m.Since, "to": until})
+		name, surname = m.Name, m.Surname
+	}
+
+	_, err := sa.neoDB.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MERGE (p:Player {id: $playerId})
+			SET p += $playerProps
+			WITH p
+			OPTIONAL MATCH (p)-[old:PLAYED_FOR]->(:Team)
+			DELETE old
+			WITH DISTINCT p
+			UNWIND $memberships AS m
+			MERGE (t:Team {id: m.team})
+			CREATE (p)-[r:PLAYED_FOR {from: m.from}]->(t)
+			SET r.to = m.to`
+		_, err := tx.Run(ctx, query, map[string]interface{}{
+			"playerId":    playerID,
+			"playerProps": nonEmptyProperties("name", name, "surname", surname),
+			"memberships": rows,
+		})
+		return nil, err
+	})
+
+	return err
+}
+
+// GetTeammates - игроки с пересекающимся по времени членством в команде или сыгравшие за одну команду в одном матче
+func (sa *StatAwardsRepo) GetTeammates(ctx context.Context, playerID string) ([]entity.Teammate, error) {
+	var teammates []entity.Teammate
+
+	query := `
+		CALL {
+			MATCH (p:Player {id: $playerId})-[a:PLAYED_FOR]->(t:Team)<-[b:PLAYED_FOR]-(o:Player)
+			WHERE o <> p AND a.from <= coalesce(b.to, $openEnd) AND b.from <= coalesce(a.to, $openEnd)
+			RETURN o, t.id AS team, a.to IS NULL AND b.to IS NULL AS current
+			UNION
+			MATCH (p:Player {id: $playerId})-[a:PLAYED_IN]->(:Match)<-[b:PLAYED_IN]-(o:Player)
+			WHERE o <> p AND a.team <> '' AND a.team = b.team
+			RETURN o, a.team AS team, false AS current
+		}
+		WITH o, collect(DISTINCT team) AS teams, sum(CASE WHEN current THEN 1 ELSE 0 END) > 0 AS current
+		RETURN o.id AS player, o.name AS name, o.surname AS surname, teams, current
+		ORDER BY player`
+	err := sa.readRows(ctx, query, map[string]interface{}{
+		"playerId": playerID,
+		"openEnd":  "9999-12-31",
+	}, func() { teammates = nil }, func(record *neo4j.Record) {
+		teams, _ := record.Get("teams")
+		current, _ := record.Get("current")
+
+		teammate := entity.Teammate{
+			Player:  recordString(record, "player"),
+			Name:    recordString(record, "name"),
+			Surname: recordString(record, "surname"),
+			Teams:   []string{},
+		}
+		for _, team := range teams.([]interface{}) {
+			teammate.Teams = append(teammate.Teams, team.(string))
+		}
+		teammate.Current, _ = current.(bool)
+		teammates = append(teammates, teammate)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return teammates, nil
+}
+
+// GetSeparation - кратчайшая цепочка через связи PLAYED_IN: игрок и матч чередуются, одна степень - два шага
+func (sa *StatAwardsRepo) GetSeparation(ctx context.Context, playerID, otherID string, maxDegrees int) (*entity.Separation, error) {
+	var separation *entity.Separation
+
+	query := `
+		MATCH (a:Player {id: $playerId}), (b:Player {id: $otherId})
+		MATCH path = shortestPath((a)-[:PLAYED_IN*..` + strconv.Itoa(2*maxDegrees) + `]-(b))
+		RETURN [n IN nodes(path) | n.id] AS ids,
+		       [n IN nodes(path) | coalesce(n.name, '')] AS names,
+		       [n IN nodes(path) | coalesce(n.surname, '')] AS surnames`
+	err := sa.readRows(ctx, query, map[string]interface{}{
+		"playerId": playerID,
+		"otherId":  otherID,
+	}, func() { separation = nil }, func(record *neo4j.Record) {
+		ids, _ := record.Get("ids")
+		names, _ := record.Get("names")
+		surnames, _ := record.Get("surnames")
+		nodes, nodeNames, nodeSurnames := ids.([]interface{}), names.([]interface{}), surnames.([]interface{})
+
+		separation = &entity.Separation{Degrees: (len(nodes) - 1) / 2}
+		for i := 0; i < len(nodes); i += 2 {
+			step := entity.SeparationStep{
+				Player:  nodes[i].(string),
+				Name:    nodeNames[i].(string),
+				Surname: nodeSurnames[i].(string),
+			}
+			if i+1 < len(nodes) {
+				step.Match = nodes[i+1].(string)
+			}
+			separation.Path = append(separation.Path, step)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return separation, nil
+}
+
+// GetCommonOpponents - команды-соперники из связей PLAYED_IN обоих игроков
+func (sa *StatAwardsRepo) GetCommonOpponents(ctx context.Context, playerID, otherID string) ([]entity.CommonOpponent, error) {
+	var opponents []entity.CommonOpponent
+
+	query := `
+		MATCH (:Player {id: $playerId})-[a:PLAYED_IN]->(:Match)
+		WHERE a.opponent <> ''
+		WITH a.opponent AS team, count(*) AS games
+		MATCH (:Player {id: $otherId})-[b:PLAYED_IN]->(:Match)
+		WHERE b.opponent = team
+		WITH team, games, count(*) AS other_games
+		RETURN team, games, other_games
+		ORDER BY games + other_games DESC, team`
+	err := sa.readRows(ctx, query, map[string]interface{}{
+		"playerId": playerID,
+		"otherId":  otherID,
+	}, func() { opponents = nil }, func(record *neo4j.Record) {
+		opponents = append(opponents, entity.CommonOpponent{
+			Team:       recordString(record, "team"),
+			Games:      recordInt(record, "games"),
+			OtherGames: recordInt(record, "other_games"),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return opponents, nil
+}
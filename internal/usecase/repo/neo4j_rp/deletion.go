@@ -0,0 +1,133 @@
+package neo4j_rp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+// nodeLabel - метка узла графа наград для сущности каталога
+func nodeLabel(entityKind string) (string, error) {
+	switch entityKind {
+	case entity.DeletionEntityPlayer:
+		return "Player", nil
+	case entity.DeletionEntityGame:
+		return "Match", nil
+	default:
+		return "", fmt.Errorf("awards graph has no nodes for %q", entityKind)
+	}
+}
+
+// awardRelationships - связи узла с наградами; связи графа карьеры ссылками не считаются:
+// состав повторяет каталог, а сыгранный матч всегда сопровождается статистикой
+var awardRelationships = map[string]string{
+	"Player": "AWARDED_TO|AWARDED_IN",
+	"Match":  "AWARDED_FOR_MATCH|PART_OF_TOURNAMENT|AWARDED_IN",
+}
+
+// HasRecords - есть ли у узла игрока или матча связи с наградами
+func (sa *StatAwardsRepo) HasRecords(ctx context.Context, entityKind, id string) (bool, error) {
+	label, err := nodeLabel(entityKind)
+	if err != nil {
+		return false, err
+	}
+
+	linked, err := sa.neoDB.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (n:` + label + ` {id: $id})-[:` + awardRelationships[label] + `]-()
+			RETURN count(*) > 0 AS linked`
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"id": id,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+		linked, _ := record.Get("linked")
+		return linked, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return linked.(bool), nil
+}
+
+// DeleteNode - удаляет узел игрока или матча со всеми связями; узлы наград общие для всех награждённых и остаются
+func (sa *StatAwardsRepo) DeleteNode(ctx context.Context, entityKind, id string) error {
+	label, err := nodeLabel(entityKind)
+	if err != nil {
+		return err
+	}
+
+	_, err = sa.neoDB.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (n:` + label + ` {id: $id})
+			DETACH DELETE n`
+		_, err := tx.Run(ctx, query, map[string]interface{}{
+			"id": id,
+		})
+		return nil, err
+	})
+
+	return err
+}
+
+// TombstoneNode - помечает узел удалённым, связи и история наград сохраняются
+func (sa *StatAwardsRepo) TombstoneNode(ctx context.Context, entityKind, id string) error {
+	label, err := nodeLabel(entityKind)
+	if err != nil {
+		return err
+	}
+
+	_, err = sa.neoDB.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (n:` + label + ` {id: $id})
+			SET n.deleted = true, n.deleted_at = coalesce(n.deleted_at, datetime())`
+		_, err := tx.Run(ctx, query, map[string]interface{}{
+			"id": id,
+		})
+		return nil, err
+	})
+
+	return err
+}
+
+// StreamNodeIDs - id узлов игроков или матчей и отметка удаления
+func (sa *StatAwardsRepo) StreamNodeIDs(ctx context.Context, entityKind string, yield func(id string, deleted bool) error) error {
+	label, err := nodeLabel(entityKind)
+	if err != nil {
+		return err
+	}
+
+	_, err = sa.neoDB.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (n:` + label + `)
+			RETURN n.id AS id, coalesce(n.deleted, false) AS deleted`
+		result, err := tx.Run(ctx, query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// при повторе транзакции id придут ещё раз, вызывающий код собирает их в множество
+		for result.Next(ctx) {
+			record := result.Record()
+
+			id, _ := record.Get("id")
+			deleted, _ := record.Get("deleted")
+
+			if err = yield(id.(string), deleted.(bool)); err != nil {
+				return nil, err
+			}
+		}
+		return nil, result.Err()
+	})
+
+	return err
+}
@@ -0,0 +1,86 @@
+package neo4j_rp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+)
+
+var _ usecase.OutboxProjection = (*StatAwardsRepo)(nil)
+
+// eventProperties - метка узла и свойства, которые событие каталога задаёт узлу графа наград
+func eventProperties(event *entity.OutboxEvent) (string, map[string]interface{}, error) {
+	props := map[string]interface{}{}
+
+	switch event.Entity {
+	case entity.OutboxEntityPlayer:
+		if p := event.Player; p != nil {
+			props["name"] = p.Name
+			props["surname"] = p.Surname
+			props["team"] = p.Team
+			props["role"] = p.Role
+			props["citizenship"] = p.Citizenship
+		}
+		return "Player", props, nil
+	case entity.OutboxEntityGame:
+		if g := event.Game; g != nil {
+			props["first_team"] = g.FirstTeam
+			props["second_team"] = g.SecondTeam
+			props["date"] = g.Date
+			props["league"] = g.League
+			props["status"] = g.Status
+			props["first_team_score"] = g.FirstTeamScore
+			props["second_team_score"] = g.SecondTeamScore
+		}
+		return "Match", props, nil
+	case entity.OutboxEntityLeague:
+		if l := event.League; l != nil {
+			props["name"] = l.Name
+			props["season"] = l.Season
+		}
+		return "Tournament", props, nil
+	case entity.OutboxEntityAward:
+		if a := event.Award; a != nil {
+			props["title"] = a.Tittle
+			props["description"] = a.Description
+		}
+		return "Reward", props, nil
+	default:
+		return "", nil, fmt.Errorf("unknown outbox entity %q", event.Entity)
+	}
+}
+
+// ApplyEvent - создаёт или обновляет узел сущности, если версия события новее применённой. Узел создаётся
+// и для сущности, у которой ещё нет записей о наградах, иначе событие потерялось бы. Событие delete
+// оставляет узел с отметкой deleted и его версией: опоздавший upsert не воскресит удалённую сущность,
+// а аналитика такие узлы пропускает
+func (sa *StatAwardsRepo) ApplyEvent(ctx context.Context, event *entity.OutboxEvent) error {
+	label, props, err := eventProperties(event)
+	if err != nil {
+		return err
+	}
+
+	set := `SET n += $props, n.version = $version`
+	if event.Type == entity.OutboxEventDelete {
+		set = `SET n.deleted = true, n.deleted_at = coalesce(n.deleted_at, datetime()), n.version = $version`
+	}
+
+	_, err = sa.neoDB.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MERGE (n:` + label + ` {id: $id})
+			WITH n
+			WHERE coalesce(n.version, 0) < $version
+			` + set
+		_, err := tx.Run(ctx, query, map[string]interface{}{
+			"id":      event.EntityID,
+			"version": event.Version,
+			"props":   props,
+		})
+		return nil, err
+	})
+
+	return err
+}
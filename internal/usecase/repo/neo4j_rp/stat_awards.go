@@ -2,7 +2,10 @@ package neo4j_rp
 
 import (
 	"context"
+	"fmt"
+
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/romeros69/basket/internal/apperrors"
 	"github.com/romeros69/basket/internal/entity"
 	"github.com/romeros69/basket/internal/usecase"
 	neo4jdb "github.com/romeros69/basket/pkg/neo4j"
@@ -20,63 +23,63 @@ func NewStatAwardsRepo(neoDB *neo4jdb.Neo4j) *StatAwardsRepo {
 
 var _ usecase.StatAwardsRp = (*StatAwardsRepo)(nil)
 
-// CreateRecord - Функция для создания записи (награждение игрока в рамках матча и турнира)
-func (sa *StatAwardsRepo) CreateRecord(ctx context.Context, rewardStat entity.RewardStat) error {
-	_, err := sa.neoDB.DB.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		query := `
-			MERGE (r:Reward {id: $rewardId})
-			MERGE (p:Player {id: $playerId})
-			MERGE (m:Match {id: $matchId})
-			MERGE (t:Tournament {id: $tournamentId})
-			MERGE (r)-[:AWARDED_TO]->(p)
-			MERGE (r)-[:AWARDED_FOR_MATCH]->(m)
-			MERGE (m)-[:PART_OF_TOURNAMENT]->(t)
-			RETURN r, p, m, t`
-		_, err := tx.Run(ctx, query, map[string]interface{}{
-			"rewardId":     rewardStat.Reward,
-			"playerId":     rewardStat.Player,
-			"matchId":      rewardStat.Match,
-			"tournamentId": rewardStat.Tournament,
-		})
-		return nil, err
-	})
+// Колонки узлов с их свойствами; имена свойств те же, что задаёт ApplyEvent
+const (
+	playerColumns     = `p.id AS player, p.name AS player_name, p.surname AS player_surname, p.team AS player_team`
+	matchColumns      = `m.id AS match, m.first_team AS match_first_team, m.second_team AS match_second_team, m.date AS match_date`
+	rewardColumns     = `r.id AS reward, r.title AS reward_title`
+	tournamentColumns = `t.id AS tournament, t.name AS tournament_name, t.season AS tournament_season`
+)
 
-	return err
+// nonEmptyProperties - свойства из пар ключ-значение без пустых значений: пустые не затирают записанные раньше
+func nonEmptyProperties(kv ...string) map[string]interface{} {
+	props := make(map[string]interface{})
+	for i := 0; i < len(kv); i += 2 {
+		if kv[i+1] != "" {
+			props[kv[i]] = kv[i+1]
+		}
+	}
+	return props
 }
 
-// ViewPlayersAndRewardsInTournament - Функция для просмотра какие игроки получили награды в рамках турнира
-func (sa *StatAwardsRepo) ViewPlayersAndRewardsInTournament(ctx context.Context, tournamentId string) ([]entity.RewardStat, error) {
-	var rewards []entity.RewardStat
+// nodeProperties - свойства узлов записи о награде
+func nodeProperties(rewardStat entity.RewardStat) map[string]interface{} {
+	return map[string]interface{}{
+		"playerProps": nonEmptyProperties("name", rewardStat.PlayerName, "surname", rewardStat.PlayerSurname,
+			"team", rewardStat.PlayerTeam),
+		"matchProps": nonEmptyProperties("first_team", rewardStat.MatchFirstTeam, "second_team", rewardStat.MatchSecondTeam,
+			"date", rewardStat.MatchDate),
+		"rewardProps":     nonEmptyProperties("title", rewardStat.RewardTitle),
+		"tournamentProps": nonEmptyProperties("name", rewardStat.TournamentName, "season", rewardStat.TournamentSeason),
+	}
+}
 
-	_, err := sa.neoDB.DB.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		query := `
-			MATCH (t:Tournament {id: $tournamentId})<-[:PART_OF_TOURNAMENT]-(m:Match)<-[:AWARDED_FOR_MATCH]-(r:Reward)-[:AWARDED_TO]->(p:Player)
-			RETURN p.id AS player, r.id AS reward, m.id AS match, t.id AS tournament`
-		result, err := tx.Run(ctx, query, map[string]interface{}{
-			"tournamentId": tournamentId,
-		})
-		if err != nil {
-			return nil, err
-		}
+// rewardStatFromRecord - запись о награде из строки результата; колонок, которых нет в запросе, нет и в записи
+func rewardStatFromRecord(record *neo4j.Record) entity.RewardStat {
+	return entity.RewardStat{
+		Player:           recordString(record, "player"),
+		Tournament:       recordString(record, "tournament"),
+		Match:            recordString(record, "match"),
+		Reward:           recordString(record, "reward"),
+		PlayerName:       recordString(record, "player_name"),
+		PlayerSurname:    recordString(record, "player_surname"),
+		PlayerTeam:       recordString(record, "player_team"),
+		MatchFirstTeam:   recordString(record, "match_first_team"),
+		MatchSecondTeam:  recordString(record, "match_second_team"),
+		MatchDate:        recordString(record, "match_date"),
+		RewardTitle:      recordString(record, "reward_title"),
+		TournamentName:   recordString(record, "tournament_name"),
+		TournamentSeason: recordString(record, "tournament_season"),
+	}
+}
 
-		for result.Next(ctx) {
-			record := result.Record()
-
-			player, _ := record.Get("player")
-			reward, _ := record.Get("reward")
-			match, _ := record.Get("match")
-			tournament, _ := record.Get("tournament")
-
-			rewards = append(rewards, entity.RewardStat{
-				Player:     player.(string),
-				Reward:     reward.(string),
-				Match:      match.(string),
-				Tournament: tournament.(string),
-			})
-		}
-		return nil, nil
-	})
+// viewRecords - выполняет запрос чтения записей о наградах
+func (sa *StatAwardsRepo) viewRecords(ctx context.Context, query string, params map[string]interface{}) ([]entity.RewardStat, error) {
+	var rewards []entity.RewardStat
 
+	err := sa.readRows(ctx, query, params, func() { rewards = nil }, func(record *neo4j.Record) {
+		rewards = append(rewards, rewardStatFromRecord(record))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -84,119 +87,203 @@ func (sa *StatAwardsRepo) ViewPlayersAndRewardsInTournament(ctx context.Context,
 	return rewards, nil
 }
 
-// ViewPlayersAndRewardsInMatch - Функция для просмотра какие игроки получили награды в рамках матча
-func (sa *StatAwardsRepo) ViewPlayersAndRewardsInMatch(ctx context.Context, matchId string) ([]entity.RewardStat, error) {
-	var rewards []entity.RewardStat
+// CreateRecord - Функция для создания записи (награждение игрока в рамках матча и турнира).
+// Свойства из записи попадают только в узлы, до которых ещё не дошли события каталога (нет version):
+// иначе запись с устаревшими именами затёрла бы более новую проекцию, см. ApplyEvent.
+// Узел награды общий для всех награждённых, поэтому само вручение - кто, что и в каком матче -
+// хранится связью (Player)-[:AWARDED_IN {reward}]->(Match), на ней строится аналитика.
+// Запрос сначала пишет в узел награды и тем берёт его блокировку, как и RetireReward, поэтому вручение
+// либо попадает в проверку удаления, либо видит отметку deleted и не создаётся
+func (sa *StatAwardsRepo) CreateRecord(ctx context.Context, rewardStat entity.RewardStat) error {
+	params := nodeProperties(rewardStat)
+	params["rewardId"] = rewardStat.Reward
+	params["playerId"] = rewardStat.Player
+	params["matchId"] = rewardStat.Match
+	params["tournamentId"] = rewardStat.Tournament
 
-	_, err := sa.neoDB.DB.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err := sa.neoDB.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		query := `
-			MATCH (m:Match {id: $matchId})<-[:AWARDED_FOR_MATCH]-(r:Reward)-[:AWARDED_TO]->(p:Player)
-			RETURN p.id AS player, r.id AS reward, m.id AS match`
-		result, err := tx.Run(ctx, query, map[string]interface{}{
-			"matchId": matchId,
-		})
+			MERGE (r:Reward {id: $rewardId})
+			SET r.granted_at = datetime()
+			WITH r WHERE r.deleted IS NULL
+			MERGE (p:Player {id: $playerId})
+			MERGE (m:Match {id: $matchId})
+			MERGE (t:Tournament {id: $tournamentId})
+			SET r += CASE WHEN r.version IS NULL THEN $rewardProps ELSE {} END,
+			    p += CASE WHEN p.version IS NULL THEN $playerProps ELSE {} END,
+			    m += CASE WHEN m.version IS NULL THEN $matchProps ELSE {} END,
+			    t += CASE WHEN t.version IS NULL THEN $tournamentProps ELSE {} END
+			MERGE (r)-[:AWARDED_TO]->(p)
+			MERGE (r)-[:AWARDED_FOR_MATCH]->(m)
+			MERGE (m)-[:PART_OF_TOURNAMENT]->(t)
+			MERGE (p)-[:AWARDED_IN {reward: $rewardId}]->(m)
+			RETURN r.id AS reward`
+		result, err := tx.Run(ctx, query, params)
 		if err != nil {
 			return nil, err
 		}
 
-		for result.Next(ctx) {
-			record := result.Record()
-
-			player, _ := record.Get("player")
-			reward, _ := record.Get("reward")
-			match, _ := record.Get("match")
-
-			rewards = append(rewards, entity.RewardStat{
-				Player: player.(string),
-				Reward: reward.(string),
-				Match:  match.(string),
-			})
+		records, err := result.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == 0 {
+			return nil, fmt.Errorf("%w: award %s is deleted", apperrors.ErrAwardNotFound, rewardStat.Reward)
 		}
 		return nil, nil
 	})
 
-	if err != nil {
-		return nil, err
-	}
-
-	return rewards, nil
+	return err
 }
 
-// ViewRewardsForPlayer - Функция для просмотра наград игрока и информации о матче и турнире, где была получена награда
-func (sa *StatAwardsRepo) ViewRewardsForPlayer(ctx context.Context, playerId string) ([]entity.RewardStat, error) {
-	var rewards []entity.RewardStat
-
-	_, err := sa.neoDB.DB.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		query := `
-			MATCH (p:Player {id: $playerId})<-[:AWARDED_TO]-(r:Reward)-[:AWARDED_FOR_MATCH]->(m:Match)-[:PART_OF_TOURNAMENT]->(t:Tournament)
-			RETURN r.id AS reward, m.id AS match, t.id AS tournament`
-		result, err := tx.Run(ctx, query, map[string]interface{}{
-			"playerId": playerId,
-		})
+// BackfillAwardedIn - восстанавливает связи AWARDED_IN для записей, сделанных до их появления. По старым связям
+// вручение однозначно, только если у награды один получатель или один матч: тогда каждый получатель получил её
+// в каждом матче. Награды, у которых получатели без AWARDED_IN остались и восстановить их нельзя, возвращаются
+// в ambiguous: такие записи нужно создать заново
+func (sa *StatAwardsRepo) BackfillAwardedIn(ctx context.Context) (created int, ambiguous []string, err error) {
+	_, err = sa.neoDB.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		created, ambiguous = 0, nil
+
+		result, err := tx.Run(ctx, `
+			MATCH (r:Reward)-[:AWARDED_TO]->(p:Player)
+			WITH r, collect(DISTINCT p) AS players
+			MATCH (r)-[:AWARDED_FOR_MATCH]->(m:Match)
+			WITH r, players, collect(DISTINCT m) AS matches
+			WHERE size(players) = 1 OR size(matches) = 1
+			UNWIND players AS p
+			UNWIND matches AS m
+			MERGE (p)-[:AWARDED_IN {reward: r.id}]->(m)`, nil)
+		if err != nil {
+			return nil, err
+		}
+		summary, err := result.Consume(ctx)
 		if err != nil {
 			return nil, err
 		}
+		created = summary.Counters().RelationshipsCreated()
 
+		result, err = tx.Run(ctx, `
+			MATCH (r:Reward)-[:AWARDED_TO]->(p:Player)
+			WHERE NOT (p)-[:AWARDED_IN {reward: r.id}]->(:Match)
+			RETURN DISTINCT r.id AS reward
+			ORDER BY reward`, nil)
+		if err != nil {
+			return nil, err
+		}
 		for result.Next(ctx) {
-			record := result.Record()
-
-			reward, _ := record.Get("reward")
-			match, _ := record.Get("match")
-			tournament, _ := record.Get("tournament")
-
-			rewards = append(rewards, entity.RewardStat{
-				Reward:     reward.(string),
-				Match:      match.(string),
-				Tournament: tournament.(string),
-				Player:     playerId,
-			})
+			ambiguous = append(ambiguous, recordString(result.Record(), "reward"))
 		}
-		return nil, nil
+		return nil, result.Err()
 	})
 
-	if err != nil {
-		return nil, err
-	}
+	return created, ambiguous, err
+}
 
-	return rewards, nil
+// Чтение записей идёт по связям AWARDED_IN: узел награды общий, и путь через AWARDED_TO и AWARDED_FOR_MATCH
+// соединил бы каждого получателя награды с каждым матчем, где её вручали
+
+// ViewPlayersAndRewardsInTournament - Функция для просмотра какие игроки получили награды в рамках турнира
+func (sa *StatAwardsRepo) ViewPlayersAndRewardsInTournament(ctx context.Context, tournamentId string) ([]entity.RewardStat, error) {
+	query := `
+		MATCH (t:Tournament {id: $tournamentId})<-[:PART_OF_TOURNAMENT]-(m:Match)<-[g:AWARDED_IN]-(p:Player)
+		MATCH (r:Reward {id: g.reward})
+		RETURN ` + playerColumns + `, ` + rewardColumns + `, ` + matchColumns + `, ` + tournamentColumns
+	return sa.viewRecords(ctx, query, map[string]interface{}{
+		"tournamentId": tournamentId,
+	})
+}
+
+// ViewPlayersAndRewardsInMatch - Функция для просмотра какие игроки получили награды в рамках матча
+func (sa *StatAwardsRepo) ViewPlayersAndRewardsInMatch(ctx context.Context, matchId string) ([]entity.RewardStat, error) {
+	query := `
+		MATCH (m:Match {id: $matchId})<-[g:AWARDED_IN]-(p:Player)
+		MATCH (r:Reward {id: g.reward})
+		RETURN ` + playerColumns + `, ` + rewardColumns + `, ` + matchColumns
+	return sa.viewRecords(ctx, query, map[string]interface{}{
+		"matchId": matchId,
+	})
+}
+
+// ViewRewardsForPlayer - Функция для просмотра наград игрока и информации о матче и турнире, где была получена награда
+func (sa *StatAwardsRepo) ViewRewardsForPlayer(ctx context.Context, playerId string) ([]entity.RewardStat, error) {
+	query := `
+		MATCH (p:Player {id: $playerId})-[g:AWARDED_IN]->(m:Match)-[:PART_OF_TOURNAMENT]->(t:Tournament)
+		MATCH (r:Reward {id: g.reward})
+		RETURN ` + playerColumns + `, ` + rewardColumns + `, ` + matchColumns + `, ` + tournamentColumns
+	return sa.viewRecords(ctx, query, map[string]interface{}{
+		"playerId": playerId,
+	})
 }
 
 // ViewWhoGotSpecificReward - Функция для просмотра кто получил конкретную награду (с информацией о матче и турнире)
 func (sa *StatAwardsRepo) ViewWhoGotSpecificReward(ctx context.Context, rewardId string) ([]entity.RewardStat, error) {
-	var rewards []entity.RewardStat
+	query := `
+		MATCH (r:Reward {id: $rewardId})
+		MATCH (p:Player)-[:AWARDED_IN {reward: $rewardId}]->(m:Match)-[:PART_OF_TOURNAMENT]->(t:Tournament)
+		RETURN ` + playerColumns + `, ` + rewardColumns + `, ` + matchColumns + `, ` + tournamentColumns
+	return sa.viewRecords(ctx, query, map[string]interface{}{
+		"rewardId": rewardId,
+	})
+}
 
-	_, err := sa.neoDB.DB.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+// ViewWhoGotRewards - Функция для просмотра кто получил награды из списка, одним запросом на все награды
+func (sa *StatAwardsRepo) ViewWhoGotRewards(ctx context.Context, rewardIDs []string) ([]entity.RewardStat, error) {
+	query := `
+		UNWIND $rewardIds AS rewardId
+		MATCH (r:Reward {id: rewardId})
+		MATCH (p:Player)-[:AWARDED_IN {reward: rewardId}]->(m:Match)-[:PART_OF_TOURNAMENT]->(t:Tournament)
+		RETURN ` + playerColumns + `, ` + rewardColumns + `, ` + matchColumns + `, ` + tournamentColumns
+	return sa.viewRecords(ctx, query, map[string]interface{}{
+		"rewardIds": rewardIDs,
+	})
+}
+
+// RetireReward - помечает узел награды удалённым, если её ни разу не вручали. Отметка ставится до подсчёта
+// вручений, чтобы взять блокировку узла: CreateRecord с той же наградой ждёт конца транзакции.
+// Связи AWARDED_TO без AWARDED_IN (записи до backfill) тоже считаются вручениями
+func (sa *StatAwardsRepo) RetireReward(ctx context.Context, rewardID string) (bool, error) {
+	granted, err := sa.neoDB.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		query := `
-			MATCH (r:Reward {id: $rewardId})-[:AWARDED_TO]->(p:Player), 
-			      (r)-[:AWARDED_FOR_MATCH]->(m:Match)-[:PART_OF_TOURNAMENT]->(t:Tournament)
-			RETURN p.id AS player, m.id AS match, t.id AS tournament`
+			MERGE (r:Reward {id: $rewardId})
+			WITH r, r.deleted IS NOT NULL AS wasDeleted
+			SET r.deleted = true, r.deleted_at = coalesce(r.deleted_at, datetime())
+			WITH r, wasDeleted
+			OPTIONAL MATCH ()-[g:AWARDED_IN {reward: $rewardId}]->()
+			WITH r, wasDeleted, count(g) + size([(r)-[:AWARDED_TO]->() | 1]) > 0 AS granted
+			FOREACH (_ IN CASE WHEN granted AND NOT wasDeleted THEN [1] ELSE [] END | REMOVE r.deleted, r.deleted_at)
+			RETURN granted`
 		result, err := tx.Run(ctx, query, map[string]interface{}{
-			"rewardId": rewardId,
+			"rewardId": rewardID,
 		})
 		if err != nil {
 			return nil, err
 		}
 
-		for result.Next(ctx) {
-			record := result.Record()
-
-			player, _ := record.Get("player")
-			match, _ := record.Get("match")
-			tournament, _ := record.Get("tournament")
-
-			rewards = append(rewards, entity.RewardStat{
-				Player:     player.(string),
-				Match:      match.(string),
-				Tournament: tournament.(string),
-				Reward:     rewardId,
-			})
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, err
 		}
-		return nil, nil
+		granted, _ := record.Get("granted")
+		return granted, nil
 	})
-
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	return rewards, nil
-}
\ No newline at end of file
+	return granted.(bool), nil
+}
+
+// RestoreReward - снимает с узла награды отметку RetireReward
+func (sa *StatAwardsRepo) RestoreReward(ctx context.Context, rewardID string) error {
+	_, err := sa.neoDB.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (r:Reward {id: $rewardId})
+			REMOVE r.deleted, r.deleted_at`
+		_, err := tx.Run(ctx, query, map[string]interface{}{
+			"rewardId": rewardID,
+		})
+		return nil, err
+	})
+
+	return err
+}
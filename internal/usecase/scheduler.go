@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+const defaultScheduleRounds = 2
+
+type SchedulerUC struct {
+	leagueRp LeagueRp
+	teamRp   TeamRp
+	gameRp   GameRp
+}
+
+func NewSchedulerUC(leagueRp LeagueRp, teamRp TeamRp, gameRp GameRp) *SchedulerUC {
+	return &SchedulerUC{
+		leagueRp: leagueRp,
+		teamRp:   teamRp,
+		gameRp:   gameRp,
+	}
+}
+
+var _ Scheduler = (*SchedulerUC)(nil)
+
+// pairing - матч тура: хозяева и гости
+type pairing struct {
+	home string
+	away string
+}
+
+// teamCalendar - занятые дни и количество матчей по неделям для каждой команды
+type teamCalendar struct {
+	days  map[string]map[time.Time]bool
+	weeks map[string]map[int]int
+}
+
+// GenerateSchedule - круговой регулярный сезон: каждая пара встречается req.Rounds раз с чередованием поля,
+// туры раскладываются по окну дат с учётом запрещённых дат, лимита матчей в неделю и уже назначенных матчей команд.
+// Если у лиги уже есть матчи регулярного сезона, возвращается ErrScheduleExists: повторная генерация задвоила бы их
+func (s *SchedulerUC) GenerateSchedule(ctx context.Context, leagueID string, req *entity.ScheduleRequest) (*entity.Schedule, error) {
+	if _, err := s.leagueRp.GetLeague(ctx, leagueID); err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse(entity.GameDateLayout, req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: start_date %q must be in %s format", apperrors.ErrInvalidSchedule, req.StartDate, entity.GameDateLayout)
+	}
+	end, err := time.Parse(entity.GameDateLayout, req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: end_date %q must be in %s format", apperrors.ErrInvalidSchedule, req.EndDate, entity.GameDateLayout)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: end_date is before start_date", apperrors.ErrInvalidSchedule)
+	}
+
+	blackout := make(map[time.Time]bool, len(req.BlackoutDates))
+	for _, date := range req.BlackoutDates {
+		day, err := time.Parse(entity.GameDateLayout, date)
+		if err != nil {
+			return nil, fmt.Errorf("%w: blackout date %q must be in %s format", apperrors.ErrInvalidSchedule, date, entity.GameDateLayout)
+		}
+		blackout[day] = true
+	}
+
+	rounds := req.Rounds
+	if rounds == 0 {
+		rounds = defaultScheduleRounds
+	}
+	if rounds < 0 || req.MaxGamesPerWeek < 0 {
+		return nil, fmt.Errorf("%w: rounds and max_games_per_week can't be negative", apperrors.ErrInvalidSchedule)
+	}
+
+	if err = s.checkNoRegularSeason(ctx, leagueID); err != nil {
+		return nil, err
+	}
+
+	teams, err := s.scheduleTeams(ctx, leagueID, req.Teams)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar, err := s.teamCalendar(ctx, teams)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []time.Time
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if !blackout[day] {
+			candidates = append(candidates, day)
+		}
+	}
+
+	// сначала туры распределяются по всему окну, если не помещаются - идут плотно друг за другом
+	matchdays := roundRobin(teams, rounds)
+	days, err := assignDays(matchdays, candidates, req.MaxGamesPerWeek, calendar.clone(), true)
+	if errors.Is(err, apperrors.ErrScheduleDoesNotFit) {
+		days, err = assignDays(matchdays, candidates, req.MaxGamesPerWeek, calendar, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &entity.Schedule{
+		HomeGames: make(map[string]int, len(teams)),
+		AwayGames: make(map[string]int, len(teams)),
+	}
+	for i, matchday := range matchdays {
+		for _, p := range matchday {
+			schedule.Games = append(schedule.Games, entity.ScheduledGame{
+				Game: entity.Game{
+					FirstTeam:  p.home,
+					SecondTeam: p.away,
+					Date:       days[i].Format(entity.GameDateLayout),
+					Type:       entity.GameTypeRegular,
+					League:     leagueID,
+					Status:     entity.GameStatusScheduled,
+				},
+			})
+			schedule.HomeGames[p.home]++
+			schedule.AwayGames[p.away]++
+		}
+	}
+
+	if req.DryRun {
+		return schedule, nil
+	}
+
+	// все матчи пишутся разом: при ошибке расписание не создаётся частично
+	games := make([]*entity.Game, 0, len(schedule.Games))
+	for i := range schedule.Games {
+		games = append(games, &schedule.Games[i].Game)
+	}
+	gameIDs, err := s.gameRp.CreateGames(ctx, games)
+	if err != nil {
+		return nil, fmt.Errorf("schedule: %w", err)
+	}
+	for i, gameID := range gameIDs {
+		schedule.Games[i].GameID = gameID
+	}
+
+	return schedule, nil
+}
+
+// checkNoRegularSeason - у лиги ещё нет матчей регулярного сезона
+func (s *SchedulerUC) checkNoRegularSeason(ctx context.Context, leagueID string) error {
+	games, err := s.gameRp.GetGamesByLeague(ctx, leagueID)
+	if err != nil {
+		return err
+	}
+	for _, game := range games {
+		if game.Type == entity.GameTypeRegular {
+			return apperrors.ErrScheduleExists
+		}
+	}
+	return nil
+}
+
+// scheduleTeams - проверенный список команд расписания, по умолчанию все команды лиги
+func (s *SchedulerUC) scheduleTeams(ctx context.Context, leagueID string, teamIDs []string) ([]string, error) {
+	if len(teamIDs) == 0 {
+		teams, err := s.teamRp.GetTeamsByLeague(ctx, leagueID)
+		if err != nil {
+			return nil, err
+		}
+		for _, team := range teams {
+			teamIDs = append(teamIDs, team.ID)
+		}
+	}
+
+	seen := make(map[string]bool, len(teamIDs))
+	for _, teamID := range teamIDs {
+		if seen[teamID] {
+			return nil, fmt.Errorf("%w: team %q is listed twice", apperrors.ErrInvalidSchedule, teamID)
+		}
+		seen[teamID] = true
+
+		if err := checkTeamReference(ctx, s.teamRp, "teams", teamID); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(teamIDs) < 2 {
+		return nil, fmt.Errorf("%w: at least two teams are required", apperrors.ErrInvalidSchedule)
+	}
+
+	return teamIDs, nil
+}
+
+// teamCalendar - уже назначенные матчи команд, чтобы не ставить команде два матча в день
+func (s *SchedulerUC) teamCalendar(ctx context.Context, teams []string) (*teamCalendar, error) {
+	calendar := &teamCalendar{
+		days:  make(map[string]map[time.Time]bool, len(teams)),
+		weeks: make(map[string]map[int]int, len(teams)),
+	}
+
+	for _, team := range teams {
+		calendar.days[team] = make(map[time.Time]bool)
+		calendar.weeks[team] = make(map[int]int)
+
+		games, err := s.gameRp.GetGamesByTeam(ctx, team)
+		if err != nil {
+			return nil, err
+		}
+		for _, game := range games {
+			if game.Status == entity.GameStatusPostponed {
+				continue
+			}
+			day, err := game.ParseDate()
+			if err != nil {
+				continue
+			}
+			calendar.book(team, day)
+		}
+	}
+
+	return calendar, nil
+}
+
+func (c *teamCalendar) clone() *teamCalendar {
+	clone := &teamCalendar{
+		days:  make(map[string]map[time.Time]bool, len(c.days)),
+		weeks: make(map[string]map[int]int, len(c.weeks)),
+	}
+	for team, days := range c.days {
+		clone.days[team] = make(map[time.Time]bool, len(days))
+		for day := range days {
+			clone.days[team][day] = true
+		}
+	}
+	for team, weeks := range c.weeks {
+		clone.weeks[team] = make(map[int]int, len(weeks))
+		for week, games := range weeks {
+			clone.weeks[team][week] = games
+		}
+	}
+	return clone
+}
+
+func (c *teamCalendar) book(team string, day time.Time) {
+	c.days[team][day] = true
+	c.weeks[team][weekKey(day)]++
+}
+
+// fits - в этот день никто из команд тура не играет и не превышает лимит матчей в неделю
+func (c *teamCalendar) fits(matchday []pairing, day time.Time, maxGamesPerWeek int) bool {
+	for _, p := range matchday {
+		for _, team := range []string{p.home, p.away} {
+			if c.days[team][day] {
+				return false
+			}
+			if maxGamesPerWeek != 0 && c.weeks[team][weekKey(day)] >= maxGamesPerWeek {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// roundRobin - туры по круговому методу (одна команда неподвижна, остальные вращаются),
+// в каждом следующем круге хозяева и гости меняются местами
+func roundRobin(teams []string, rounds int) [][]pairing {
+	circle := append([]string(nil), teams...)
+	if len(circle)%2 != 0 {
+		circle = append(circle, "") // пропуск тура
+	}
+	n := len(circle)
+
+	homes := make(map[string]int, n)
+	lastHome := make(map[string]bool, n)
+
+	var firstRound [][]pairing
+	for r := 0; r < n-1; r++ {
+		var matchday []pairing
+		for i := 0; i < n/2; i++ {
+			a, b := circle[i], circle[n-1-i]
+			if a == "" || b == "" {
+				continue
+			}
+
+			// хозяином становится команда с меньшим числом домашних матчей,
+			// при равенстве - та, что играла предыдущий матч в гостях
+			p := pairing{home: a, away: b}
+			if homes[b] < homes[a] || (homes[b] == homes[a] && lastHome[a] && !lastHome[b]) {
+				p = pairing{home: b, away: a}
+			}
+			homes[p.home]++
+			lastHome[p.home], lastHome[p.away] = true, false
+
+			matchday = append(matchday, p)
+		}
+		firstRound = append(firstRound, matchday)
+
+		// вращение всех команд, кроме первой
+		last := circle[n-1]
+		copy(circle[2:], circle[1:n-1])
+		circle[1] = last
+	}
+
+	var matchdays [][]pairing
+	for round := 0; round < rounds; round++ {
+		for _, matchday := range firstRound {
+			md := make([]pairing, len(matchday))
+			for i, p := range matchday {
+				if round%2 == 1 {
+					p = pairing{home: p.away, away: p.home}
+				}
+				md[i] = p
+			}
+			matchdays = append(matchdays, md)
+		}
+	}
+
+	return matchdays
+}
+
+// assignDays - раскладывает туры по доступным дням, сохраняя их порядок; spread - равномерно по всему окну
+func assignDays(matchdays [][]pairing, candidates []time.Time, maxGamesPerWeek int, calendar *teamCalendar, spread bool) ([]time.Time, error) {
+	if len(candidates) < len(matchdays) {
+		return nil, fmt.Errorf("%w: %d matchdays, but only %d available days", apperrors.ErrScheduleDoesNotFit, len(matchdays), len(candidates))
+	}
+
+	stride := 1
+	if spread {
+		stride = len(candidates) / len(matchdays)
+	}
+	days := make([]time.Time, len(matchdays))
+	next := 0
+	for i, matchday := range matchdays {
+		j := i * stride
+		if j < next {
+			j = next
+		}
+		for j < len(candidates) && !calendar.fits(matchday, candidates[j], maxGamesPerWeek) {
+			j++
+		}
+		if j == len(candidates) {
+			return nil, fmt.Errorf("%w: no day left for matchday %d of %d", apperrors.ErrScheduleDoesNotFit, i+1, len(matchdays))
+		}
+
+		days[i] = candidates[j]
+		for _, p := range matchday {
+			calendar.book(p.home, candidates[j])
+			calendar.book(p.away, candidates[j])
+		}
+		next = j + 1
+	}
+
+	return days, nil
+}
+
+func weekKey(day time.Time) int {
+	year, week := day.ISOWeek()
+	return year*100 + week
+}
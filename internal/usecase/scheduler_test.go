@@ -0,0 +1,176 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/internal/usecase/repo/memory_rp"
+)
+
+// schedulerFixture - лига с командами в памяти
+type schedulerFixture struct {
+	scheduler *usecase.SchedulerUC
+	games     *memory_rp.GameRepo
+	league    string
+	teams     []string
+}
+
+func newSchedulerFixture(t *testing.T, teamCount int) *schedulerFixture {
+	t.Helper()
+	ctx := context.Background()
+	leagues, teams, games := memory_rp.NewLeagueRepo(), memory_rp.NewTeamRepo(), memory_rp.NewGameRepo()
+
+	leagueID, err := leagues.CreateLeague(ctx, &entity.League{Name: "test"})
+	if err != nil {
+		t.Fatalf("CreateLeague() error = %v", err)
+	}
+	f := &schedulerFixture{
+		scheduler: usecase.NewSchedulerUC(leagues, teams, games),
+		games:     games,
+		league:    leagueID,
+	}
+	for i := 0; i < teamCount; i++ {
+		teamID, err := teams.CreateTeam(ctx, &entity.Team{Name: string(rune('A' + i)), League: leagueID})
+		if err != nil {
+			t.Fatalf("CreateTeam() error = %v", err)
+		}
+		f.teams = append(f.teams, teamID)
+	}
+	return f
+}
+
+func TestGenerateSchedule(t *testing.T) {
+	tests := []struct {
+		name      string
+		teams     int
+		rounds    int
+		booked    int // матчей, уже назначенных первой команде на первые дни окна
+		wantGames int
+	}{
+		{name: "even team count", teams: 4, rounds: 2, wantGames: 12},
+		{name: "odd team count gets a bye", teams: 5, rounds: 1, wantGames: 10},
+		{name: "odd team count, two rounds", teams: 3, rounds: 2, wantGames: 6},
+		{name: "days already booked for a team", teams: 4, rounds: 1, booked: 3, wantGames: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newSchedulerFixture(t, tt.teams)
+
+			for i := 0; i < tt.booked; i++ {
+				_, err := f.games.CreateGame(ctx, &entity.Game{
+					FirstTeam:  f.teams[0],
+					SecondTeam: "other",
+					Date:       "0" + string(rune('1'+i)) + ".09.24",
+					Status:     entity.GameStatusScheduled,
+				})
+				if err != nil {
+					t.Fatalf("CreateGame() error = %v", err)
+				}
+			}
+
+			schedule, err := f.scheduler.GenerateSchedule(ctx, f.league, &entity.ScheduleRequest{
+				StartDate: "01.09.24",
+				EndDate:   "30.09.24",
+				Rounds:    tt.rounds,
+			})
+			if err != nil {
+				t.Fatalf("GenerateSchedule() error = %v", err)
+			}
+			if len(schedule.Games) != tt.wantGames {
+				t.Fatalf("games = %d, want %d", len(schedule.Games), tt.wantGames)
+			}
+
+			played := make(map[string]int)
+			pairs := make(map[[2]string]int)
+			for _, team := range f.teams {
+				games, err := f.games.GetGamesByTeam(ctx, team)
+				if err != nil {
+					t.Fatalf("GetGamesByTeam() error = %v", err)
+				}
+				days := make(map[string]bool)
+				for _, game := range games {
+					if days[game.Date] {
+						t.Errorf("team %s plays twice on %s", team, game.Date)
+					}
+					days[game.Date] = true
+				}
+			}
+			for _, scheduled := range schedule.Games {
+				game := scheduled.Game
+				if scheduled.GameID == "" {
+					t.Errorf("game %s - %s on %s was not created", game.FirstTeam, game.SecondTeam, game.Date)
+				}
+				played[game.FirstTeam]++
+				played[game.SecondTeam]++
+				pairs[[2]string{game.FirstTeam, game.SecondTeam}]++
+			}
+			for _, team := range f.teams {
+				if want := (tt.teams - 1) * tt.rounds; played[team] != want {
+					t.Errorf("team %s plays %d games, want %d", team, played[team], want)
+				}
+			}
+			// в двух кругах каждая пара встречается по разу на поле каждой команды
+			if tt.rounds == 2 {
+				for pair, n := range pairs {
+					if n != 1 || pairs[[2]string{pair[1], pair[0]}] != 1 {
+						t.Errorf("pair %v has %d home games and %d away games", pair, n, pairs[[2]string{pair[1], pair[0]}])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateScheduleErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("does not fit", func(t *testing.T) {
+		f := newSchedulerFixture(t, 4)
+		_, err := f.scheduler.GenerateSchedule(ctx, f.league, &entity.ScheduleRequest{
+			StartDate: "01.09.24",
+			EndDate:   "05.09.24",
+			Rounds:    2,
+		})
+		if !errors.Is(err, apperrors.ErrScheduleDoesNotFit) {
+			t.Fatalf("error = %v, want %v", err, apperrors.ErrScheduleDoesNotFit)
+		}
+		if games, _ := f.games.GetGamesByLeague(ctx, f.league); len(games) != 0 {
+			t.Errorf("%d games created for a schedule that does not fit", len(games))
+		}
+	})
+
+	t.Run("max games per week", func(t *testing.T) {
+		f := newSchedulerFixture(t, 4)
+		_, err := f.scheduler.GenerateSchedule(ctx, f.league, &entity.ScheduleRequest{
+			StartDate:       "02.09.24",
+			EndDate:         "15.09.24",
+			Rounds:          2,
+			MaxGamesPerWeek: 2,
+		})
+		if !errors.Is(err, apperrors.ErrScheduleDoesNotFit) {
+			t.Fatalf("error = %v, want %v", err, apperrors.ErrScheduleDoesNotFit)
+		}
+	})
+
+	t.Run("regular season already exists", func(t *testing.T) {
+		f := newSchedulerFixture(t, 4)
+		req := &entity.ScheduleRequest{StartDate: "01.09.24", EndDate: "30.09.24", Rounds: 1}
+		if _, err := f.scheduler.GenerateSchedule(ctx, f.league, req); err != nil {
+			t.Fatalf("GenerateSchedule() error = %v", err)
+		}
+
+		_, err := f.scheduler.GenerateSchedule(ctx, f.league, req)
+		if !errors.Is(err, apperrors.ErrScheduleExists) {
+			t.Fatalf("error = %v, want %v", err, apperrors.ErrScheduleExists)
+		}
+		if games, _ := f.games.GetGamesByLeague(ctx, f.league); len(games) != 6 {
+			t.Errorf("games = %d after a repeated generation, want 6", len(games))
+		}
+	})
+}
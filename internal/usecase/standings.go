@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+const lastGamesWindow = 10
+
+// teamTable - накопленные результаты команды при расчёте турнирной таблицы
+type teamTable struct {
+	standing entity.Standing
+	// outcomes - исходы матчей в хронологическом порядке, true - победа
+	outcomes   []bool
+	headToHead map[string]*entity.Record
+}
+
+// validateTieBreakers - проверяет, что все правила тай-брейка известны
+func validateTieBreakers(tieBreakers []string) error {
+	for _, rule := range tieBreakers {
+		switch rule {
+		case entity.TieBreakerHeadToHead, entity.TieBreakerPointDifferential, entity.TieBreakerConferenceRecord:
+		default:
+			return fmt.Errorf("%w: %q", apperrors.ErrInvalidTieBreaker, rule)
+		}
+	}
+	return nil
+}
+
+// GetStandings - турнирная таблица лиги по завершённым матчам. Если conference не пустая,
+// в таблицу попадают только команды этой конференции и отставание считается от её лидера.
+// Пустой tieBreakers означает правила, заданные в лиге, либо entity.DefaultTieBreakers.
+func (l *LeagueUC) GetStandings(ctx context.Context, leagueID, conference string, tieBreakers []string) ([]entity.Standing, error) {
+	league, err := l.leagueRp.GetLeague(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tieBreakers) == 0 {
+		tieBreakers = league.TieBreakers
+	}
+	if len(tieBreakers) == 0 {
+		tieBreakers = entity.DefaultTieBreakers
+	}
+	if err = validateTieBreakers(tieBreakers); err != nil {
+		return nil, err
+	}
+
+	teams, err := l.teamRp.GetTeamsByLeague(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	tables := make(map[string]*teamTable, len(teams))
+	for _, team := range teams {
+		tables[team.ID] = newTeamTable(team)
+	}
+
+	games, err := l.finalGames(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, game := range games {
+		first, err := l.tableFor(ctx, tables, game.FirstTeam)
+		if err != nil {
+			return nil, err
+		}
+		second, err := l.tableFor(ctx, tables, game.SecondTeam)
+		if err != nil {
+			return nil, err
+		}
+
+		sameConference := first.standing.Conference != "" && first.standing.Conference == second.standing.Conference
+		first.addGame(second.standing.Team, game.FirstTeamScore, game.SecondTeamScore, true, sameConference)
+		second.addGame(first.standing.Team, game.SecondTeamScore, game.FirstTeamScore, false, sameConference)
+	}
+
+	var rows []*teamTable
+	for _, table := range tables {
+		if conference != "" && table.standing.Conference != conference {
+			continue
+		}
+		table.finish()
+		rows = append(rows, table)
+	}
+
+	rows = rankTables(rows, tieBreakers)
+
+	standings := make([]entity.Standing, 0, len(rows))
+	for i, row := range rows {
+		row.standing.Rank = i + 1
+		leader := rows[0].standing
+		row.standing.GamesBehind = float64((leader.Wins-row.standing.Wins)+(row.standing.Losses-leader.Losses)) / 2
+		standings = append(standings, row.standing)
+	}
+
+	return standings, nil
+}
+
+// finalGames - завершённые матчи регулярного сезона лиги в хронологическом порядке
+func (l *LeagueUC) finalGames(ctx context.Context, leagueID string) ([]*entity.Game, error) {
+	games, err := l.gameRp.GetGamesByLeague(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	var final []*entity.Game
+	for _, game := range games {
+		if game.Status == entity.GameStatusFinal && game.Type != entity.GameTypePlayoff {
+			final = append(final, game)
+		}
+	}
+
+	sort.SliceStable(final, func(i, j int) bool {
+		return gameTime(final[i]).Before(gameTime(final[j]))
+	})
+
+	return final, nil
+}
+
+// tableFor - строка таблицы команды; команды, сыгравшие в лиге, но не привязанные к ней, подгружаются по id
+func (l *LeagueUC) tableFor(ctx context.Context, tables map[string]*teamTable, teamID string) (*teamTable, error) {
+	if table, ok := tables[teamID]; ok {
+		return table, nil
+	}
+
+	team, err := l.teamRp.GetTeam(ctx, teamID)
+	if errors.Is(err, apperrors.ErrTeamNotFound) || errors.Is(err, apperrors.ErrInvalidTeamID) {
+		team, err = &entity.Team{ID: teamID, Name: teamID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	table := newTeamTable(team)
+	tables[teamID] = table
+
+	return table, nil
+}
+
+// gameTime - дата матча; матчи с некорректной датой считаются самыми ранними
+func gameTime(game *entity.Game) time.Time {
+	date, err := game.ParseDate()
+	if err != nil {
+		return time.Time{}
+	}
+	return date
+}
+
+func newTeamTable(team *entity.Team) *teamTable {
+	return &teamTable{
+		standing: entity.Standing{
+			Team:       team.ID,
+			TeamName:   team.Name,
+			Conference: team.Conference,
+		},
+		headToHead: make(map[string]*entity.Record),
+	}
+}
+
+func (t *teamTable) addGame(opponent string, pointsFor, pointsAgainst int, home, sameConference bool) {
+	won := pointsFor > pointsAgainst
+
+	s := &t.standing
+	s.PointsFor += pointsFor
+	s.PointsAgainst += pointsAgainst
+	addOutcome(&s.Wins, &s.Losses, won)
+
+	if home {
+		addOutcome(&s.Home.Wins, &s.Home.Losses, won)
+	} else {
+		addOutcome(&s.Away.Wins, &s.Away.Losses, won)
+	}
+	if sameConference {
+		addOutcome(&s.ConferenceRecord.Wins, &s.ConferenceRecord.Losses, won)
+	}
+
+	h2h, ok := t.headToHead[opponent]
+	if !ok {
+		h2h = &entity.Record{}
+		t.headToHead[opponent] = h2h
+	}
+	addOutcome(&h2h.Wins, &h2h.Losses, won)
+
+	t.outcomes = append(t.outcomes, won)
+}
+
+// finish - процент побед, разница очков, последние 10 матчей и серия
+func (t *teamTable) finish() {
+	s := &t.standing
+	s.WinPct = winPct(s.Wins, s.Losses)
+	s.PointDiff = s.PointsFor - s.PointsAgainst
+
+	s.LastTen = entity.Record{}
+	for i := len(t.outcomes) - 1; i >= 0 && i >= len(t.outcomes)-lastGamesWindow; i-- {
+		addOutcome(&s.LastTen.Wins, &s.LastTen.Losses, t.outcomes[i])
+	}
+
+	s.Streak = ""
+	if len(t.outcomes) == 0 {
+		return
+	}
+	last := t.outcomes[len(t.outcomes)-1]
+	streak := 0
+	for i := len(t.outcomes) - 1; i >= 0 && t.outcomes[i] == last; i-- {
+		streak++
+	}
+	if last {
+		s.Streak = "W" + strconv.Itoa(streak)
+	} else {
+		s.Streak = "L" + strconv.Itoa(streak)
+	}
+}
+
+// rankTables - сортировка по проценту побед, равные команды разводятся тай-брейками по порядку
+func rankTables(rows []*teamTable, tieBreakers []string) []*teamTable {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].standing.WinPct > rows[j].standing.WinPct
+	})
+
+	ranked := make([]*teamTable, 0, len(rows))
+	for _, group := range splitTies(rows, func(t *teamTable) float64 { return t.standing.WinPct }) {
+		ranked = append(ranked, breakTies(group, tieBreakers)...)
+	}
+
+	return ranked
+}
+
+// breakTies - применяет первое правило к группе равных команд и рекурсивно следующие правила к оставшимся равным
+func breakTies(group []*teamTable, tieBreakers []string) []*teamTable {
+	if len(group) < 2 {
+		return group
+	}
+	if len(tieBreakers) == 0 {
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].standing.TeamName != group[j].standing.TeamName {
+				return group[i].standing.TeamName < group[j].standing.TeamName
+			}
+			return group[i].standing.Team < group[j].standing.Team
+		})
+		return group
+	}
+
+	metric := tieBreakerMetric(group, tieBreakers[0])
+	sort.SliceStable(group, func(i, j int) bool {
+		return metric(group[i]) > metric(group[j])
+	})
+
+	ranked := make([]*teamTable, 0, len(group))
+	for _, subgroup := range splitTies(group, metric) {
+		ranked = append(ranked, breakTies(subgroup, tieBreakers[1:])...)
+	}
+
+	return ranked
+}
+
+// tieBreakerMetric - значение правила тай-брейка для команды внутри группы равных
+func tieBreakerMetric(group []*teamTable, rule string) func(*teamTable) float64 {
+	switch rule {
+	case entity.TieBreakerHeadToHead:
+		// процент побед только в матчах между командами группы
+		return func(t *teamTable) float64 {
+			var wins, losses int
+			for _, opponent := range group {
+				if h2h, ok := t.headToHead[opponent.standing.Team]; ok {
+					wins += h2h.Wins
+					losses += h2h.Losses
+				}
+			}
+			if wins+losses == 0 {
+				return 0.5
+			}
+			return winPct(wins, losses)
+		}
+	case entity.TieBreakerPointDifferential:
+		return func(t *teamTable) float64 {
+			return float64(t.standing.PointDiff)
+		}
+	case entity.TieBreakerConferenceRecord:
+		return func(t *teamTable) float64 {
+			return winPct(t.standing.ConferenceRecord.Wins, t.standing.ConferenceRecord.Losses)
+		}
+	default:
+		return func(*teamTable) float64 { return 0 }
+	}
+}
+
+// splitTies - разбивает отсортированный список на группы с одинаковым значением метрики
+func splitTies(rows []*teamTable, metric func(*teamTable) float64) [][]*teamTable {
+	var groups [][]*teamTable
+	for i := 0; i < len(rows); {
+		j := i + 1
+		for j < len(rows) && metric(rows[j]) == metric(rows[i]) {
+			j++
+		}
+		groups = append(groups, rows[i:j])
+		i = j
+	}
+	return groups
+}
+
+func addOutcome(wins, losses *int, won bool) {
+	if won {
+		*wins++
+	} else {
+		*losses++
+	}
+}
+
+func winPct(wins, losses int) float64 {
+	if wins+losses == 0 {
+		return 0
+	}
+	return float64(wins) / float64(wins+losses)
+}
@@ -0,0 +1,177 @@
+package usecase_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/internal/usecase/repo/memory_rp"
+)
+
+// result - завершённый матч: хозяева, гости и счёт
+type result struct {
+	home, away           string
+	homeScore, awayScore int
+}
+
+func TestGetStandingsTieBreakers(t *testing.T) {
+	tests := []struct {
+		name           string
+		leagueBreakers []string
+		tieBreakers    []string
+		games          []result
+		want           []string
+	}{
+		{
+			// A и B по 2-1, у B разница очков лучше, но личную встречу выиграла A; C и D по 1-2, D обыграла C
+			name: "head to head before point differential",
+			games: []result{
+				{"A", "B", 100, 99}, {"B", "C", 120, 80}, {"B", "D", 120, 80},
+				{"C", "A", 100, 90}, {"A", "D", 100, 95}, {"D", "C", 100, 90},
+			},
+			want: []string{"A", "B", "D", "C"},
+		},
+		{
+			name:        "point differential only",
+			tieBreakers: []string{entity.TieBreakerPointDifferential},
+			games: []result{
+				{"A", "B", 100, 99}, {"B", "C", 120, 80}, {"B", "D", 120, 80},
+				{"C", "A", 100, 90}, {"A", "D", 100, 95}, {"D", "C", 100, 90},
+			},
+			want: []string{"B", "A", "D", "C"},
+		},
+		{
+			// A и B разошлись миром в личных встречах, дальше решает разница очков
+			name: "even head to head falls through to point differential",
+			games: []result{
+				{"A", "B", 100, 90}, {"B", "A", 95, 90}, {"A", "C", 110, 80}, {"B", "C", 100, 95},
+			},
+			want: []string{"A", "B", "C"},
+		},
+		{
+			name:           "league tie breakers apply when request has none",
+			leagueBreakers: []string{entity.TieBreakerPointDifferential},
+			games: []result{
+				{"A", "B", 100, 99}, {"B", "C", 120, 80}, {"B", "D", 120, 80},
+				{"C", "A", 100, 90}, {"A", "D", 100, 95}, {"D", "C", 100, 90},
+			},
+			want: []string{"B", "A", "D", "C"},
+		},
+		{
+			// равны и личные встречи, и разница очков - порядок по имени
+			name:  "full tie ordered by name",
+			games: []result{{"B", "A", 100, 90}, {"A", "B", 100, 90}},
+			want:  []string{"A", "B"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			leagues, teams, games := memory_rp.NewLeagueRepo(), memory_rp.NewTeamRepo(), memory_rp.NewGameRepo()
+
+			leagueID, err := leagues.CreateLeague(ctx, &entity.League{Name: "test", TieBreakers: tt.leagueBreakers})
+			if err != nil {
+				t.Fatalf("CreateLeague() error = %v", err)
+			}
+			ids := make(map[string]string)
+			teamID := func(name string) string {
+				if id, ok := ids[name]; ok {
+					return id
+				}
+				id, err := teams.CreateTeam(ctx, &entity.Team{Name: name, League: leagueID})
+				if err != nil {
+					t.Fatalf("CreateTeam() error = %v", err)
+				}
+				ids[name] = id
+				return id
+			}
+
+			for i, r := range tt.games {
+				_, err := games.CreateGame(ctx, &entity.Game{
+					FirstTeam:       teamID(r.home),
+					SecondTeam:      teamID(r.away),
+					Date:            "01.01.24",
+					League:          leagueID,
+					Status:          entity.GameStatusFinal,
+					FirstTeamScore:  r.homeScore,
+					SecondTeamScore: r.awayScore,
+				})
+				if err != nil {
+					t.Fatalf("CreateGame(%d) error = %v", i, err)
+				}
+			}
+			// незавершённый матч в таблицу не попадает
+			if _, err = games.CreateGame(ctx, &entity.Game{
+				FirstTeam:  teamID(tt.want[len(tt.want)-1]),
+				SecondTeam: teamID(tt.want[0]),
+				Date:       "02.01.24",
+				League:     leagueID,
+				Status:     entity.GameStatusScheduled,
+			}); err != nil {
+				t.Fatalf("CreateGame() error = %v", err)
+			}
+
+			standings, err := usecase.NewLeagueUC(leagues, teams, games).GetStandings(ctx, leagueID, "", tt.tieBreakers)
+			if err != nil {
+				t.Fatalf("GetStandings() error = %v", err)
+			}
+
+			var got []string
+			for i, s := range standings {
+				if s.Rank != i+1 {
+					t.Errorf("%s rank = %d, want %d", s.TeamName, s.Rank, i+1)
+				}
+				got = append(got, s.TeamName)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetStandingsSkipsPlayoffGames(t *testing.T) {
+	ctx := context.Background()
+	leagues, teams, games := memory_rp.NewLeagueRepo(), memory_rp.NewTeamRepo(), memory_rp.NewGameRepo()
+
+	leagueID, err := leagues.CreateLeague(ctx, &entity.League{Name: "test"})
+	if err != nil {
+		t.Fatalf("CreateLeague() error = %v", err)
+	}
+	a, err := teams.CreateTeam(ctx, &entity.Team{Name: "A", League: leagueID})
+	if err != nil {
+		t.Fatalf("CreateTeam() error = %v", err)
+	}
+	b, err := teams.CreateTeam(ctx, &entity.Team{Name: "B", League: leagueID})
+	if err != nil {
+		t.Fatalf("CreateTeam() error = %v", err)
+	}
+
+	for _, game := range []*entity.Game{
+		{FirstTeam: a, SecondTeam: b, Type: entity.GameTypeRegular, FirstTeamScore: 100, SecondTeamScore: 90},
+		{FirstTeam: a, SecondTeam: b, Type: entity.GameTypePlayoff, FirstTeamScore: 80, SecondTeamScore: 110},
+		{FirstTeam: b, SecondTeam: a, Type: entity.GameTypePlayoff, FirstTeamScore: 120, SecondTeamScore: 70},
+	} {
+		game.Date, game.League, game.Status = "01.04.24", leagueID, entity.GameStatusFinal
+		if _, err = games.CreateGame(ctx, game); err != nil {
+			t.Fatalf("CreateGame() error = %v", err)
+		}
+	}
+
+	standings, err := usecase.NewLeagueUC(leagues, teams, games).GetStandings(ctx, leagueID, "", nil)
+	if err != nil {
+		t.Fatalf("GetStandings() error = %v", err)
+	}
+	if len(standings) != 2 {
+		t.Fatalf("standings has %d rows, want 2", len(standings))
+	}
+	if first := standings[0]; first.Team != a || first.Wins != 1 || first.Losses != 0 || first.PointDiff != 10 {
+		t.Errorf("first row = %+v, want A 1-0 with point diff 10", first)
+	}
+	if second := standings[1]; second.Team != b || second.Wins != 0 || second.Losses != 1 {
+		t.Errorf("second row = %+v, want B 0-1", second)
+	}
+}
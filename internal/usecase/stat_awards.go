@@ -2,34 +2,226 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/romeros69/basket/internal/apperrors"
 	"github.com/romeros69/basket/internal/entity"
 )
 
+const (
+	defaultAwardAnalyticsLimit = 10
+	maxAwardAnalyticsLimit     = 100
+)
+
 type StatAwardsUC struct {
 	statAwardsRp StatAwardsRp
+	playerRp     PlayerRp
+	gameRp       GameRp
+	awardRp      AwardRp
+	leagueRp     LeagueRp
+	teamRp       TeamRp
 }
 
-func NewStatAwardsUC(statAwardsRp StatAwardsRp) *StatAwardsUC {
+func NewStatAwardsUC(statAwardsRp StatAwardsRp, playerRp PlayerRp, gameRp GameRp, awardRp AwardRp, leagueRp LeagueRp,
+	teamRp TeamRp) *StatAwardsUC {
 	return &StatAwardsUC{
 		statAwardsRp: statAwardsRp,
+		playerRp:     playerRp,
+		gameRp:       gameRp,
+		awardRp:      awardRp,
+		leagueRp:     leagueRp,
+		teamRp:       teamRp,
 	}
 }
 
 var _ StatAwards = (*StatAwardsUC)(nil)
 
 func (sa *StatAwardsUC) CreateRecord(ctx context.Context, rewardStat entity.RewardStat) error {
+	if err := sa.fillProperties(ctx, &rewardStat); err != nil {
+		return err
+	}
 	return sa.statAwardsRp.CreateRecord(ctx, rewardStat)
 }
+
+// fillProperties - проверяет, что игрок, матч, награда и турнир (лига) записи есть в каталоге,
+// и копирует из каталога свойства их узлов. Если проверка ссылок отключена, сущности, которых нет в каталоге,
+// пропускаются, и их узлы остаются без свойств, пока сущность не появится или не изменится в каталоге
+func (sa *StatAwardsUC) fillProperties(ctx context.Context, rewardStat *entity.RewardStat) error {
+	skip := referenceCheckSkipped(ctx)
+	unresolved := func(err error) error {
+		if skip && errors.Is(err, apperrors.ErrUnresolvedReference) {
+			return nil
+		}
+		return err
+	}
+
+	player, err := resolveReference(ctx, "player", rewardStat.Player, sa.playerRp.GetPlayer,
+		apperrors.ErrPlayerNotFound, apperrors.ErrInvalidPlayerID)
+	if err = unresolved(err); err != nil {
+		return err
+	}
+	game, err := resolveReference(ctx, "match", rewardStat.Match, sa.gameRp.GetGame,
+		apperrors.ErrGameNotFound, apperrors.ErrInvalidGameID)
+	if err = unresolved(err); err != nil {
+		return err
+	}
+	award, err := resolveReference(ctx, "reward", rewardStat.Reward, sa.awardRp.GetAward,
+		apperrors.ErrAwardNotFound, apperrors.ErrInvalidAwardID)
+	if err = unresolved(err); err != nil {
+		return err
+	}
+	league, err := resolveReference(ctx, "tournament", rewardStat.Tournament, sa.leagueRp.GetLeague,
+		apperrors.ErrLeagueNotFound, apperrors.ErrInvalidLeagueID)
+	if err = unresolved(err); err != nil {
+		return err
+	}
+
+	if player != nil {
+		rewardStat.PlayerName = player.Name
+		rewardStat.PlayerSurname = player.Surname
+		rewardStat.PlayerTeam = player.Team
+	}
+	if game != nil {
+		rewardStat.MatchFirstTeam = game.FirstTeam
+		rewardStat.MatchSecondTeam = game.SecondTeam
+		rewardStat.MatchDate = game.Date
+	}
+	if award != nil {
+		rewardStat.RewardTitle = award.Tittle
+	}
+	if league != nil {
+		rewardStat.TournamentName = league.Name
+		rewardStat.TournamentSeason = league.Season
+	}
+
+	return nil
+}
+
 func (sa *StatAwardsUC) ViewPlayersAndRewardsInTournament(ctx context.Context, tournamentId string) ([]entity.RewardStat, error) {
-	return sa.statAwardsRp.ViewPlayersAndRewardsInTournament(ctx, tournamentId)
+	rewards, err := sa.statAwardsRp.ViewPlayersAndRewardsInTournament(ctx, tournamentId)
+	if err != nil {
+		return nil, err
+	}
+	return sa.resolveTeamNames(ctx, rewards)
 }
 func (sa *StatAwardsUC) ViewPlayersAndRewardsInMatch(ctx context.Context, matchId string) ([]entity.RewardStat, error) {
-	return sa.statAwardsRp.ViewPlayersAndRewardsInMatch(ctx, matchId)
+	rewards, err := sa.statAwardsRp.ViewPlayersAndRewardsInMatch(ctx, matchId)
+	if err != nil {
+		return nil, err
+	}
+	return sa.resolveTeamNames(ctx, rewards)
 }
 func (sa *StatAwardsUC) ViewRewardsForPlayer(ctx context.Context, playerId string) ([]entity.RewardStat, error) {
-	return sa.statAwardsRp.ViewRewardsForPlayer(ctx, playerId)
+	rewards, err := sa.statAwardsRp.ViewRewardsForPlayer(ctx, playerId)
+	if err != nil {
+		return nil, err
+	}
+	return sa.resolveTeamNames(ctx, rewards)
 }
 func (sa *StatAwardsUC) ViewWhoGotSpecificReward(ctx context.Context, rewardId string) ([]entity.RewardStat, error) {
-	return sa.statAwardsRp.ViewWhoGotSpecificReward(ctx, rewardId)
+	rewards, err := sa.statAwardsRp.ViewWhoGotSpecificReward(ctx, rewardId)
+	if err != nil {
+		return nil, err
+	}
+	return sa.resolveTeamNames(ctx, rewards)
+}
+
+// resolveTeamNames - дополняет записи названиями команд игрока и матча из каталога, каждая команда читается
+// один раз; команды, которых нет в каталоге, остаются без названия
+func (sa *StatAwardsUC) resolveTeamNames(ctx context.Context, rewards []entity.RewardStat) ([]entity.RewardStat, error) {
+	var err error
+	names := make(map[string]string)
+	teamName := func(teamID string) (string, error) {
+		if teamID == "" {
+			return "", nil
+		}
+		if name, ok := names[teamID]; ok {
+			return name, nil
+		}
+		team, err := sa.teamRp.GetTeam(ctx, teamID)
+		switch {
+		case err == nil:
+			names[teamID] = team.Name
+		case errors.Is(err, apperrors.ErrTeamNotFound) || errors.Is(err, apperrors.ErrInvalidTeamID):
+			names[teamID] = ""
+		default:
+			return "", err
+		}
+		return names[teamID], nil
+	}
+
+	for i := range rewards {
+		r := &rewards[i]
+		if r.PlayerTeamName, err = teamName(r.PlayerTeam); err != nil {
+			return nil, err
+		}
+		if r.MatchFirstTeamName, err = teamName(r.MatchFirstTeam); err != nil {
+			return nil, err
+		}
+		if r.MatchSecondTeamName, err = teamName(r.MatchSecondTeam); err != nil {
+			return nil, err
+		}
+	}
+
+	return rewards, nil
+}
+
+// GetCoAwardedPlayers - игроки, награждённые в тех же матчах или турнирах, что и заданный
+func (sa *StatAwardsUC) GetCoAwardedPlayers(ctx context.Context, playerID string, limit int) ([]entity.CoAwardedPlayer, error) {
+	limit, err := awardAnalyticsLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	return sa.statAwardsRp.GetCoAwardedPlayers(ctx, playerID, limit)
+}
+
+// GetMostDecoratedPlayers - самые награждаемые игроки турнира; игроки с равным числом наград делят место
+func (sa *StatAwardsUC) GetMostDecoratedPlayers(ctx context.Context, tournamentID string, limit int) ([]entity.DecoratedPlayer, error) {
+	limit, err := awardAnalyticsLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := sa.statAwardsRp.GetMostDecoratedPlayers(ctx, tournamentID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range players {
+		players[i].Rank = i + 1
+		if i > 0 && players[i].Awards == players[i-1].Awards {
+			players[i].Rank = players[i-1].Rank
+		}
+	}
+
+	return players, nil
+}
+
+// GetAwardCoOccurrence - какие награды достаются одним и тем же игрокам; с awardID - только пары с этой наградой
+func (sa *StatAwardsUC) GetAwardCoOccurrence(ctx context.Context, awardID string, limit int) ([]entity.AwardCoOccurrence, error) {
+	limit, err := awardAnalyticsLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	return sa.statAwardsRp.GetAwardCoOccurrence(ctx, awardID, limit)
+}
+
+// GetDegreeCentrality - самые связанные игроки сети совместных награждений
+func (sa *StatAwardsUC) GetDegreeCentrality(ctx context.Context, limit int) ([]entity.PlayerCentrality, error) {
+	limit, err := awardAnalyticsLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	return sa.statAwardsRp.GetDegreeCentrality(ctx, limit)
+}
+
+func awardAnalyticsLimit(limit int) (int, error) {
+	if limit == 0 {
+		return defaultAwardAnalyticsLimit, nil
+	}
+	if limit < 0 || limit > maxAwardAnalyticsLimit {
+		return 0, fmt.Errorf("%w: limit must be between 1 and %d", apperrors.ErrInvalidAwardAnalytics, maxAwardAnalyticsLimit)
+	}
+	return limit, nil
 }
@@ -2,25 +2,264 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
 
+	"github.com/romeros69/basket/internal/apperrors"
 	"github.com/romeros69/basket/internal/entity"
 )
 
+const (
+	defaultLeadersLimit = 10
+	maxLeadersLimit     = 100
+
+	// maxStatBatchRows - сколько строк статистики принимается за один пакетный запрос
+	maxStatBatchRows = 10000
+)
+
 type StatPlayerUC struct {
 	statPlayerRp StatPlayerRp
+	gameRp       GameRp
+	playerRp     PlayerRp
+	teamRp       TeamRp
+	leagueRp     LeagueRp
+	careerRp     CareerRp
 }
 
-func NewStatPlayerUC(statPlayerRp StatPlayerRp) *StatPlayerUC {
+func NewStatPlayerUC(statPlayerRp StatPlayerRp, gameRp GameRp, playerRp PlayerRp, teamRp TeamRp, leagueRp LeagueRp, careerRp CareerRp) *StatPlayerUC {
 	return &StatPlayerUC{
 		statPlayerRp: statPlayerRp,
+		gameRp:       gameRp,
+		playerRp:     playerRp,
+		teamRp:       teamRp,
+		leagueRp:     leagueRp,
+		careerRp:     careerRp,
 	}
 }
 
 var _ StatPlayer = (*StatPlayerUC)(nil)
 
-
+// InsertPlayerStat - статистика уже записана, когда пишется граф карьеры, поэтому при ошибке графа
+// возвращается ErrCareerGraphNotUpdated: связь игрока с матчем восстанавливает команда backfill career
 func (sp *StatPlayerUC) InsertPlayerStat(ctx context.Context, stat entity.PlayerStat) error {
-	return sp.statPlayerRp.InsertPlayerStat(ctx, stat)
+	prepared, appearance, err := sp.prepareStat(ctx, sp.newStatCatalog(), stat)
+	if err != nil {
+		return err
+	}
+	if err = sp.statPlayerRp.InsertPlayerStat(ctx, prepared); err != nil {
+		return err
+	}
+
+	return sp.recordAppearances(ctx, []entity.Appearance{appearance})
+}
+
+// ReplacePlayerStat - как InsertPlayerStat, но сначала удаляет прежние строки игрока за матч
+func (sp *StatPlayerUC) ReplacePlayerStat(ctx context.Context, stat entity.PlayerStat) error {
+	prepared, appearance, err := sp.prepareStat(ctx, sp.newStatCatalog(), stat)
+	if err != nil {
+		return err
+	}
+	if err = sp.statPlayerRp.ReplacePlayerStat(ctx, prepared); err != nil {
+		return err
+	}
+
+	return sp.recordAppearances(ctx, []entity.Appearance{appearance})
+}
+
+// InsertPlayerStats - проверяет и дополняет каждую строку как InsertPlayerStat и вставляет прошедшие проверку одной пачкой.
+// Каталог читается один раз на каждый id пачки, связи вставленных строк с матчами пишутся в граф карьеры одним запросом
+// после вставки; если он не удался, вместе с итогом возвращается ErrCareerGraphNotUpdated.
+// Ошибка строки не мешает остальным, она попадает в итог с номером строки
+func (sp *StatPlayerUC) InsertPlayerStats(ctx context.Context, stats []entity.PlayerStat) (*entity.StatBatchResult, error) {
+	if len(stats) > maxStatBatchRows {
+		return nil, fmt.Errorf("%w: batch has %d rows, at most %d are allowed", apperrors.ErrInvalidPlayerStat, len(stats), maxStatBatchRows)
+	}
+
+	result := &entity.StatBatchResult{Received: len(stats)}
+	fail := func(row int, err error) {
+		result.Failed++
+		result.Errors = append(result.Errors, entity.StatBatchError{Row: row, Error: err.Error()})
+	}
+
+	catalog := sp.newStatCatalog()
+	prepared := make([]entity.PlayerStat, 0, len(stats))
+	appearances := make([]entity.Appearance, 0, len(stats))
+	rows := make([]int, 0, len(stats))
+	for i, stat := range stats {
+		stat, appearance, err := sp.prepareStat(ctx, catalog, stat)
+		if err != nil {
+			fail(i, err)
+			continue
+		}
+		prepared = append(prepared, stat)
+		appearances = append(appearances, appearance)
+		rows = append(rows, i)
+	}
+
+	inserted := make([]entity.Appearance, 0, len(prepared))
+	for i, err := range sp.statPlayerRp.InsertPlayerStats(ctx, prepared) {
+		if err != nil {
+			fail(rows[i], err)
+			continue
+		}
+		result.Inserted++
+		inserted = append(inserted, appearances[i])
+	}
+	sort.Slice(result.Errors, func(i, j int) bool { return result.Errors[i].Row < result.Errors[j].Row })
+
+	return result, sp.recordAppearances(ctx, inserted)
+}
+
+// recordAppearances - связывает игроков с уже записанными матчами в графе карьеры
+func (sp *StatPlayerUC) recordAppearances(ctx context.Context, appearances []entity.Appearance) error {
+	if len(appearances) == 0 {
+		return nil
+	}
+	if err := sp.careerRp.RecordAppearances(ctx, appearances); err != nil {
+		return fmt.Errorf("%w: stats of %d rows are saved, but their appearances are not recorded: %w",
+			apperrors.ErrCareerGraphNotUpdated, len(appearances), err)
+	}
+	return nil
+}
+
+// statCatalog - чтение каталога для строк статистики; в пределах одного запроса каждый id читается один раз
+type statCatalog struct {
+	getPlayer func(context.Context, string) (*entity.Player, error)
+	getGame   func(context.Context, string) (*entity.Game, error)
+	getTeam   func(context.Context, string) (*entity.Team, error)
+	getLeague func(context.Context, string) (*entity.League, error)
+}
+
+func (sp *StatPlayerUC) newStatCatalog() statCatalog {
+	return statCatalog{
+		getPlayer: cachedGet(sp.playerRp.GetPlayer),
+		getGame:   cachedGet(sp.gameRp.GetGame),
+		getTeam:   cachedGet(sp.teamRp.GetTeam),
+		getLeague: cachedGet(sp.leagueRp.GetLeague),
+	}
+}
+
+// prepareStat - проверяет строку, дописывает измерения и возвращает связь игрока с матчем для графа карьеры
+func (sp *StatPlayerUC) prepareStat(ctx context.Context, catalog statCatalog, stat entity.PlayerStat) (entity.PlayerStat, entity.Appearance, error) {
+	normalized, err := normalizePlayerStat(stat)
+	if err != nil {
+		return stat, entity.Appearance{}, err
+	}
+	if normalized.GameDate != "" {
+		if _, err = time.Parse(entity.GameDateLayout, normalized.GameDate); err != nil {
+			return stat, entity.Appearance{}, fmt.Errorf("%w: gameDate %q must be in %s format",
+				apperrors.ErrInvalidPlayerStat, normalized.GameDate, entity.GameDateLayout)
+		}
+	}
+	if !referenceCheckSkipped(ctx) {
+		if err = checkStatReferences(ctx, catalog, normalized); err != nil {
+			return stat, entity.Appearance{}, err
+		}
+	}
+	if err = fillDimensions(ctx, catalog, &normalized); err != nil {
+		return stat, entity.Appearance{}, err
+	}
+	appearance, err := statAppearance(ctx, catalog, normalized)
+	if err != nil {
+		return stat, entity.Appearance{}, err
+	}
+
+	return normalized, appearance, nil
+}
+
+// statAppearance - связь игрока с матчем в графе карьеры; соперник - другая команда матча
+func statAppearance(ctx context.Context, catalog statCatalog, stat entity.PlayerStat) (entity.Appearance, error) {
+	appearance := entity.Appearance{
+		Player: stat.PlayerID,
+		Match:  stat.MatchID,
+		Team:   stat.TeamID,
+	}
+	if stat.GameDate != "" {
+		date, err := time.Parse(entity.GameDateLayout, stat.GameDate)
+		if err != nil {
+			return appearance, err
+		}
+		appearance.Date = date.Format(entity.CareerDateLayout)
+	}
+
+	game, err := catalog.getGame(ctx, stat.MatchID)
+	switch {
+	case err == nil:
+		appearance.Opponent = game.Opponent(stat.TeamID)
+	case !errors.Is(err, apperrors.ErrGameNotFound) && !errors.Is(err, apperrors.ErrInvalidGameID):
+		return appearance, err
+	}
+
+	return appearance, nil
+}
+
+// checkStatReferences - игрок, матч и явно переданные команда и лига должны быть в каталоге
+func checkStatReferences(ctx context.Context, catalog statCatalog, stat entity.PlayerStat) error {
+	if _, err := resolveReference(ctx, "playerId", stat.PlayerID, catalog.getPlayer,
+		apperrors.ErrPlayerNotFound, apperrors.ErrInvalidPlayerID); err != nil {
+		return err
+	}
+	if _, err := resolveReference(ctx, "matchId", stat.MatchID, catalog.getGame,
+		apperrors.ErrGameNotFound, apperrors.ErrInvalidGameID); err != nil {
+		return err
+	}
+	if stat.TeamID != "" {
+		if _, err := resolveReference(ctx, "teamId", stat.TeamID, catalog.getTeam,
+			apperrors.ErrTeamNotFound, apperrors.ErrInvalidTeamID); err != nil {
+			return err
+		}
+	}
+	if stat.LeagueID != "" {
+		_, err := resolveReference(ctx, "leagueId", stat.LeagueID, catalog.getLeague,
+			apperrors.ErrLeagueNotFound, apperrors.ErrInvalidLeagueID)
+		return err
+	}
+	return nil
+}
+
+// fillDimensions - дописывает команду, дату, лигу и сезон матча, если клиент их не прислал.
+// При отключённой проверке ссылок неизвестные игрок, матч или лига не мешают записи, измерения тогда остаются пустыми
+func fillDimensions(ctx context.Context, catalog statCatalog, stat *entity.PlayerStat) error {
+	if stat.TeamID == "" {
+		player, err := catalog.getPlayer(ctx, stat.PlayerID)
+		switch {
+		case err == nil:
+			stat.TeamID = player.Team
+		case !errors.Is(err, apperrors.ErrPlayerNotFound) && !errors.Is(err, apperrors.ErrInvalidPlayerID):
+			return err
+		}
+	}
+
+	if stat.GameDate != "" && stat.Season != "" && stat.LeagueID != "" {
+		return nil
+	}
+	game, err := catalog.getGame(ctx, stat.MatchID)
+	if errors.Is(err, apperrors.ErrGameNotFound) || errors.Is(err, apperrors.ErrInvalidGameID) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if stat.GameDate == "" {
+		stat.GameDate = game.Date
+	}
+	if stat.LeagueID == "" {
+		stat.LeagueID = game.League
+	}
+	if stat.Season == "" && stat.LeagueID != "" {
+		league, err := catalog.getLeague(ctx, stat.LeagueID)
+		switch {
+		case err == nil:
+			stat.Season = league.Season
+		case !errors.Is(err, apperrors.ErrLeagueNotFound) && !errors.Is(err, apperrors.ErrInvalidLeagueID):
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (sp *StatPlayerUC) GetPlayerStatsByIDAndMatch(ctx context.Context, playerID, matchID string) ([]entity.PlayerStat, error) {
@@ -34,3 +273,79 @@ func (sp *StatPlayerUC) GetPlayersWithAvgGoalsGreaterThanByMatch(ctx context.Con
 func (sp *StatPlayerUC) GetPlayersWithTotalAvgStatsGreaterThanByMatch(ctx context.Context, minTotalAvg float64, matchID string) ([]entity.PlayerStat, error) {
 	return sp.statPlayerRp.GetPlayersWithTotalAvgStatsGreaterThanByMatch(ctx, minTotalAvg, matchID)
 }
+
+// GetPlayerMetrics - продвинутые метрики игрока по матчу, интервалу дат или сезону
+func (sp *StatPlayerUC) GetPlayerMetrics(ctx context.Context, playerID string, scope entity.StatScope) (*entity.PlayerMetrics, error) {
+	var from, to time.Time
+	var err error
+	if scope.From != "" {
+		if from, err = time.Parse(entity.GameDateLayout, scope.From); err != nil {
+			return nil, fmt.Errorf("%w: from %q must be in %s format", apperrors.ErrInvalidStatScope, scope.From, entity.GameDateLayout)
+		}
+	}
+	if scope.To != "" {
+		if to, err = time.Parse(entity.GameDateLayout, scope.To); err != nil {
+			return nil, fmt.Errorf("%w: to %q must be in %s format", apperrors.ErrInvalidStatScope, scope.To, entity.GameDateLayout)
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, fmt.Errorf("%w: to is before from", apperrors.ErrInvalidStatScope)
+	}
+
+	return sp.statPlayerRp.GetPlayerMetrics(ctx, playerID, scope)
+}
+
+// GetPlayerSeasonStats - суммы и средние игрока по сезонам из предрассчитанных агрегатов; пустой season - все сезоны
+func (sp *StatPlayerUC) GetPlayerSeasonStats(ctx context.Context, playerID, season string) ([]entity.PlayerSeasonStat, error) {
+	return sp.statPlayerRp.GetPlayerSeasonStats(ctx, playerID, season)
+}
+
+// GetTeamSeasonStats - суммы и средние команды по сезонам из предрассчитанных агрегатов; пустой season - все сезоны
+func (sp *StatPlayerUC) GetTeamSeasonStats(ctx context.Context, teamID, season string) ([]entity.TeamSeasonStat, error) {
+	return sp.statPlayerRp.GetTeamSeasonStats(ctx, teamID, season)
+}
+
+// GetLeaders - рейтинг игроков по показателю с именами из каталога игроков
+func (sp *StatPlayerUC) GetLeaders(ctx context.Context, req entity.LeadersRequest) ([]entity.Leader, error) {
+	if !slices.Contains(entity.LeaderStats, req.Stat) {
+		return nil, fmt.Errorf("%w: unknown stat %q, expected one of %v", apperrors.ErrInvalidLeaders, req.Stat, entity.LeaderStats)
+	}
+	if req.Per == "" {
+		req.Per = entity.LeaderPerGame
+	}
+	switch req.Per {
+	case entity.LeaderPerGame, entity.LeaderTotal, entity.LeaderPer36Min:
+	default:
+		return nil, fmt.Errorf("%w: per must be game, total or 36, got %q", apperrors.ErrInvalidLeaders, req.Per)
+	}
+	if req.MinGames < 0 {
+		return nil, fmt.Errorf("%w: min_games can't be negative", apperrors.ErrInvalidLeaders)
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultLeadersLimit
+	}
+	if req.Limit < 0 || req.Limit > maxLeadersLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", apperrors.ErrInvalidLeaders, maxLeadersLimit)
+	}
+
+	leaders, err := sp.statPlayerRp.GetLeaders(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	playerIDs := make([]string, 0, len(leaders))
+	for _, leader := range leaders {
+		playerIDs = append(playerIDs, leader.PlayerID)
+	}
+	players, err := sp.playerRp.GetPlayersByIDs(ctx, playerIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range leaders {
+		if player, ok := players[leaders[i].PlayerID]; ok {
+			leaders[i].Name, leaders[i].Surname = player.Name, player.Surname
+		}
+	}
+
+	return leaders, nil
+}
@@ -0,0 +1,117 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+	"github.com/romeros69/basket/internal/usecase"
+	"github.com/romeros69/basket/internal/usecase/repo/memory_rp"
+)
+
+func TestInsertLegacyPlayerStatKeepsUnknownAttempts(t *testing.T) {
+	// строки загружаются без проверки ссылок на каталог, как генератором
+	ctx := usecase.WithoutReferenceCheck(context.Background())
+	stats := memory_rp.NewStatPlayerRepo()
+
+	err := usecase.NewStatPlayerUC(stats, memory_rp.NewGameRepo(), memory_rp.NewPlayerRepo(), memory_rp.NewTeamRepo(), memory_rp.NewLeagueRepo(), memory_rp.NewStatAwardsRepo()).InsertPlayerStat(ctx, entity.PlayerStat{PlayerID: "p", MatchID: "m", Goals: 3, Interceptions: 1})
+	if err != nil {
+		t.Fatalf("InsertPlayerStat() error = %v", err)
+	}
+
+	rows, err := stats.GetPlayerStatsByIDAndMatch(ctx, "p", "m")
+	if err != nil {
+		t.Fatalf("GetPlayerStatsByIDAndMatch() error = %v", err)
+	}
+	want := entity.PlayerStat{PlayerID: "p", MatchID: "m", Points: 6, FGM: 3, Steals: 1, Goals: 3, Interceptions: 1}
+	if len(rows) != 1 || rows[0] != want {
+		t.Errorf("rows = %+v, want only %+v", rows, want)
+	}
+}
+
+func TestGetPlayerMetricsSkipsUnknownAttempts(t *testing.T) {
+	ctx := usecase.WithoutReferenceCheck(context.Background())
+	stats := memory_rp.NewStatPlayerRepo()
+	statPlayer := usecase.NewStatPlayerUC(stats, memory_rp.NewGameRepo(), memory_rp.NewPlayerRepo(), memory_rp.NewTeamRepo(), memory_rp.NewLeagueRepo(), memory_rp.NewStatAwardsRepo())
+
+	for _, stat := range []entity.PlayerStat{
+		{PlayerID: "p", MatchID: "old", Goals: 3},
+		{PlayerID: "p", MatchID: "new", FGM: 1, FGA: 2, Minutes: 10},
+	} {
+		if err := statPlayer.InsertPlayerStat(ctx, stat); err != nil {
+			t.Fatalf("InsertPlayerStat() error = %v", err)
+		}
+	}
+
+	metrics, err := statPlayer.GetPlayerMetrics(ctx, "p", entity.StatScope{})
+	if err != nil {
+		t.Fatalf("GetPlayerMetrics() error = %v", err)
+	}
+	// попадания старого матча без попыток не превращают 1 из 2 в 4 из 2
+	if metrics.Points != 8 || metrics.EffectiveFG != 0.5 || metrics.TrueShooting != 0.5 {
+		t.Errorf("points = %d, eFG = %v, TS = %v, want 8, 0.5 and 0.5", metrics.Points, metrics.EffectiveFG, metrics.TrueShooting)
+	}
+}
+
+func TestGetLeadersNames(t *testing.T) {
+	ctx := usecase.WithoutReferenceCheck(context.Background())
+	stats, players := memory_rp.NewStatPlayerRepo(), memory_rp.NewPlayerRepo()
+	statPlayer := usecase.NewStatPlayerUC(stats, memory_rp.NewGameRepo(), players, memory_rp.NewTeamRepo(), memory_rp.NewLeagueRepo(), memory_rp.NewStatAwardsRepo())
+
+	known, err := players.CreatePlayer(ctx, &entity.Player{Name: "Jimmy", Surname: "Butler"})
+	if err != nil {
+		t.Fatalf("CreatePlayer() error = %v", err)
+	}
+	// статистика игрока, которого нет в каталоге, остаётся в рейтинге без имени
+	for _, stat := range []entity.PlayerStat{
+		{PlayerID: known, MatchID: "m", FGM: 10, FGA: 20},
+		{PlayerID: "unknown", MatchID: "m", FGM: 5, FGA: 10},
+	} {
+		if err = statPlayer.InsertPlayerStat(ctx, stat); err != nil {
+			t.Fatalf("InsertPlayerStat() error = %v", err)
+		}
+	}
+
+	leaders, err := statPlayer.GetLeaders(ctx, entity.LeadersRequest{Stat: "points", Per: entity.LeaderTotal})
+	if err != nil {
+		t.Fatalf("GetLeaders() error = %v", err)
+	}
+	want := []entity.Leader{
+		{Rank: 1, PlayerID: known, Name: "Jimmy", Surname: "Butler", Games: 1, Value: 20},
+		{Rank: 2, PlayerID: "unknown", Games: 1, Value: 10},
+	}
+	if len(leaders) != len(want) || leaders[0] != want[0] || leaders[1] != want[1] {
+		t.Errorf("leaders = %+v, want %+v", leaders, want)
+	}
+}
+
+// brokenCareer - граф карьеры, в который не удаётся записать матч игрока
+type brokenCareer struct {
+	*memory_rp.StatAwardsRepo
+}
+
+func (brokenCareer) RecordAppearances(context.Context, []entity.Appearance) error {
+	return errors.New("graph is unavailable")
+}
+
+func TestInsertPlayerStatKeepsStatsWhenCareerGraphFails(t *testing.T) {
+	ctx := usecase.WithoutReferenceCheck(context.Background())
+	stats := memory_rp.NewStatPlayerRepo()
+	statPlayer := usecase.NewStatPlayerUC(stats, memory_rp.NewGameRepo(), memory_rp.NewPlayerRepo(), memory_rp.NewTeamRepo(),
+		memory_rp.NewLeagueRepo(), brokenCareer{memory_rp.NewStatAwardsRepo()})
+
+	err := statPlayer.InsertPlayerStat(ctx, entity.PlayerStat{PlayerID: "p", MatchID: "m", Points: 2, FGM: 1, FGA: 1})
+	if !errors.Is(err, apperrors.ErrCareerGraphNotUpdated) {
+		t.Fatalf("InsertPlayerStat() error = %v, want %v", err, apperrors.ErrCareerGraphNotUpdated)
+	}
+
+	rows, err := stats.GetPlayerStatsByIDAndMatch(ctx, "p", "m")
+	if err != nil {
+		t.Fatalf("GetPlayerStatsByIDAndMatch() error = %v", err)
+	}
+	if len(rows) != 1 || rows[0].Points != 2 {
+		t.Errorf("rows = %+v, want the inserted row", rows)
+	}
+}
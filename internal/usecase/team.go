@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romeros69/basket/internal/apperrors"
+	"github.com/romeros69/basket/internal/entity"
+)
+
+type TeamUC struct {
+	teamRp   TeamRp
+	leagueRp LeagueRp
+	playerRp PlayerRp
+	gameRp   GameRp
+}
+
+func NewTeamUC(teamRp TeamRp, leagueRp LeagueRp, playerRp PlayerRp, gameRp GameRp) *TeamUC {
+	return &TeamUC{
+		teamRp:   teamRp,
+		leagueRp: leagueRp,
+		playerRp: playerRp,
+		gameRp:   gameRp,
+	}
+}
+
+var _ Team = (*TeamUC)(nil)
+
+func (t *TeamUC) CreateTeam(ctx context.Context, team *entity.Team) (string, error) {
+	if err := t.validate(ctx, team); err != nil {
+		return "", err
+	}
+	return t.teamRp.CreateTeam(ctx, team)
+}
+
+func (t *TeamUC) UpdateTeam(ctx context.Context, teamID string, team *entity.Team) (*entity.Team, error) {
+	if err := t.validate(ctx, team); err != nil {
+		return nil, err
+	}
+	return t.teamRp.UpdateTeam(ctx, teamID, team)
+}
+
+func (t *TeamUC) GetTeam(ctx context.Context, teamID string) (*entity.Team, error) {
+	return t.teamRp.GetTeam(ctx, teamID)
+}
+
+// DeleteTeam - команду нельзя удалить, пока в ней есть игроки или у неё есть матчи:
+// ростер, расписание и таблица лиги ссылались бы на несуществующую команду
+func (t *TeamUC) DeleteTeam(ctx context.Context, teamID string) error {
+	if _, err := t.teamRp.GetTeam(ctx, teamID); err != nil {
+		return err
+	}
+
+	players, err := t.playerRp.GetPlayersByTeam(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if len(players) != 0 {
+		return fmt.Errorf("%w: team %s has %d players", apperrors.ErrEntityReferenced, teamID, len(players))
+	}
+
+	games, err := t.gameRp.GetGamesByTeam(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if len(games) != 0 {
+		return fmt.Errorf("%w: team %s has %d games", apperrors.ErrEntityReferenced, teamID, len(games))
+	}
+
+	return t.teamRp.DeleteTeam(ctx, teamID)
+}
+
+func (t *TeamUC) GetTeamList(ctx context.Context, pageSize, pageNumber int64) ([]*entity.Team, error) {
+	return t.teamRp.GetTeamList(ctx, pageSize, pageNumber)
+}
+
+func (t *TeamUC) GetRoster(ctx context.Context, teamID string) ([]*entity.Player, error) {
+	if _, err := t.teamRp.GetTeam(ctx, teamID); err != nil {
+		return nil, err
+	}
+	return t.playerRp.GetPlayersByTeam(ctx, teamID)
+}
+
+func (t *TeamUC) GetSchedule(ctx context.Context, teamID string) ([]*entity.Game, error) {
+	if _, err := t.teamRp.GetTeam(ctx, teamID); err != nil {
+		return nil, err
+	}
+	return t.gameRp.GetGamesByTeam(ctx, teamID)
+}
+
+func (t *TeamUC) validate(ctx context.Context, team *entity.Team) error {
+	if team.League == "" {
+		return nil
+	}
+	return checkLeagueReference(ctx, t.leagueRp, "league", team.League)
+}
@@ -0,0 +1,178 @@
+package chouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// flushTimeout - сколько ждать одну вставку пачки; вставка не зависит от контекста запросов, чьи строки в ней
+const flushTimeout = 30 * time.Second
+
+// ErrBatchWriterClosed - писатель уже остановлен и новые строки не принимает
+var ErrBatchWriterClosed = errors.New("clickhouse batch writer is closed")
+
+// batchRow - строка в очереди и канал, в который придёт результат её вставки
+type batchRow struct {
+	args []interface{}
+	done chan error
+}
+
+// BatchWriter - копит строки одного INSERT и вставляет их пачкой: по batch_size строк или раз в flush_interval.
+// Очередь ограничена queue_size строками, при заполненной очереди Write ждёт, пока писатель её разгрузит
+type BatchWriter struct {
+	ch       *Chouse
+	query    string
+	size     int
+	interval time.Duration
+	// insert - вставка одной пачки, возвращает строки, добавленные в блок, и ошибку самой вставки
+	insert func(ctx context.Context, batch []*batchRow) ([]*batchRow, error)
+
+	// mu - Close ждёт, пока Write, уже ставящие строки в очередь, закончат, и только потом закрывает очередь
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *batchRow
+	done   chan struct{}
+}
+
+// NewBatchWriter - запускает писатель для запроса вида INSERT INTO table (columns) VALUES (?, ...)
+func (ch *Chouse) NewBatchWriter(query string) *BatchWriter {
+	w := &BatchWriter{
+		ch:       ch,
+		query:    query,
+		size:     ch.cfg.BatchSize,
+		interval: ch.cfg.FlushInterval,
+		queue:    make(chan *batchRow, ch.cfg.QueueSize),
+		done:     make(chan struct{}),
+	}
+	w.insert = w.insertBlock
+	go w.run()
+	return w
+}
+
+// Write - ставит строки в очередь и ждёт их вставки. Ошибки возвращаются по строкам, в порядке rows.
+// ctx ограничивает только постановку в очередь: строки, которые не успели в неё попасть, получают ошибку контекста
+// и точно не вставлены. Результат попавших в очередь строк дожидается всегда - иначе вставленная строка
+// выглядела бы упавшей и повтор запроса задвоил бы её; ожидание ограничено flush_interval и flushTimeout
+func (w *BatchWriter) Write(ctx context.Context, rows [][]interface{}) []error {
+	errs := make([]error, len(rows))
+	pending := make([]*batchRow, len(rows))
+
+	w.mu.RLock()
+	for i, args := range rows {
+		if w.closed {
+			errs[i] = ErrBatchWriterClosed
+			continue
+		}
+		row := &batchRow{args: args, done: make(chan error, 1)}
+		select {
+		case w.queue <- row:
+			pending[i] = row
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+		}
+	}
+	w.mu.RUnlock()
+
+	for i, row := range pending {
+		if row != nil {
+			errs[i] = <-row.done
+		}
+	}
+
+	return errs
+}
+
+// Close - перестаёт принимать строки и ждёт вставки всего, что уже в очереди
+func (w *BatchWriter) Close(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("flush pending rows: %w", ctx.Err())
+	}
+}
+
+func (w *BatchWriter) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	batch := make([]*batchRow, 0, w.size)
+	for {
+		select {
+		case row, ok := <-w.queue:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+			batch = append(batch, row)
+			if len(batch) >= w.size {
+				w.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) != 0 {
+				w.flush(batch)
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+// flush - вставляет пачку одним блоком: драйвер копит строки подготовленного запроса до Commit.
+// Строка, которую драйвер не смог преобразовать, получает свою ошибку и не мешает остальным
+func (w *BatchWriter) flush(batch []*batchRow) {
+	if len(batch) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	appended, err := w.insert(ctx, batch)
+	for _, row := range appended {
+		row.done <- err
+	}
+}
+
+// insertBlock - одна транзакция драйвера на пачку
+func (w *BatchWriter) insertBlock(ctx context.Context, batch []*batchRow) ([]*batchRow, error) {
+	tx, err := w.ch.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return batch, fmt.Errorf("ошибка при открытии пачки: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, w.query)
+	if err != nil {
+		return batch, fmt.Errorf("ошибка при подготовке пачки: %w", err)
+	}
+	defer stmt.Close()
+
+	appended := batch[:0:0]
+	for _, row := range batch {
+		if _, err = stmt.ExecContext(ctx, row.args...); err != nil {
+			row.done <- fmt.Errorf("ошибка при добавлении строки в пачку: %w", err)
+			continue
+		}
+		appended = append(appended, row)
+	}
+	if len(appended) == 0 {
+		return nil, nil
+	}
+
+	if err = tx.Commit(); err != nil {
+		return appended, fmt.Errorf("ошибка при вставке пачки: %w", err)
+	}
+	return appended, nil
+}
@@ -0,0 +1,205 @@
+package chouse
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeInsert - вставка без базы: запоминает пачки и отвечает err на каждую
+type fakeInsert struct {
+	mu      sync.Mutex
+	batches [][]interface{}
+	err     error
+}
+
+func (f *fakeInsert) insert(_ context.Context, batch []*batchRow) ([]*batchRow, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	values := make([]interface{}, 0, len(batch))
+	for _, row := range batch {
+		values = append(values, row.args[0])
+	}
+	f.batches = append(f.batches, values)
+	return batch, f.err
+}
+
+func (f *fakeInsert) flushed() [][]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][]interface{}(nil), f.batches...)
+}
+
+// newTestBatchWriter - писатель без базы; run запускает сам тест
+func newTestBatchWriter(size int, interval time.Duration, insert func(context.Context, []*batchRow) ([]*batchRow, error)) *BatchWriter {
+	return &BatchWriter{
+		size:     size,
+		interval: interval,
+		insert:   insert,
+		queue:    make(chan *batchRow, 16),
+		done:     make(chan struct{}),
+	}
+}
+
+func startBatchWriter(size int, interval time.Duration, insert func(context.Context, []*batchRow) ([]*batchRow, error)) *BatchWriter {
+	w := newTestBatchWriter(size, interval, insert)
+	go w.run()
+	return w
+}
+
+func rowsOf(values ...interface{}) [][]interface{} {
+	rows := make([][]interface{}, 0, len(values))
+	for _, v := range values {
+		rows = append(rows, []interface{}{v})
+	}
+	return rows
+}
+
+// writeAsync - Write в отдельной горутине; тест ждёт результат с ограничением по времени
+func writeAsync(ctx context.Context, w *BatchWriter, rows [][]interface{}) <-chan []error {
+	result := make(chan []error, 1)
+	go func() { result <- w.Write(ctx, rows) }()
+	return result
+}
+
+func waitErrors(t *testing.T, result <-chan []error) []error {
+	t.Helper()
+	select {
+	case errs := <-result:
+		return errs
+	case <-time.After(2 * time.Second):
+		t.Fatal("Write did not return")
+		return nil
+	}
+}
+
+func waitQueued(t *testing.T, w *BatchWriter, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for len(w.queue) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("queue has %d rows, want %d", len(w.queue), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func closeWriter(t *testing.T, w *BatchWriter) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := w.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func TestBatchWriterFlushesBySize(t *testing.T) {
+	fake := &fakeInsert{}
+	w := startBatchWriter(2, time.Hour, fake.insert)
+	defer closeWriter(t, w)
+
+	errs := waitErrors(t, writeAsync(context.Background(), w, rowsOf(1, 2, 3, 4)))
+
+	if !reflect.DeepEqual(errs, make([]error, 4)) {
+		t.Errorf("Write() errors = %v, want none", errs)
+	}
+	want := [][]interface{}{{1, 2}, {3, 4}}
+	if got := fake.flushed(); !reflect.DeepEqual(got, want) {
+		t.Errorf("flushed batches = %v, want %v", got, want)
+	}
+}
+
+func TestBatchWriterFlushesByInterval(t *testing.T) {
+	fake := &fakeInsert{}
+	w := startBatchWriter(100, 10*time.Millisecond, fake.insert)
+	defer closeWriter(t, w)
+
+	errs := waitErrors(t, writeAsync(context.Background(), w, rowsOf(1, 2, 3)))
+
+	if !reflect.DeepEqual(errs, make([]error, 3)) {
+		t.Errorf("Write() errors = %v, want none", errs)
+	}
+	want := [][]interface{}{{1, 2, 3}}
+	if got := fake.flushed(); !reflect.DeepEqual(got, want) {
+		t.Errorf("flushed batches = %v, want %v", got, want)
+	}
+}
+
+func TestBatchWriterFlushesOnClose(t *testing.T) {
+	fake := &fakeInsert{}
+	w := newTestBatchWriter(100, time.Hour, fake.insert)
+
+	// ни размер, ни интервал не наступят: строки вставит только Close
+	result := writeAsync(context.Background(), w, rowsOf(1, 2))
+	waitQueued(t, w, 2)
+	go w.run()
+	closeWriter(t, w)
+
+	if errs := waitErrors(t, result); !reflect.DeepEqual(errs, make([]error, 2)) {
+		t.Errorf("Write() errors = %v, want none", errs)
+	}
+	want := [][]interface{}{{1, 2}}
+	if got := fake.flushed(); !reflect.DeepEqual(got, want) {
+		t.Errorf("flushed batches = %v, want %v", got, want)
+	}
+
+	errs := w.Write(context.Background(), rowsOf(3))
+	if !errors.Is(errs[0], ErrBatchWriterClosed) {
+		t.Errorf("Write() after Close error = %v, want %v", errs[0], ErrBatchWriterClosed)
+	}
+}
+
+func TestBatchWriterReportsErrorsPerRow(t *testing.T) {
+	errRow := errors.New("bad row")
+	errInsert := errors.New("insert failed")
+	insert := func(_ context.Context, batch []*batchRow) ([]*batchRow, error) {
+		appended := batch[:0:0]
+		for _, row := range batch {
+			if row.args[0] == "bad" {
+				row.done <- errRow
+				continue
+			}
+			appended = append(appended, row)
+		}
+		return appended, errInsert
+	}
+	w := startBatchWriter(3, time.Hour, insert)
+	defer closeWriter(t, w)
+
+	errs := waitErrors(t, writeAsync(context.Background(), w, rowsOf("ok", "bad", "ok")))
+
+	want := []error{errInsert, errRow, errInsert}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("Write() errors = %v, want %v", errs, want)
+	}
+}
+
+func TestBatchWriterWaitsForQueuedRowsAfterCancel(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	fake := &fakeInsert{}
+	insert := func(ctx context.Context, batch []*batchRow) ([]*batchRow, error) {
+		close(started)
+		<-release
+		return fake.insert(ctx, batch)
+	}
+	w := startBatchWriter(2, time.Hour, insert)
+	defer closeWriter(t, w)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := writeAsync(ctx, w, rowsOf(1, 2))
+	<-started
+	cancel()
+	close(release)
+
+	// строки уже в очереди: отмена контекста не должна выдавать их за невставленные
+	if errs := waitErrors(t, result); !reflect.DeepEqual(errs, make([]error, 2)) {
+		t.Errorf("Write() errors = %v, want none", errs)
+	}
+	if got := fake.flushed(); len(got) != 1 {
+		t.Errorf("flushed %d batches, want 1", len(got))
+	}
+}
@@ -5,54 +5,111 @@ import (
 	"database/sql"
 	"fmt"
 
-	// Добавляем драйвер для ClickHouse
-	_ "github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/romeros69/basket/config"
 )
 
 type Chouse struct {
 	DB *sql.DB
+	// cfg - режим кластера и параметры пакетной вставки для NewBatchWriter
+	cfg config.ClickHouse
 }
 
+// New - подключается и приводит схему к последней версии; при выключенном auto_migrate только проверяет,
+// что все миграции применены
 func New(cfg *config.Config) (*Chouse, error) {
-	// Используем DSN для ClickHouse
-	db, err := sql.Open("clickhouse", cfg.ClickHouse.ClickHouseURL) // Указываем драйвер "clickhouse"
+	ch, err := Open(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при подключении к ClickHouse: %w", err)
+		return nil, err
 	}
 
-	// Проверяем подключение
-	if err = db.Ping(); err != nil {
-		return nil, fmt.Errorf("ошибка при проверке соединения с ClickHouse: %w", err)
+	migrator, err := ch.NewMigrator()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при чтении миграций: %w", err)
+	}
+	if cfg.ClickHouse.AutoMigrate {
+		if _, err = migrator.Up(context.Background()); err != nil {
+			return nil, fmt.Errorf("ошибка при миграции схемы: %w", err)
+		}
+		return ch, nil
 	}
 
-	// Создание таблицы
-	err = createTable(context.Background(), db)
+	pending, err := migrator.Pending(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("ошибка при создании таблицы: %w", err)
+		return nil, fmt.Errorf("ошибка при проверке миграций: %w", err)
+	}
+	if len(pending) != 0 {
+		return nil, fmt.Errorf("%w: %d pending, first is %d %s", ErrSchemaOutdated, len(pending), pending[0].Version, pending[0].Name)
 	}
 
-	return &Chouse{DB: db}, nil
+	return ch, nil
 }
 
-// Функция для создания таблицы в ClickHouse
-func createTable(ctx context.Context, db *sql.DB) error {
-	createTableQuery := `
-		CREATE TABLE IF NOT EXISTS player_stats
-		(
-			player_id        String,  -- Идентификатор игрока
-			match_id         Int,     -- Идентификатор матча
-			goals            Int,     -- Количество забитых голов
-			assists          Int,     -- Количество передач
-			interceptions    Int,     -- Количество перехватов
-			rebounds         Int      -- Количество подборов
-		) 
-		ENGINE = MergeTree()
-		ORDER BY (player_id, match_id)
-	`
-	_, err := db.ExecContext(ctx, createTableQuery)
+// Open - только подключается, схему не трогает
+func Open(cfg *config.Config) (*Chouse, error) {
+	options, err := clickhouse.ParseDSN(cfg.ClickHouse.ClickHouseURL)
 	if err != nil {
-		return fmt.Errorf("ошибка при создании таблицы: %w", err)
+		return nil, fmt.Errorf("ошибка в clickhouse_url: %w", err)
+	}
+	if cfg.ClickHouse.ClusterMode {
+		// вставка в Distributed-таблицу возвращается, когда строки записаны в шарды, а не только в очередь узла;
+		// IN с подзапросом к Distributed-таблице внутри запроса к ней же выполняется один раз на инициаторе;
+		// мутации реплицируемых таблиц с подзапросом к другой таблице ClickHouse по умолчанию запрещает
+		options.Settings["insert_distributed_sync"] = 1
+		options.Settings["distributed_product_mode"] = "global"
+		options.Settings["allow_nondeterministic_mutations"] = 1
+	}
+	db := clickhouse.OpenDB(options)
+
+	// Проверяем подключение
+	if err = db.Ping(); err != nil {
+		return nil, fmt.Errorf("ошибка при проверке соединения с ClickHouse: %w", err)
 	}
-	return nil
+
+	return &Chouse{DB: db, cfg: cfg.ClickHouse}, nil
+}
+
+// Cluster - схема создана для кластера: данные в реплицируемых таблицах name_local за Distributed-таблицами
+func (ch *Chouse) Cluster() bool {
+	return ch.cfg.ClusterMode
 }
+
+// OnCluster - суффикс DDL и мутаций, которые нужно выполнить на всех узлах кластера
+func (ch *Chouse) OnCluster() string {
+	if ch.Cluster() {
+		return " ON CLUSTER '{cluster}'"
+	}
+	return ""
+}
+
+// LocalTable - таблица, в которой лежат строки name. Читать и вставлять нужно через name,
+// а мутации в кластере выполняются над реплицируемыми таблицами узлов
+func (ch *Chouse) LocalTable(name string) string {
+	if ch.Cluster() {
+		return name + "_local"
+	}
+	return name
+}
+
+// SeasonStatsStates - состояния агрегатов box score для пересборки сезонных представлений, как в миграции, создающей их.
+// Имена состояний отличаются от колонок player_stats, иначе ClickHouse подставит псевдоним внутрь sumState
+const SeasonStatsStates = `
+	uniqExactState(toString(match_id)) AS matches,
+	sumState(minutes) AS sum_minutes,
+	sumState(points) AS sum_points,
+	sumState(fgm) AS sum_fgm,
+	sumState(fga) AS sum_fga,
+	sumState(three_pm) AS sum_three_pm,
+	sumState(three_pa) AS sum_three_pa,
+	sumState(ftm) AS sum_ftm,
+	sumState(fta) AS sum_fta,
+	sumState(offensive_rebounds) AS sum_offensive_rebounds,
+	sumState(defensive_rebounds) AS sum_defensive_rebounds,
+	sumState(rebounds) AS sum_rebounds,
+	sumState(assists) AS sum_assists,
+	sumState(steals) AS sum_steals,
+	sumState(blocks) AS sum_blocks,
+	sumState(turnovers) AS sum_turnovers,
+	sumState(personal_fouls) AS sum_personal_fouls,
+	sumState(plus_minus) AS sum_plus_minus
+`
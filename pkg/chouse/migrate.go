@@ -0,0 +1,368 @@
+package chouse
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrationFiles - скрипты вида 0001_name.up.sql и 0001_name.down.sql, отдельно для одного узла (single)
+// и для кластера (cluster). У наборов свои версии и свои журналы: schema_migrations и schema_migrations_cluster.
+// Применённый up-скрипт менять нельзя: контрольная сумма в журнале не совпадёт, и миграции остановятся
+//
+//go:embed migrations/single/*.sql migrations/cluster/*.sql
+var migrationFiles embed.FS
+
+var migrationName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// ErrSchemaOutdated - в базе применены не все миграции
+var ErrSchemaOutdated = errors.New("clickhouse schema is outdated, run migrate up")
+
+// ErrSchemaModeMismatch - на узле схема другого режима: cluster_mode не совпадает с тем, в котором она создана
+var ErrSchemaModeMismatch = errors.New("clickhouse schema was created for another cluster_mode")
+
+const (
+	singleJournal  = "schema_migrations"
+	clusterJournal = "schema_migrations_cluster"
+)
+
+// Migration - версия схемы: up применяет изменения, down их откатывает
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // sha256 up-скрипта
+}
+
+// MigrationStatus - миграция и её состояние в базе
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// appliedMigration - последняя запись журнала по версии
+type appliedMigration struct {
+	name      string
+	checksum  string
+	applied   bool
+	changedAt time.Time
+}
+
+// Migrator - применяет и откатывает миграции по порядку версий. У ClickHouse нет транзакций для DDL:
+// если скрипт упал посередине, версия не записывается, а повтор выполняет скрипт заново, поэтому
+// скрипты пишутся так, чтобы их можно было выполнить повторно. Запускать одновременно из нескольких процессов нельзя.
+// Миграции, пересоздающие таблицу с переносом строк (0005_match_id_string), выполняются при остановленной записи:
+// строки, вставленные между INSERT ... SELECT и EXCHANGE TABLES, остаются в старой таблице и удаляются вместе с ней
+//
+// Схема одного узла не превращается в кластерную сама: кластерные скрипты создают таблицы через IF NOT EXISTS
+// и оставили бы прежнюю player_stats на месте. Поэтому Migrator не работает на узле, где есть журнал другого режима.
+// Переход на cluster_mode: остановить запись, переименовать на прежнем узле schema_migrations, player_stats
+// и таблицы измерений (например, в name_single), удалить сезонные представления, выполнить migrate up
+// в cluster_mode, перелить строки статистики и измерений через Distributed-таблицы
+// (INSERT INTO player_stats SELECT * FROM player_stats_single; сезонные суммы пересчитаются при вставке)
+// и удалить переименованные таблицы
+type Migrator struct {
+	ch         *Chouse
+	migrations []Migration
+}
+
+func (ch *Chouse) NewMigrator() (*Migrator, error) {
+	dir := "migrations/single"
+	if ch.Cluster() {
+		dir = "migrations/cluster"
+	}
+	migrations, err := loadMigrations(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{ch: ch, migrations: migrations}, nil
+}
+
+// loadMigrations - читает встроенные скрипты из dir; у каждой версии должны быть оба скрипта
+func loadMigrations(dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := migrationName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %q", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		script, err := migrationFiles.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has names %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(script)
+			sum := sha256.Sum256(script)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d %s must have both up and down scripts", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Status - все известные миграции с отметкой, применены ли они
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if a, ok := applied[migration.Version]; ok && a.applied {
+			status.Applied, status.AppliedAt = true, a.changedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending - миграции, которые ещё не применены
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if a, ok := applied[migration.Version]; !ok || !a.applied {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up - применяет все неприменённые миграции по возрастанию версий
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range pending {
+		if err = m.exec(ctx, migration.Version, migration.Name, "up", migration.Up); err != nil {
+			return done, err
+		}
+		if err = m.record(ctx, migration, true); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down - откатывает steps последних применённых миграций
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if a, ok := applied[migration.Version]; !ok || !a.applied {
+			continue
+		}
+		if err = m.exec(ctx, migration.Version, migration.Name, "down", migration.Down); err != nil {
+			return done, err
+		}
+		if err = m.record(ctx, migration, false); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// journal - таблица журнала миграций и журнал другого режима
+func (m *Migrator) journal() (own, other string) {
+	if m.ch.Cluster() {
+		return clusterJournal, singleJournal
+	}
+	return singleJournal, clusterJournal
+}
+
+// checkMode - на узле не должно быть журнала другого режима, см. Migrator
+func (m *Migrator) checkMode(ctx context.Context) error {
+	_, other := m.journal()
+	var count uint64
+	err := m.ch.DB.QueryRowContext(ctx,
+		"SELECT count() FROM system.tables WHERE database = currentDatabase() AND name = ?", other).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("ошибка при проверке журнала миграций: %w", err)
+	}
+	if count != 0 {
+		return fmt.Errorf("%w: table %s exists, convert the schema before switching cluster_mode", ErrSchemaModeMismatch, other)
+	}
+	return nil
+}
+
+// applied - состояние миграций в базе. Сверяет контрольные суммы: применённый скрипт не должен меняться,
+// а версия из базы должна быть известна этой сборке
+func (m *Migrator) applied(ctx context.Context) (map[int]appliedMigration, error) {
+	if err := m.checkMode(ctx); err != nil {
+		return nil, err
+	}
+
+	// в кластере журнал общий для всех узлов: путь реплики без {shard}, поэтому все узлы - реплики одной таблицы
+	journal, _ := m.journal()
+	engine := "ReplacingMergeTree(changed_at)"
+	if m.ch.Cluster() {
+		engine = "ReplicatedReplacingMergeTree('/clickhouse/tables/{database}/" + journal + "', '{replica}', changed_at)"
+	}
+	_, err := m.ch.DB.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS `+journal+m.ch.OnCluster()+`
+		(
+			version    UInt32,
+			name       String,
+			checksum   String,
+			applied    UInt8,
+			changed_at DateTime64(3)
+		)
+		ENGINE = `+engine+`
+		ORDER BY version
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при создании %s: %w", journal, err)
+	}
+
+	// последняя запись по версии: откат добавляет строку с applied = 0
+	rows, err := m.ch.DB.QueryContext(ctx, `
+		SELECT version, argMax(name, changed_at), argMax(checksum, changed_at),
+		       argMax(applied, changed_at), max(changed_at)
+		FROM `+journal+`
+		GROUP BY version
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при чтении %s: %w", journal, err)
+	}
+	defer rows.Close()
+
+	known := make(map[int]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	applied := make(map[int]appliedMigration)
+	for rows.Next() {
+		var (
+			version uint32
+			a       appliedMigration
+			flag    uint8
+		)
+		if err = rows.Scan(&version, &a.name, &a.checksum, &flag, &a.changedAt); err != nil {
+			return nil, err
+		}
+		a.applied = flag == 1
+		if !a.applied {
+			applied[int(version)] = a
+			continue
+		}
+
+		migration, ok := known[int(version)]
+		if !ok {
+			return nil, fmt.Errorf("migration %d %s is applied, but unknown to this build", version, a.name)
+		}
+		if migration.Checksum != a.checksum {
+			return nil, fmt.Errorf("migration %d %s was changed after it had been applied", version, a.name)
+		}
+		applied[int(version)] = a
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+// exec - выполняет скрипт по одному запросу: ClickHouse не принимает несколько запросов за раз
+func (m *Migrator) exec(ctx context.Context, version int, name, direction, script string) error {
+	for i, statement := range splitStatements(script) {
+		if _, err := m.ch.DB.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("migration %d %s %s, statement %d: %w", version, name, direction, i+1, err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) record(ctx context.Context, migration Migration, applied bool) error {
+	var flag uint8
+	if applied {
+		flag = 1
+	}
+	journal, _ := m.journal()
+	_, err := m.ch.DB.ExecContext(ctx,
+		"INSERT INTO "+journal+" (version, name, checksum, applied, changed_at) VALUES (?, ?, ?, ?, ?)",
+		uint32(migration.Version), migration.Name, migration.Checksum, flag, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("ошибка при записи миграции %d: %w", migration.Version, err)
+	}
+	return nil
+}
+
+// splitStatements - запросы скрипта разделены точкой с запятой в конце строки; строки-комментарии пропускаются
+func splitStatements(script string) []string {
+	var (
+		statements []string
+		current    strings.Builder
+	)
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statement := strings.TrimSuffix(strings.TrimSpace(current.String()), ";")
+			statements = append(statements, statement)
+			current.Reset()
+		}
+	}
+	if statement := strings.TrimSpace(current.String()); statement != "" {
+		statements = append(statements, statement)
+	}
+	return statements
+}
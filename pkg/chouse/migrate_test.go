@@ -0,0 +1,85 @@
+package chouse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "empty script",
+			script: "",
+			want:   nil,
+		},
+		{
+			name:   "only comments and blank lines",
+			script: "-- comment\n\n   -- indented comment\n",
+			want:   nil,
+		},
+		{
+			name:   "single statement with trailing semicolon",
+			script: "DROP TABLE t;\n",
+			want:   []string{"DROP TABLE t"},
+		},
+		{
+			name:   "last statement without semicolon",
+			script: "DROP TABLE a;\nDROP TABLE b",
+			want:   []string{"DROP TABLE a", "DROP TABLE b"},
+		},
+		{
+			name:   "multiline statement keeps its lines",
+			script: "CREATE TABLE t\n(\n    id String\n)\nENGINE = MergeTree()\nORDER BY id;\n",
+			want:   []string{"CREATE TABLE t\n(\n    id String\n)\nENGINE = MergeTree()\nORDER BY id"},
+		},
+		{
+			name:   "comments between and inside statements are dropped",
+			script: "-- header\nDROP VIEW v;\n-- rebuild\nCREATE TABLE t\n-- columns\n(id String)\nORDER BY id;\n",
+			want:   []string{"DROP VIEW v", "CREATE TABLE t\n(id String)\nORDER BY id"},
+		},
+		{
+			name:   "semicolon inside a line does not split",
+			script: "SELECT ';' AS s, 1\nFROM t;\n",
+			want:   []string{"SELECT ';' AS s, 1\nFROM t"},
+		},
+		{
+			name:   "trailing spaces after semicolon",
+			script: "DROP TABLE a;   \nDROP TABLE b;\t\n",
+			want:   []string{"DROP TABLE a", "DROP TABLE b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitStatements(tt.script); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	for _, dir := range []string{"migrations/single", "migrations/cluster"} {
+		t.Run(dir, func(t *testing.T) {
+			migrations, err := loadMigrations(dir)
+			if err != nil {
+				t.Fatalf("loadMigrations() error = %v", err)
+			}
+			if len(migrations) == 0 {
+				t.Fatal("loadMigrations() returned no migrations")
+			}
+			for i, migration := range migrations {
+				if i > 0 && migration.Version <= migrations[i-1].Version {
+					t.Errorf("migration %d goes after %d", migration.Version, migrations[i-1].Version)
+				}
+				if len(splitStatements(migration.Up)) == 0 || len(splitStatements(migration.Down)) == 0 {
+					t.Errorf("migration %d %s has an empty script", migration.Version, migration.Name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+-- SYNC - метаданные реплик удаляются из Keeper сразу, и схему можно создать заново
+DROP TABLE IF EXISTS awards_dim ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS awards_dim_local ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS leagues_dim ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS leagues_dim_local ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS games_dim ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS games_dim_local ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS players_dim ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS players_dim_local ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS team_season_stats ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS team_season_stats_mv ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS team_season_stats_local ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS player_season_stats ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS player_season_stats_mv ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS player_season_stats_local ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS player_stats ON CLUSTER '{cluster}' SYNC;
+DROP TABLE IF EXISTS player_stats_local ON CLUSTER '{cluster}' SYNC;
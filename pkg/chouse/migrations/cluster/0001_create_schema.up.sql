@@ -0,0 +1,216 @@
+-- Схема для кластера: данные лежат в реплицируемых таблицах name_local на каждом узле, запросы идут
+-- через Distributed-таблицы с прежними именами. Статистика и сезонные суммы игрока шардируются по игроку,
+-- измерения - по id, чтобы все версии строки измерения попали в один шард. Суммы команды считаются
+-- из player_stats_local на шарде игрока, поэтому состояния одной команды лежат на разных шардах:
+-- чтения собирают их через -Merge и GROUP BY по Distributed-таблице
+
+CREATE TABLE IF NOT EXISTS player_stats_local ON CLUSTER '{cluster}'
+(
+    player_id          String,
+    match_id           String,
+    goals              Int,
+    assists            Int,
+    interceptions      Int,
+    rebounds           Int,
+    fgm                Int,
+    fga                Int,
+    three_pm           Int,
+    three_pa           Int,
+    ftm                Int,
+    fta                Int,
+    offensive_rebounds Int,
+    defensive_rebounds Int,
+    steals             Int,
+    blocks             Int,
+    turnovers          Int,
+    personal_fouls     Int,
+    minutes            Float64,
+    plus_minus         Int,
+    points             Int,
+    team_id            String,
+    game_date          Date,
+    season             String,
+    league_id          String
+)
+ENGINE = ReplicatedMergeTree('/clickhouse/tables/{shard}/{database}/player_stats_local', '{replica}')
+ORDER BY (player_id, match_id);
+
+CREATE TABLE IF NOT EXISTS player_stats ON CLUSTER '{cluster}' AS player_stats_local
+ENGINE = Distributed('{cluster}', currentDatabase(), 'player_stats_local', cityHash64(player_id));
+
+-- Материализованное представление пишет в реплицируемую таблицу узла, на который пришла вставка;
+-- реплики получают готовые куски, а не пересчитывают их
+CREATE TABLE IF NOT EXISTS player_season_stats_local ON CLUSTER '{cluster}'
+(
+    season                 String,
+    league_id              String,
+    team_id                String,
+    player_id              String,
+    matches                AggregateFunction(uniqExact, String),
+    sum_minutes            AggregateFunction(sum, Float64),
+    sum_points             AggregateFunction(sum, Int),
+    sum_fgm                AggregateFunction(sum, Int),
+    sum_fga                AggregateFunction(sum, Int),
+    sum_three_pm           AggregateFunction(sum, Int),
+    sum_three_pa           AggregateFunction(sum, Int),
+    sum_ftm                AggregateFunction(sum, Int),
+    sum_fta                AggregateFunction(sum, Int),
+    sum_offensive_rebounds AggregateFunction(sum, Int),
+    sum_defensive_rebounds AggregateFunction(sum, Int),
+    sum_rebounds           AggregateFunction(sum, Int),
+    sum_assists            AggregateFunction(sum, Int),
+    sum_steals             AggregateFunction(sum, Int),
+    sum_blocks             AggregateFunction(sum, Int),
+    sum_turnovers          AggregateFunction(sum, Int),
+    sum_personal_fouls     AggregateFunction(sum, Int),
+    sum_plus_minus         AggregateFunction(sum, Int)
+)
+ENGINE = ReplicatedAggregatingMergeTree('/clickhouse/tables/{shard}/{database}/player_season_stats_local', '{replica}')
+ORDER BY (season, league_id, team_id, player_id);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS player_season_stats_mv ON CLUSTER '{cluster}' TO player_season_stats_local
+AS SELECT season, league_id, team_id, player_id,
+    uniqExactState(toString(match_id)) AS matches,
+    sumState(minutes) AS sum_minutes,
+    sumState(points) AS sum_points,
+    sumState(fgm) AS sum_fgm,
+    sumState(fga) AS sum_fga,
+    sumState(three_pm) AS sum_three_pm,
+    sumState(three_pa) AS sum_three_pa,
+    sumState(ftm) AS sum_ftm,
+    sumState(fta) AS sum_fta,
+    sumState(offensive_rebounds) AS sum_offensive_rebounds,
+    sumState(defensive_rebounds) AS sum_defensive_rebounds,
+    sumState(rebounds) AS sum_rebounds,
+    sumState(assists) AS sum_assists,
+    sumState(steals) AS sum_steals,
+    sumState(blocks) AS sum_blocks,
+    sumState(turnovers) AS sum_turnovers,
+    sumState(personal_fouls) AS sum_personal_fouls,
+    sumState(plus_minus) AS sum_plus_minus
+FROM player_stats_local
+GROUP BY season, league_id, team_id, player_id;
+
+CREATE TABLE IF NOT EXISTS player_season_stats ON CLUSTER '{cluster}' AS player_season_stats_local
+ENGINE = Distributed('{cluster}', currentDatabase(), 'player_season_stats_local', cityHash64(player_id));
+
+-- Материализованное представление пишет в реплицируемую таблицу узла, на который пришла вставка;
+-- реплики получают готовые куски, а не пересчитывают их
+CREATE TABLE IF NOT EXISTS team_season_stats_local ON CLUSTER '{cluster}'
+(
+    season                 String,
+    league_id              String,
+    team_id                String,
+    matches                AggregateFunction(uniqExact, String),
+    sum_minutes            AggregateFunction(sum, Float64),
+    sum_points             AggregateFunction(sum, Int),
+    sum_fgm                AggregateFunction(sum, Int),
+    sum_fga                AggregateFunction(sum, Int),
+    sum_three_pm           AggregateFunction(sum, Int),
+    sum_three_pa           AggregateFunction(sum, Int),
+    sum_ftm                AggregateFunction(sum, Int),
+    sum_fta                AggregateFunction(sum, Int),
+    sum_offensive_rebounds AggregateFunction(sum, Int),
+    sum_defensive_rebounds AggregateFunction(sum, Int),
+    sum_rebounds           AggregateFunction(sum, Int),
+    sum_assists            AggregateFunction(sum, Int),
+    sum_steals             AggregateFunction(sum, Int),
+    sum_blocks             AggregateFunction(sum, Int),
+    sum_turnovers          AggregateFunction(sum, Int),
+    sum_personal_fouls     AggregateFunction(sum, Int),
+    sum_plus_minus         AggregateFunction(sum, Int)
+)
+ENGINE = ReplicatedAggregatingMergeTree('/clickhouse/tables/{shard}/{database}/team_season_stats_local', '{replica}')
+ORDER BY (season, league_id, team_id);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS team_season_stats_mv ON CLUSTER '{cluster}' TO team_season_stats_local
+AS SELECT season, league_id, team_id,
+    uniqExactState(toString(match_id)) AS matches,
+    sumState(minutes) AS sum_minutes,
+    sumState(points) AS sum_points,
+    sumState(fgm) AS sum_fgm,
+    sumState(fga) AS sum_fga,
+    sumState(three_pm) AS sum_three_pm,
+    sumState(three_pa) AS sum_three_pa,
+    sumState(ftm) AS sum_ftm,
+    sumState(fta) AS sum_fta,
+    sumState(offensive_rebounds) AS sum_offensive_rebounds,
+    sumState(defensive_rebounds) AS sum_defensive_rebounds,
+    sumState(rebounds) AS sum_rebounds,
+    sumState(assists) AS sum_assists,
+    sumState(steals) AS sum_steals,
+    sumState(blocks) AS sum_blocks,
+    sumState(turnovers) AS sum_turnovers,
+    sumState(personal_fouls) AS sum_personal_fouls,
+    sumState(plus_minus) AS sum_plus_minus
+FROM player_stats_local
+GROUP BY season, league_id, team_id;
+
+-- Ключ шардирования нужен только для строк, которые вставляются через Distributed-таблицу при пересборке
+-- после удаления статистики; привязки команды к шарду нет, поэтому строки раскладываются случайно
+CREATE TABLE IF NOT EXISTS team_season_stats ON CLUSTER '{cluster}' AS team_season_stats_local
+ENGINE = Distributed('{cluster}', currentDatabase(), 'team_season_stats_local', rand());
+
+CREATE TABLE IF NOT EXISTS players_dim_local ON CLUSTER '{cluster}'
+(
+    id          String,
+    name        String,
+    surname     String,
+    team_id     String,
+    role        String,
+    citizenship String,
+    version     Int64,
+    deleted     UInt8
+)
+ENGINE = ReplicatedReplacingMergeTree('/clickhouse/tables/{shard}/{database}/players_dim_local', '{replica}', version)
+ORDER BY id;
+
+CREATE TABLE IF NOT EXISTS players_dim ON CLUSTER '{cluster}' AS players_dim_local
+ENGINE = Distributed('{cluster}', currentDatabase(), 'players_dim_local', cityHash64(id));
+
+CREATE TABLE IF NOT EXISTS games_dim_local ON CLUSTER '{cluster}'
+(
+    id                String,
+    first_team        String,
+    second_team       String,
+    game_date         Date,
+    league_id         String,
+    status            String,
+    first_team_score  Int,
+    second_team_score Int,
+    version           Int64,
+    deleted           UInt8
+)
+ENGINE = ReplicatedReplacingMergeTree('/clickhouse/tables/{shard}/{database}/games_dim_local', '{replica}', version)
+ORDER BY id;
+
+CREATE TABLE IF NOT EXISTS games_dim ON CLUSTER '{cluster}' AS games_dim_local
+ENGINE = Distributed('{cluster}', currentDatabase(), 'games_dim_local', cityHash64(id));
+
+CREATE TABLE IF NOT EXISTS leagues_dim_local ON CLUSTER '{cluster}'
+(
+    id      String,
+    name    String,
+    season  String,
+    version Int64,
+    deleted UInt8
+)
+ENGINE = ReplicatedReplacingMergeTree('/clickhouse/tables/{shard}/{database}/leagues_dim_local', '{replica}', version)
+ORDER BY id;
+
+CREATE TABLE IF NOT EXISTS leagues_dim ON CLUSTER '{cluster}' AS leagues_dim_local
+ENGINE = Distributed('{cluster}', currentDatabase(), 'leagues_dim_local', cityHash64(id));
+
+CREATE TABLE IF NOT EXISTS awards_dim_local ON CLUSTER '{cluster}'
+(
+    id          String,
+    title       String,
+    description String,
+    version     Int64,
+    deleted     UInt8
+)
+ENGINE = ReplicatedReplacingMergeTree('/clickhouse/tables/{shard}/{database}/awards_dim_local', '{replica}', version)
+ORDER BY id;
+
+CREATE TABLE IF NOT EXISTS awards_dim ON CLUSTER '{cluster}' AS awards_dim_local
+ENGINE = Distributed('{cluster}', currentDatabase(), 'awards_dim_local', cityHash64(id));
@@ -0,0 +1,12 @@
+-- Исходная таблица статистики игроков. IF NOT EXISTS - у баз, созданных до миграций, таблица уже есть
+CREATE TABLE IF NOT EXISTS player_stats
+(
+    player_id        String,  -- Идентификатор игрока
+    match_id         Int,     -- Идентификатор матча
+    goals            Int,     -- Количество забитых голов
+    assists          Int,     -- Количество передач
+    interceptions    Int,     -- Количество перехватов
+    rebounds         Int      -- Количество подборов
+)
+ENGINE = MergeTree()
+ORDER BY (player_id, match_id);
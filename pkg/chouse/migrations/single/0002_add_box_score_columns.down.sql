@@ -0,0 +1,19 @@
+ALTER TABLE player_stats DROP COLUMN IF EXISTS league_id;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS season;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS game_date;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS team_id;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS points;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS plus_minus;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS minutes;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS personal_fouls;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS turnovers;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS blocks;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS steals;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS defensive_rebounds;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS offensive_rebounds;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS fta;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS ftm;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS three_pa;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS three_pm;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS fga;
+ALTER TABLE player_stats DROP COLUMN IF EXISTS fgm;
@@ -0,0 +1,23 @@
+-- Колонки box score и измерения матча; DEFAULT переносит в них значения старых строк:
+-- goals - забитые броски с игры (очки по ним считаются как за двухочковые), interceptions - перехваты,
+-- все подборы считаются подборами в защите. Попытки бросков у старых строк неизвестны, fga остаётся 0.
+-- Порядок важен: DEFAULT ссылается на уже добавленные колонки
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS fgm                Int DEFAULT goals;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS fga                Int DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS three_pm           Int DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS three_pa           Int DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS ftm                Int DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS fta                Int DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS offensive_rebounds Int DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS defensive_rebounds Int DEFAULT rebounds;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS steals             Int DEFAULT interceptions;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS blocks             Int DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS turnovers          Int DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS personal_fouls     Int DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS minutes            Float64 DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS plus_minus         Int DEFAULT 0;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS points             Int DEFAULT 2 * fgm + three_pm + ftm;
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS team_id            String DEFAULT '';
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS game_date          Date DEFAULT toDate(0);
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS season             String DEFAULT '';
+ALTER TABLE player_stats ADD COLUMN IF NOT EXISTS league_id          String DEFAULT '';
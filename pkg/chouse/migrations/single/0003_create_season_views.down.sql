@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS team_season_stats;
+DROP VIEW IF EXISTS player_season_stats;
@@ -0,0 +1,54 @@
+-- Сезонные суммы игроков и команд. Представления обновляются при каждой вставке в player_stats,
+-- POPULATE переносит строки, вставленные до их создания. Имена состояний отличаются от колонок player_stats,
+-- иначе ClickHouse подставит псевдоним внутрь sumState
+CREATE MATERIALIZED VIEW IF NOT EXISTS player_season_stats
+ENGINE = AggregatingMergeTree()
+ORDER BY (season, league_id, team_id, player_id)
+POPULATE
+AS SELECT season, league_id, team_id, player_id,
+    uniqExactState(toString(match_id)) AS matches,
+    sumState(minutes) AS sum_minutes,
+    sumState(points) AS sum_points,
+    sumState(fgm) AS sum_fgm,
+    sumState(fga) AS sum_fga,
+    sumState(three_pm) AS sum_three_pm,
+    sumState(three_pa) AS sum_three_pa,
+    sumState(ftm) AS sum_ftm,
+    sumState(fta) AS sum_fta,
+    sumState(offensive_rebounds) AS sum_offensive_rebounds,
+    sumState(defensive_rebounds) AS sum_defensive_rebounds,
+    sumState(rebounds) AS sum_rebounds,
+    sumState(assists) AS sum_assists,
+    sumState(steals) AS sum_steals,
+    sumState(blocks) AS sum_blocks,
+    sumState(turnovers) AS sum_turnovers,
+    sumState(personal_fouls) AS sum_personal_fouls,
+    sumState(plus_minus) AS sum_plus_minus
+FROM player_stats
+GROUP BY season, league_id, team_id, player_id;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS team_season_stats
+ENGINE = AggregatingMergeTree()
+ORDER BY (season, league_id, team_id)
+POPULATE
+AS SELECT season, league_id, team_id,
+    uniqExactState(toString(match_id)) AS matches,
+    sumState(minutes) AS sum_minutes,
+    sumState(points) AS sum_points,
+    sumState(fgm) AS sum_fgm,
+    sumState(fga) AS sum_fga,
+    sumState(three_pm) AS sum_three_pm,
+    sumState(three_pa) AS sum_three_pa,
+    sumState(ftm) AS sum_ftm,
+    sumState(fta) AS sum_fta,
+    sumState(offensive_rebounds) AS sum_offensive_rebounds,
+    sumState(defensive_rebounds) AS sum_defensive_rebounds,
+    sumState(rebounds) AS sum_rebounds,
+    sumState(assists) AS sum_assists,
+    sumState(steals) AS sum_steals,
+    sumState(blocks) AS sum_blocks,
+    sumState(turnovers) AS sum_turnovers,
+    sumState(personal_fouls) AS sum_personal_fouls,
+    sumState(plus_minus) AS sum_plus_minus
+FROM player_stats
+GROUP BY season, league_id, team_id;
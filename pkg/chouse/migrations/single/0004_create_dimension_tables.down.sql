@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS awards_dim;
+DROP TABLE IF EXISTS leagues_dim;
+DROP TABLE IF EXISTS games_dim;
+DROP TABLE IF EXISTS players_dim;
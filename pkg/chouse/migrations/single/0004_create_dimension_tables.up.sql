@@ -0,0 +1,54 @@
+-- Измерения каталога, которые заполняет relay outbox. ReplacingMergeTree(version) оставляет по каждому id
+-- строку с наибольшей версией, поэтому повторная или запоздалая вставка события не меняет результат;
+-- читать измерения нужно с FINAL
+CREATE TABLE IF NOT EXISTS players_dim
+(
+    id          String,
+    name        String,
+    surname     String,
+    team_id     String,
+    role        String,
+    citizenship String,
+    version     Int64,
+    deleted     UInt8
+)
+ENGINE = ReplacingMergeTree(version)
+ORDER BY id;
+
+CREATE TABLE IF NOT EXISTS games_dim
+(
+    id                String,
+    first_team        String,
+    second_team       String,
+    game_date         Date,
+    league_id         String,
+    status            String,
+    first_team_score  Int,
+    second_team_score Int,
+    version           Int64,
+    deleted           UInt8
+)
+ENGINE = ReplacingMergeTree(version)
+ORDER BY id;
+
+CREATE TABLE IF NOT EXISTS leagues_dim
+(
+    id      String,
+    name    String,
+    season  String,
+    version Int64,
+    deleted UInt8
+)
+ENGINE = ReplacingMergeTree(version)
+ORDER BY id;
+
+CREATE TABLE IF NOT EXISTS awards_dim
+(
+    id          String,
+    title       String,
+    description String,
+    version     Int64,
+    deleted     UInt8
+)
+ENGINE = ReplacingMergeTree(version)
+ORDER BY id;
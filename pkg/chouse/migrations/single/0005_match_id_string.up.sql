@@ -0,0 +1,96 @@
+-- match_id - hex id матча из mongo, а не число. Тип колонки ключа сортировки нельзя изменить через MODIFY COLUMN,
+-- поэтому таблица пересоздаётся с переносом строк. Сезонные представления привязаны к прежней таблице,
+-- они пересоздаются и заполняются заново
+DROP VIEW IF EXISTS team_season_stats;
+DROP VIEW IF EXISTS player_season_stats;
+DROP TABLE IF EXISTS player_stats_rebuild;
+
+CREATE TABLE player_stats_rebuild
+(
+    player_id          String,
+    match_id           String,
+    goals              Int,
+    assists            Int,
+    interceptions      Int,
+    rebounds           Int,
+    fgm                Int DEFAULT goals,
+    fga                Int DEFAULT 0,
+    three_pm           Int DEFAULT 0,
+    three_pa           Int DEFAULT 0,
+    ftm                Int DEFAULT 0,
+    fta                Int DEFAULT 0,
+    offensive_rebounds Int DEFAULT 0,
+    defensive_rebounds Int DEFAULT rebounds,
+    steals             Int DEFAULT interceptions,
+    blocks             Int DEFAULT 0,
+    turnovers          Int DEFAULT 0,
+    personal_fouls     Int DEFAULT 0,
+    minutes            Float64 DEFAULT 0,
+    plus_minus         Int DEFAULT 0,
+    points             Int DEFAULT 2 * fgm + three_pm + ftm,
+    team_id            String DEFAULT '',
+    game_date          Date DEFAULT toDate(0),
+    season             String DEFAULT '',
+    league_id          String DEFAULT ''
+)
+ENGINE = MergeTree()
+ORDER BY (player_id, match_id);
+
+INSERT INTO player_stats_rebuild
+SELECT * REPLACE (toString(match_id) AS match_id)
+FROM player_stats;
+
+EXCHANGE TABLES player_stats_rebuild AND player_stats;
+DROP TABLE player_stats_rebuild;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS player_season_stats
+ENGINE = AggregatingMergeTree()
+ORDER BY (season, league_id, team_id, player_id)
+POPULATE
+AS SELECT season, league_id, team_id, player_id,
+    uniqExactState(toString(match_id)) AS matches,
+    sumState(minutes) AS sum_minutes,
+    sumState(points) AS sum_points,
+    sumState(fgm) AS sum_fgm,
+    sumState(fga) AS sum_fga,
+    sumState(three_pm) AS sum_three_pm,
+    sumState(three_pa) AS sum_three_pa,
+    sumState(ftm) AS sum_ftm,
+    sumState(fta) AS sum_fta,
+    sumState(offensive_rebounds) AS sum_offensive_rebounds,
+    sumState(defensive_rebounds) AS sum_defensive_rebounds,
+    sumState(rebounds) AS sum_rebounds,
+    sumState(assists) AS sum_assists,
+    sumState(steals) AS sum_steals,
+    sumState(blocks) AS sum_blocks,
+    sumState(turnovers) AS sum_turnovers,
+    sumState(personal_fouls) AS sum_personal_fouls,
+    sumState(plus_minus) AS sum_plus_minus
+FROM player_stats
+GROUP BY season, league_id, team_id, player_id;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS team_season_stats
+ENGINE = AggregatingMergeTree()
+ORDER BY (season, league_id, team_id)
+POPULATE
+AS SELECT season, league_id, team_id,
+    uniqExactState(toString(match_id)) AS matches,
+    sumState(minutes) AS sum_minutes,
+    sumState(points) AS sum_points,
+    sumState(fgm) AS sum_fgm,
+    sumState(fga) AS sum_fga,
+    sumState(three_pm) AS sum_three_pm,
+    sumState(three_pa) AS sum_three_pa,
+    sumState(ftm) AS sum_ftm,
+    sumState(fta) AS sum_fta,
+    sumState(offensive_rebounds) AS sum_offensive_rebounds,
+    sumState(defensive_rebounds) AS sum_defensive_rebounds,
+    sumState(rebounds) AS sum_rebounds,
+    sumState(assists) AS sum_assists,
+    sumState(steals) AS sum_steals,
+    sumState(blocks) AS sum_blocks,
+    sumState(turnovers) AS sum_turnovers,
+    sumState(personal_fouls) AS sum_personal_fouls,
+    sumState(plus_minus) AS sum_plus_minus
+FROM player_stats
+GROUP BY season, league_id, team_id;
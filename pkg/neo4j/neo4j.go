@@ -2,45 +2,88 @@ package neo4j
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
-	"github.com/romeros69/basket/config"
+	appconfig "github.com/romeros69/basket/config"
 )
 
+// Neo4j - драйвер с пулом соединений. Сессии не потокобезопасны, поэтому каждая операция открывает свою сессию.
+// Общий менеджер закладок делает чтение после записи причинно согласованным: чтение на реплике
+// дождётся записей, уже подтверждённых этим процессом
 type Neo4j struct {
-	DB neo4j.SessionWithContext
+	driver    neo4j.DriverWithContext
+	bookmarks neo4j.BookmarkManager
 }
 
+// logLevels - уровни логирования драйвера из конфига
+var logLevels = map[string]log.Level{
+	"error": log.ERROR,
+	"warn":  log.WARNING,
+	"info":  log.INFO,
+	"debug": log.DEBUG,
+}
 
 // Construct a new driver
+func New(cfg *appconfig.Config) (*Neo4j, error) {
+	auth := neo4j.NoAuth()
+	if cfg.Neo4j.Neo4jLogin != "" {
+		auth = neo4j.BasicAuth(cfg.Neo4j.Neo4jLogin, cfg.Neo4j.Neo4jPassword, "")
+	}
 
-func New(cfg *config.Config) (*Neo4j, error) {
-    
-    useConsoleLogger := func(level neo4j.LogLevel) func(config *neo4j.Config) {
-	    return func(config *neo4j.Config) {
-	    	config.Log = log.ToConsole(level)
-	    }
-    }
-
-    driver, err := neo4j.NewDriverWithContext("neo4j://127.0.0.1:7687", neo4j.NoAuth(), useConsoleLogger(log.DEBUG))
+	configurers := []func(*neo4j.Config){}
+	if cfg.Neo4j.Neo4jLogLevel != appconfig.Neo4jLogOff {
+		level, ok := logLevels[cfg.Neo4j.Neo4jLogLevel]
+		if !ok {
+			return nil, fmt.Errorf("unknown neo4j log level %q", cfg.Neo4j.Neo4jLogLevel)
+		}
+		configurers = append(configurers, func(c *neo4j.Config) {
+			c.Log = log.ToConsole(level)
+		})
+	}
 
-	//driver, err := neo4j.NewDriverWithContext(cfg.Neo4j.Neo4jURL, neo4j.BasicAuth(cfg.Neo4j.Neo4jLogin, cfg.Neo4j.Neo4jPassword, ""))
-	//driver, err := neo4j.NewDriverWithContext("neo4j://127.0.0.1:7687", neo4j.BasicAuth(cfg.Neo4j.Neo4jLogin, cfg.Neo4j.Neo4jPassword, ""))
+	driver, err := neo4j.NewDriverWithContext(cfg.Neo4j.Neo4jURL, auth, configurers...)
 	if err != nil {
 		return nil, err
 	}
 
-	err = driver.VerifyConnectivity(context.Background())
-	if err != nil {
-		return nil, fmt.Errorf("error in verify: %w", err)
+	ctx := context.Background()
+	if err = driver.VerifyConnectivity(ctx); err != nil {
+		// драйвер уже держит пул соединений и фоновые горутины маршрутизации
+		return nil, errors.Join(fmt.Errorf("error in verify: %w", err), driver.Close(ctx))
 	}
 
-	// Открываем сессию
-	session := driver.NewSession( context.Background(), neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
-
 	return &Neo4j{
-		DB: session,
+		driver:    driver,
+		bookmarks: neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{}),
 	}, nil
 }
+
+// ExecuteRead - транзакция чтения в отдельной сессии; в кластере запрос уходит на читающий узел
+func (n *Neo4j) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	session := n.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode:      neo4j.AccessModeRead,
+		BookmarkManager: n.bookmarks,
+	})
+	defer session.Close(ctx)
+
+	return session.ExecuteRead(ctx, work)
+}
+
+// ExecuteWrite - транзакция записи в отдельной сессии на лидере кластера
+func (n *Neo4j) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork) (any, error) {
+	session := n.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode:      neo4j.AccessModeWrite,
+		BookmarkManager: n.bookmarks,
+	})
+	defer session.Close(ctx)
+
+	return session.ExecuteWrite(ctx, work)
+}
+
+// Close - закрывает соединения драйвера
+func (n *Neo4j) Close(ctx context.Context) error {
+	return n.driver.Close(ctx)
+}